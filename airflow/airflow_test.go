@@ -86,4 +86,11 @@ func TestInit(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, exist)
 	}
+
+	// deploy reports are kept out of the image and of the repository
+	for _, file := range []string{".dockerignore", ".gitignore"} {
+		data, err := os.ReadFile(filepath.Join(tmpDir, file))
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), ".astro/deploys/")
+	}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/astronomer/astro-cli/airflow/types"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/sbom"
 	"github.com/astronomer/astro-cli/pkg/util"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/docker/client"
@@ -45,6 +46,7 @@ type ImageHandler interface {
 	Push(registry, username, token, remoteImage string) error
 	GetLabel(labelName string) (string, error)
 	ListLabels() (map[string]string, error)
+	ListPackages() ([]sbom.Package, error)
 	TagLocalImage(localImage string) error
 	Run(dagID, envFile, settingsFile, containerName, dagFile string, taskLogs bool) error
 	Pytest(pytestFile, airflowHome, envFile string, pytestArgs []string, config types.ImageBuildConfig) (string, error)
@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/astronomer/astro-cli/pkg/sbom"
 	"github.com/astronomer/astro-cli/pkg/util"
 	cliCommand "github.com/docker/cli/cli/command"
 	cliConfig "github.com/docker/cli/cli/config"
@@ -29,6 +30,7 @@ const (
 	EchoCmd            = "echo"
 	pushingImagePrompt = "Pushing image to Astronomer registry"
 	astroRunContainer  = "astro-run"
+	remoteBuilderName  = "astro-remote"
 )
 
 var errGetImageLabel = errors.New("error getting image label")
@@ -48,6 +50,9 @@ func (d *DockerImage) Build(buildConfig airflowTypes.ImageBuildConfig) error {
 	if err != nil {
 		return err
 	}
+	if buildConfig.Buildx.IsSet() {
+		return d.buildx(dockerCommand, buildConfig)
+	}
 	args := []string{
 		"build",
 		"-t",
@@ -62,14 +67,7 @@ func (d *DockerImage) Build(buildConfig airflowTypes.ImageBuildConfig) error {
 		args = append(args, fmt.Sprintf("--platform=%s", strings.Join(buildConfig.TargetPlatforms, ",")))
 	}
 	// Build image
-	var stdout, stderr io.Writer
-	if buildConfig.Output {
-		stdout = os.Stdout
-		stderr = os.Stderr
-	} else {
-		stdout = nil
-		stderr = nil
-	}
+	stdout, stderr := buildOutput(buildConfig)
 	err = cmdExec(dockerCommand, stdout, stderr, args...)
 	if err != nil {
 		return fmt.Errorf("command 'docker build -t %s failed: %w", d.imageName, err)
@@ -77,6 +75,63 @@ func (d *DockerImage) Build(buildConfig airflowTypes.ImageBuildConfig) error {
 	return err
 }
 
+// buildx builds the image with BuildKit, loading the result into the local image store so it can be inspected and pushed as usual
+func (d *DockerImage) buildx(dockerCommand string, buildConfig airflowTypes.ImageBuildConfig) error {
+	stdout, stderr := buildOutput(buildConfig)
+
+	builder := buildConfig.Buildx.Builder
+	if builder == "" && buildConfig.Buildx.BuilderEndpoint != "" {
+		builder = remoteBuilderName
+		// reuse the remote builder if it was created by a previous build
+		if err := cmdExec(dockerCommand, nil, nil, "buildx", "inspect", builder); err != nil {
+			err = cmdExec(dockerCommand, stdout, stderr, "buildx", "create", "--name", builder, "--driver", "remote", buildConfig.Buildx.BuilderEndpoint)
+			if err != nil {
+				return fmt.Errorf("command 'docker buildx create --name %s' failed: %w", builder, err)
+			}
+		}
+	}
+
+	args := []string{
+		"buildx",
+		"build",
+		"-t",
+		d.imageName,
+		"--load",
+	}
+	if builder != "" {
+		args = append(args, "--builder", builder)
+	}
+	if buildConfig.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if len(buildConfig.TargetPlatforms) > 0 {
+		args = append(args, fmt.Sprintf("--platform=%s", strings.Join(buildConfig.TargetPlatforms, ",")))
+	}
+	for _, cache := range buildConfig.Buildx.CacheFrom {
+		args = append(args, "--cache-from", cache)
+	}
+	for _, cache := range buildConfig.Buildx.CacheTo {
+		args = append(args, "--cache-to", cache)
+	}
+	for _, secret := range buildConfig.Buildx.Secrets {
+		args = append(args, "--secret", secret)
+	}
+	args = append(args, ".")
+
+	err := cmdExec(dockerCommand, stdout, stderr, args...)
+	if err != nil {
+		return fmt.Errorf("command 'docker buildx build -t %s' failed: %w", d.imageName, err)
+	}
+	return nil
+}
+
+func buildOutput(buildConfig airflowTypes.ImageBuildConfig) (stdout, stderr io.Writer) {
+	if buildConfig.Output {
+		return os.Stdout, os.Stderr
+	}
+	return nil, nil
+}
+
 func (d *DockerImage) Pytest(pytestFile, airflowHome, envFile string, pytestArgs []string, buildConfig airflowTypes.ImageBuildConfig) (string, error) {
 	// delete container
 	dockerCommand := config.CFG.DockerCommand.GetString()
@@ -268,6 +323,46 @@ func (d *DockerImage) ListLabels() (map[string]string, error) {
 	return labels, nil
 }
 
+// ListPackages returns the Python packages and the Debian packages installed in the image
+func (d *DockerImage) ListPackages() ([]sbom.Package, error) {
+	dockerCommand := config.CFG.DockerCommand.GetString()
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	err := cmdExec(dockerCommand, stdout, stderr, "run", "--rm", "--entrypoint", "pip", d.imageName, "list", "--format", "json", "--disable-pip-version-check")
+	if err != nil {
+		return nil, fmt.Errorf("error listing python packages in %s: %s: %w", d.imageName, stderr.String(), err)
+	}
+	var pythonPackages []struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &pythonPackages); err != nil {
+		return nil, fmt.Errorf("error parsing python packages in %s: %w", d.imageName, err)
+	}
+	packages := make([]sbom.Package, 0, len(pythonPackages))
+	for _, p := range pythonPackages {
+		packages = append(packages, sbom.Package{Name: p.Name, Version: p.Version, Type: sbom.PythonPackage})
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	err = cmdExec(dockerCommand, stdout, stderr, "run", "--rm", "--entrypoint", "dpkg-query", d.imageName, "-W", "-f=${Package}\t${Version}\n")
+	if err != nil {
+		// not every image is Debian based, the python packages are still worth reporting
+		log.Debugf("error listing OS packages in %s: %s: %v", d.imageName, stderr.String(), err)
+		return packages, nil
+	}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 2 { //nolint:gomnd
+			continue
+		}
+		packages = append(packages, sbom.Package{Name: fields[0], Version: fields[1], Type: sbom.DebianPackage})
+	}
+	return packages, nil
+}
+
 func (d *DockerImage) TagLocalImage(localImage string) error {
 	dockerCommand := config.CFG.DockerCommand.GetString()
 
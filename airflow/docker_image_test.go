@@ -9,6 +9,7 @@ import (
 
 	airflowTypes "github.com/astronomer/astro-cli/airflow/types"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/sbom"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/docker/cli/cli/config/types"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -83,6 +84,91 @@ func TestDockerImageBuild(t *testing.T) {
 	cmdExec = previousCmdExec
 }
 
+func TestDockerImageBuildx(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+
+	handler := DockerImage{
+		imageName: "testing",
+	}
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	previousCmdExec := cmdExec
+	defer func() { cmdExec = previousCmdExec }()
+
+	t.Run("cache and secrets", func(t *testing.T) {
+		options := airflowTypes.ImageBuildConfig{
+			Path:            cwd,
+			TargetPlatforms: []string{"linux/amd64"},
+			Buildx: airflowTypes.BuildxConfig{
+				CacheFrom: []string{"type=registry,ref=example.com/cache"},
+				CacheTo:   []string{"type=local,dest=/tmp/cache"},
+				Secrets:   []string{"id=pip,src=pip.conf"},
+			},
+		}
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			assert.Equal(t, []string{
+				"buildx", "build", "-t", "testing", "--load", "--platform=linux/amd64",
+				"--cache-from", "type=registry,ref=example.com/cache",
+				"--cache-to", "type=local,dest=/tmp/cache",
+				"--secret", "id=pip,src=pip.conf",
+				".",
+			}, args)
+			return nil
+		}
+		err = handler.Build(options)
+		assert.NoError(t, err)
+	})
+
+	t.Run("creates remote builder", func(t *testing.T) {
+		options := airflowTypes.ImageBuildConfig{
+			Path:   cwd,
+			Buildx: airflowTypes.BuildxConfig{BuilderEndpoint: "tcp://buildkitd:1234"},
+		}
+		var calls [][]string
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			calls = append(calls, args)
+			if args[1] == "inspect" {
+				return errMock
+			}
+			return nil
+		}
+		err = handler.Build(options)
+		assert.NoError(t, err)
+		assert.Len(t, calls, 3)
+		assert.Equal(t, []string{"buildx", "create", "--name", remoteBuilderName, "--driver", "remote", "tcp://buildkitd:1234"}, calls[1])
+		assert.Contains(t, calls[2], remoteBuilderName)
+	})
+
+	t.Run("reuses remote builder", func(t *testing.T) {
+		options := airflowTypes.ImageBuildConfig{
+			Path:   cwd,
+			Buildx: airflowTypes.BuildxConfig{BuilderEndpoint: "tcp://buildkitd:1234"},
+		}
+		var calls [][]string
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			calls = append(calls, args)
+			return nil
+		}
+		err = handler.Build(options)
+		assert.NoError(t, err)
+		assert.Len(t, calls, 2)
+	})
+
+	t.Run("build error", func(t *testing.T) {
+		options := airflowTypes.ImageBuildConfig{
+			Path:   cwd,
+			Buildx: airflowTypes.BuildxConfig{Enabled: true},
+		}
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			return errMock
+		}
+		err = handler.Build(options)
+		assert.ErrorIs(t, err, errMock)
+	})
+}
+
 func TestDockerImagePytest(t *testing.T) {
 	handler := DockerImage{
 		imageName: "testing",
@@ -277,6 +363,58 @@ func TestDockerImageListLabel(t *testing.T) {
 	})
 }
 
+func TestDockerImageListPackages(t *testing.T) {
+	handler := DockerImage{
+		imageName: "testing",
+	}
+
+	previousCmdExec := cmdExec
+	defer func() { cmdExec = previousCmdExec }()
+
+	t.Run("success", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			switch args[3] {
+			case "pip":
+				io.WriteString(stdout, `[{"name": "requests", "version": "2.28.1"}]`)
+			case "dpkg-query":
+				io.WriteString(stdout, "openssl\t3.0.2\nbash\t5.1-2\n")
+			}
+			return nil
+		}
+
+		resp, err := handler.ListPackages()
+		assert.NoError(t, err)
+		assert.Equal(t, []sbom.Package{
+			{Name: "requests", Version: "2.28.1", Type: sbom.PythonPackage},
+			{Name: "openssl", Version: "3.0.2", Type: sbom.DebianPackage},
+			{Name: "bash", Version: "5.1-2", Type: sbom.DebianPackage},
+		}, resp)
+	})
+
+	t.Run("no dpkg in image", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			if args[3] == "dpkg-query" {
+				return errMockDocker
+			}
+			io.WriteString(stdout, `[{"name": "requests", "version": "2.28.1"}]`)
+			return nil
+		}
+
+		resp, err := handler.ListPackages()
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+	})
+
+	t.Run("pip error", func(t *testing.T) {
+		cmdExec = func(cmd string, stdout, stderr io.Writer, args ...string) error {
+			return errMockDocker
+		}
+
+		_, err := handler.ListPackages()
+		assert.ErrorIs(t, err, errMockDocker)
+	})
+}
+
 func TestDockerTagLocalImage(t *testing.T) {
 	handler := DockerImage{
 		imageName: "testing",
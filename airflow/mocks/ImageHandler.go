@@ -4,6 +4,7 @@ package mocks
 
 import (
 	types "github.com/astronomer/astro-cli/airflow/types"
+	sbom "github.com/astronomer/astro-cli/pkg/sbom"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -70,6 +71,29 @@ func (_m *ImageHandler) ListLabels() (map[string]string, error) {
 	return r0, r1
 }
 
+// ListPackages provides a mock function with given fields:
+func (_m *ImageHandler) ListPackages() ([]sbom.Package, error) {
+	ret := _m.Called()
+
+	var r0 []sbom.Package
+	if rf, ok := ret.Get(0).(func() []sbom.Package); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sbom.Package)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Push provides a mock function with given fields: registry, username, token, remoteImage
 func (_m *ImageHandler) Push(registry string, username string, token string, remoteImage string) error {
 	ret := _m.Called(registry, username, token, remoteImage)
@@ -6,4 +6,25 @@ type ImageBuildConfig struct {
 	TargetPlatforms []string
 	NoCache         bool
 	Output          bool
+	Buildx          BuildxConfig
+}
+
+// BuildxConfig defines BuildKit specific options, setting any of them builds the image with `docker buildx build`
+type BuildxConfig struct {
+	// Enabled forces a buildx build even when no other option is set
+	Enabled bool
+	// Builder is the name of an existing buildx builder instance
+	Builder string
+	// BuilderEndpoint is the address of a remote BuildKit daemon, e.g. tcp://buildkitd:1234
+	BuilderEndpoint string
+	// CacheFrom and CacheTo take buildx cache specs, e.g. type=registry,ref=<image> or type=local,dest=<dir>
+	CacheFrom []string
+	CacheTo   []string
+	// Secrets take buildx secret specs, e.g. id=pip,src=pip.conf or id=pip,env=PIP_INDEX_URL
+	Secrets []string
+}
+
+// IsSet returns true if the image should be built with buildx
+func (b BuildxConfig) IsSet() bool {
+	return b.Enabled || b.Builder != "" || b.BuilderEndpoint != "" || len(b.CacheFrom) > 0 || len(b.CacheTo) > 0 || len(b.Secrets) > 0
 }
@@ -0,0 +1,100 @@
+package airflowversions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/astronomer/astro-cli/config"
+)
+
+// cacheFileName is the file in the home config directory the versions responses are cached in
+const cacheFileName = "versions_cache.json"
+
+var ErrNoCachedVersions = errors.New("there are no cached runtime versions to use offline, run the command once without --offline or set runtime_versions.source to a local file")
+
+// cachedResponse is a versions response and when it was fetched
+type cachedResponse struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Response  Response  `json:"response"`
+}
+
+// readCache returns the cached response of source and when it was fetched
+func readCache(source string) (*Response, time.Time, error) {
+	cache, err := readCacheFile()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	cached, ok := cache[source]
+	if !ok {
+		return nil, time.Time{}, os.ErrNotExist
+	}
+	return &cached.Response, cached.FetchedAt, nil
+}
+
+// writeCache caches the response of source, the response is still used if it can not be cached
+func writeCache(source string, resp *Response) {
+	cache, err := readCacheFile()
+	if err != nil {
+		cache = map[string]cachedResponse{}
+	}
+	cache[source] = cachedResponse{FetchedAt: time.Now(), Response: *resp}
+	data, err := json.Marshal(cache)
+	if err == nil {
+		err = config.WriteCacheFile(cacheFileName, data)
+	}
+	if err != nil {
+		logrus.Debugf("Unable to cache the versions of %s: %s", source, err.Error())
+	}
+}
+
+func readCacheFile() (map[string]cachedResponse, error) {
+	data, err := config.ReadCacheFile(cacheFileName)
+	if err != nil {
+		return nil, err
+	}
+	cache := map[string]cachedResponse{}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// cacheTTL returns how long cached responses are used before they are fetched again
+func cacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(config.CFG.RuntimeVersionsTTL.GetGlobalString())
+	if err != nil {
+		return 0
+	}
+	return ttl
+}
+
+// localSource returns the path of source if it is a local file, a path or a file:// URL
+func localSource(source string) (string, bool) {
+	u, err := url.Parse(source)
+	if err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return "", false
+	}
+	if err == nil && u.Scheme == "file" {
+		return u.Path, true
+	}
+	return source, true
+}
+
+// readSourceFile reads the versions from a local copy of the updates astronomer API response
+func readSourceFile(path string) (*Response, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("runtime_versions.source: %w", err)
+	}
+	decode := Response{}
+	if err := json.Unmarshal(data, &decode); err != nil {
+		return nil, fmt.Errorf("failed to JSON decode %s: %w", path, err)
+	}
+	return &decode, nil
+}
@@ -6,7 +6,11 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 )
 
@@ -48,13 +52,53 @@ func (r *Request) Do() (*Response, error) {
 	return r.DoWithClient(NewClient(httputil.NewHTTPClient(), false))
 }
 
-// Do executes a query against the updates astronomer API, logging out any errors contained in the response object
+// Do returns the versions of the source of the client. Responses of the updates astronomer API are cached for
+// runtime_versions.cache_ttl, the cached response is used when the API can not be reached or the CLI is offline.
 func (c *Client) Do(doOpts *httputil.DoOptions) (*Response, error) {
-	var response httputil.HTTPResponse
-	doOpts.Path = RuntimeReleaseURL
+	source := c.source()
+	if path, ok := localSource(source); ok {
+		return readSourceFile(path)
+	}
+
+	cached, fetchedAt, cacheErr := readCache(source)
+	if config.IsOffline() {
+		if cacheErr != nil {
+			return nil, ErrNoCachedVersions
+		}
+		return cached, nil
+	}
+	if cacheErr == nil && time.Since(fetchedAt) < cacheTTL() {
+		return cached, nil
+	}
+
+	resp, err := c.fetch(source, doOpts)
+	if err != nil {
+		if cacheErr == nil {
+			logrus.Debugf("Using the versions of %s cached at %s: %s", source, fetchedAt.Format(time.RFC3339), err.Error())
+			return cached, nil
+		}
+		return nil, err
+	}
+	writeCache(source, resp)
+	return resp, nil
+}
+
+// source returns the URL or the file the versions are read from
+func (c *Client) source() string {
 	if c.useAstronomerCertified {
-		doOpts.Path = AirflowReleaseURL
+		return AirflowReleaseURL
 	}
+	if source := config.CFG.RuntimeVersionsSource.GetGlobalString(); source != "" {
+		return source
+	}
+	return RuntimeReleaseURL
+}
+
+// fetch executes a query against the updates astronomer API or its mirror at source, logging out any errors
+// contained in the response object
+func (c *Client) fetch(source string, doOpts *httputil.DoOptions) (*Response, error) {
+	var response httputil.HTTPResponse
+	doOpts.Path = source
 	doOpts.Method = http.MethodGet
 	httpResponse, err := c.HTTPClient.Do(doOpts)
 	if err != nil {
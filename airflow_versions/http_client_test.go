@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/stretchr/testify/assert"
@@ -32,3 +35,122 @@ func TestClientDo(t *testing.T) {
 		assert.Equal(t, mockResp, *resp)
 	})
 }
+
+func TestClientDoCache(t *testing.T) {
+	mockResp := Response{RuntimeVersions: map[string]RuntimeVersion{"4.2.5": {RuntimeVersionMetadata{AirflowVersion: "2.2.5", Channel: "stable"}, RuntimeVersionMigrations{}}}}
+	jsonResponse, err := json.Marshal(mockResp)
+	assert.NoError(t, err)
+
+	// newCountingClient returns a client that answers with status and counts its requests in calls
+	newCountingClient := func(status int, calls *int) *Client {
+		httpClient := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			*calls++
+			return &http.Response{
+				StatusCode: status,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+		return NewClient(httpClient, false)
+	}
+
+	t.Run("responses are cached", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.LocalPlatform)
+		calls := 0
+		client := newCountingClient(http.StatusOK, &calls)
+
+		for i := 0; i < 2; i++ {
+			resp, err := client.Do(&httputil.DoOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, mockResp, *resp)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("expired responses are fetched again", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.LocalPlatform)
+		t.Setenv(config.CFG.RuntimeVersionsTTL.EnvName(), "0s")
+		calls := 0
+		client := newCountingClient(http.StatusOK, &calls)
+
+		for i := 0; i < 2; i++ {
+			_, err := client.Do(&httputil.DoOptions{})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("the cached response is used when the API can not be reached", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.LocalPlatform)
+		t.Setenv(config.CFG.RuntimeVersionsTTL.EnvName(), "0s")
+		calls := 0
+		_, err := newCountingClient(http.StatusOK, &calls).Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+
+		resp, err := newCountingClient(http.StatusServiceUnavailable, &calls).Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResp, *resp)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.LocalPlatform)
+		t.Setenv(config.CFG.RuntimeVersionsTTL.EnvName(), "0s")
+		config.SetOfflineOverride(true)
+		defer config.SetOfflineOverride(false)
+		calls := 0
+		client := newCountingClient(http.StatusOK, &calls)
+
+		_, err := client.Do(&httputil.DoOptions{})
+		assert.ErrorIs(t, err, ErrNoCachedVersions)
+
+		writeCache(RuntimeReleaseURL, &mockResp)
+		resp, err := client.Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResp, *resp)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestClientDoSource(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	mockResp := Response{RuntimeVersions: map[string]RuntimeVersion{"7.0.0": {RuntimeVersionMetadata{AirflowVersion: "2.5.0", Channel: "stable"}, RuntimeVersionMigrations{}}}}
+	jsonResponse, err := json.Marshal(mockResp)
+	assert.NoError(t, err)
+
+	t.Run("mirror", func(t *testing.T) {
+		t.Setenv(config.CFG.RuntimeVersionsSource.EnvName(), "https://mirror.example.com/astronomer-runtime")
+		httpClient := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			assert.Equal(t, "https://mirror.example.com/astronomer-runtime", req.URL.String())
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBuffer(jsonResponse)),
+				Header:     make(http.Header),
+			}
+		})
+
+		resp, err := NewClient(httpClient, false).Do(&httputil.DoOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, mockResp, *resp)
+	})
+
+	t.Run("local file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "astronomer-runtime.json")
+		assert.NoError(t, os.WriteFile(path, jsonResponse, 0o600))
+		httpClient := testUtil.NewTestClient(func(req *http.Request) *http.Response {
+			t.Error("the local file is read without requests")
+			return nil
+		})
+
+		for _, source := range []string{path, "file://" + path} {
+			t.Setenv(config.CFG.RuntimeVersionsSource.EnvName(), source)
+			resp, err := NewClient(httpClient, false).Do(&httputil.DoOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, mockResp, *resp)
+		}
+
+		t.Setenv(config.CFG.RuntimeVersionsSource.EnvName(), filepath.Join(t.TempDir(), "missing.json"))
+		_, err := NewClient(httpClient, false).Do(&httputil.DoOptions{})
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 
+	astro "github.com/astronomer/astro-cli/astro-client"
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 )
@@ -37,8 +38,9 @@ func requestEditor(ctx httpContext.Context, req *http.Request) error {
 	return nil
 }
 
-// create api client for astro core services
+// create api client for astro core services, expired access tokens are refreshed by the HTTP client of c
 func NewCoreClient(c *httputil.HTTPClient) *ClientWithResponses {
+	c.HTTPClient.Transport = astro.NewRefreshTransport(c.HTTPClient.Transport)
 	// we append base url in request editor, so set to an empty string here
 	cl, _ := NewClientWithResponses("", WithHTTPClient(c.HTTPClient), WithRequestEditorFn(requestEditor))
 	return cl
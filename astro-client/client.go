@@ -24,7 +24,9 @@ type HTTPClient struct {
 }
 
 // NewAstroClient returns a new Client with the logger and HTTP client setup.
+// Expired access tokens are refreshed by the HTTP client of c.
 func NewAstroClient(c *httputil.HTTPClient) *HTTPClient {
+	c.HTTPClient.Transport = NewRefreshTransport(c.HTTPClient.Transport)
 	return &HTTPClient{
 		c,
 	}
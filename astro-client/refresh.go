@@ -0,0 +1,216 @@
+package astro
+
+import (
+	httpContext "context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/domainutil"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+)
+
+const (
+	// AccessTokenExpThreshold is how long before it expires an access token is refreshed
+	AccessTokenExpThreshold = 5 * time.Minute
+
+	authConfigEndpoint = "auth-config"
+	authorizationKey   = "Authorization"
+)
+
+var (
+	errNoRefreshToken = errors.New("there is no refresh token, log in again with astro login")
+
+	// Monkey patched to write unit tests
+	getAuthConfig = fetchAuthConfig
+	// refreshClient is created for every request so it uses the http settings of the loaded config
+	refreshClient = func() *http.Client { return httputil.NewHTTPClient().HTTPClient }
+
+	// refreshLock stops concurrent requests from refreshing the same token more than once
+	refreshLock sync.Mutex
+)
+
+// refreshTokenResponse is the response of the OAuth token endpoint to a refresh token grant
+type refreshTokenResponse struct {
+	AccessToken      string  `json:"access_token"`
+	RefreshToken     string  `json:"refresh_token"`
+	ExpiresIn        int64   `json:"expires_in"`
+	Error            *string `json:"error,omitempty"`
+	ErrorDescription string  `json:"error_description,omitempty"`
+}
+
+// RefreshToken gets a new access token for c with its refresh token and saves it in c and the config
+func RefreshToken(c *config.Context) error {
+	if c.RefreshToken == "" {
+		return errNoRefreshToken
+	}
+	authConfig, err := getAuthConfig(c.Domain)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{
+		"client_id":     {authConfig.ClientID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {c.RefreshToken},
+	}
+	req, err := http.NewRequestWithContext(httpContext.Background(), http.MethodPost, authConfig.DomainURL+"oauth/token", strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("cannot get a new access token from the refresh token: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := refreshClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot get a new access token from the refresh token: %w", err)
+	}
+	defer res.Body.Close()
+
+	var tokenRes refreshTokenResponse
+	err = json.NewDecoder(res.Body).Decode(&tokenRes)
+	if err != nil {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+	if tokenRes.Error != nil {
+		return errors.New(tokenRes.ErrorDescription) //nolint:goerr113
+	}
+	if tokenRes.AccessToken == "" {
+		return fmt.Errorf("cannot get a new access token from the refresh token: status %d", res.StatusCode) //nolint:goerr113
+	}
+
+	// persist the updated context with the renewed access token
+	c.Token = "Bearer " + tokenRes.AccessToken
+	err = c.SetContextKey("token", c.Token)
+	if err != nil {
+		return err
+	}
+	// the refresh token is only returned when it is rotated
+	if tokenRes.RefreshToken != "" {
+		c.RefreshToken = tokenRes.RefreshToken
+		err = c.SetContextKey("refreshtoken", c.RefreshToken)
+		if err != nil {
+			return err
+		}
+	}
+	return c.SetExpiresIn(tokenRes.ExpiresIn)
+}
+
+// TokenExpiresSoon is true if the access token of c expires within AccessTokenExpThreshold or its expiry is unknown
+func TokenExpiresSoon(c *config.Context) bool {
+	expireTime, err := c.GetExpiresIn()
+	if err != nil {
+		return true
+	}
+	return time.Now().Add(AccessTokenExpThreshold).After(expireTime)
+}
+
+func fetchAuthConfig(domain string) (AuthConfig, error) {
+	var authConfig AuthConfig
+	addr := domainutil.GetURLToEndpoint("https", domain, authConfigEndpoint)
+	req, err := http.NewRequestWithContext(httpContext.Background(), http.MethodGet, addr, http.NoBody)
+	if err != nil {
+		return authConfig, err
+	}
+	res, err := refreshClient().Do(req)
+	if err != nil {
+		return authConfig, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return authConfig, fmt.Errorf("cannot get the auth config of %s: status %d", domain, res.StatusCode) //nolint:goerr113
+	}
+	err = json.NewDecoder(res.Body).Decode(&authConfig)
+	if err != nil {
+		return authConfig, fmt.Errorf("cannot decode response: %w", err)
+	}
+	return authConfig, nil
+}
+
+// refreshTransport refreshes the access token of the current context when it is about to expire and retries a
+// request once with a new access token when the API answers 401 Unauthorized. Only requests that are sent with the
+// access token of a user that has a refresh token are refreshed, API tokens and keys are sent as is.
+type refreshTransport struct {
+	base http.RoundTripper
+}
+
+// NewRefreshTransport returns a RoundTripper that refreshes expired access tokens around base
+func NewRefreshTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := base.(*refreshTransport); ok {
+		return base
+	}
+	return &refreshTransport{base: base}
+}
+
+func (t *refreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c, err := context.GetCurrentContext()
+	if err != nil || c.RefreshToken == "" || c.Token == "" || req.Header.Get(authorizationKey) != c.Token {
+		return t.base.RoundTrip(req)
+	}
+
+	if TokenExpiresSoon(&c) {
+		// the request is still sent with the current token if the refresh fails, the API decides if it is valid
+		if token, err := refreshOnce(c.Token); err == nil {
+			req = withToken(req, token)
+		}
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	// the body of the request is read by the first attempt, it can only be retried if it can be read again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return res, nil
+	}
+	token, err := refreshOnce(req.Header.Get(authorizationKey))
+	if err != nil {
+		return res, nil
+	}
+	retry := withToken(req, token)
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	return t.base.RoundTrip(retry)
+}
+
+// refreshOnce refreshes the access token of the current context unless another request already replaced
+// expiredToken, it returns the new access token
+func refreshOnce(expiredToken string) (string, error) {
+	refreshLock.Lock()
+	defer refreshLock.Unlock()
+
+	c, err := context.GetCurrentContext()
+	if err != nil {
+		return "", err
+	}
+	if c.Token != expiredToken && !TokenExpiresSoon(&c) {
+		return c.Token, nil
+	}
+	err = RefreshToken(&c)
+	if err != nil {
+		return "", err
+	}
+	return c.Token, nil
+}
+
+// withToken returns a copy of req that is sent with token
+func withToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set(authorizationKey, token)
+	return r
+}
@@ -0,0 +1,202 @@
+package astro
+
+import (
+	"bytes"
+	httpContext "context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/context"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOAuthServer answers refresh token grants for refresh-token with new-token and rotates the refresh token
+func fakeOAuthServer(t *testing.T, grants *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth/token", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "test-client-id", r.PostForm.Get("client_id"))
+		*grants++
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("refresh_token") != "refresh-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Unknown or invalid refresh token."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "new-token",
+			"refresh_token": "rotated-refresh-token",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	getAuthConfig = func(domain string) (AuthConfig, error) {
+		return AuthConfig{ClientID: "test-client-id", DomainURL: server.URL + "/"}, nil
+	}
+	t.Cleanup(func() { getAuthConfig = fetchAuthConfig })
+	return server
+}
+
+// fakeAPIServer only accepts requests with new-token and echoes their body
+func fakeAPIServer(t *testing.T, requests *int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("Authorization") != "Bearer new-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// initRefreshTestConfig logs in the current context with an access token that is valid for expiresIn seconds
+func initRefreshTestConfig(t *testing.T, refreshToken string, expiresIn int64) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	c, err := context.GetCurrentContext()
+	assert.NoError(t, err)
+	assert.NoError(t, c.SetContextKey("token", "Bearer expired-token"))
+	assert.NoError(t, c.SetContextKey("refreshtoken", refreshToken))
+	assert.NoError(t, c.SetExpiresIn(expiresIn))
+}
+
+func doWithToken(t *testing.T, url, token, body string) *http.Response {
+	client := &http.Client{Transport: NewRefreshTransport(nil)}
+	req, err := http.NewRequestWithContext(httpContext.Background(), http.MethodPost, url, bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", token)
+	res, err := client.Do(req)
+	assert.NoError(t, err)
+	return res
+}
+
+func TestRefreshTransport(t *testing.T) {
+	t.Run("refreshes the token and retries a request on 401", func(t *testing.T) {
+		var grants, requests int
+		fakeOAuthServer(t, &grants)
+		api := fakeAPIServer(t, &requests)
+		initRefreshTestConfig(t, "refresh-token", 3600)
+
+		res := doWithToken(t, api.URL, "Bearer expired-token", "request body")
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "request body", string(body))
+		assert.Equal(t, 1, grants)
+		assert.Equal(t, 2, requests)
+
+		// the new tokens are saved
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer new-token", c.Token)
+		assert.Equal(t, "rotated-refresh-token", c.RefreshToken)
+		assert.False(t, TokenExpiresSoon(&c))
+	})
+
+	t.Run("refreshes the token before it expires", func(t *testing.T) {
+		var grants, requests int
+		fakeOAuthServer(t, &grants)
+		api := fakeAPIServer(t, &requests)
+		initRefreshTestConfig(t, "refresh-token", 60)
+
+		res := doWithToken(t, api.URL, "Bearer expired-token", "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, 1, grants)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("returns the 401 response if the refresh fails", func(t *testing.T) {
+		var grants, requests int
+		fakeOAuthServer(t, &grants)
+		api := fakeAPIServer(t, &requests)
+		initRefreshTestConfig(t, "revoked-refresh-token", 3600)
+
+		res := doWithToken(t, api.URL, "Bearer expired-token", "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, 1, grants)
+		assert.Equal(t, 1, requests)
+
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer expired-token", c.Token)
+	})
+
+	t.Run("does not refresh requests with another token", func(t *testing.T) {
+		var grants, requests int
+		fakeOAuthServer(t, &grants)
+		api := fakeAPIServer(t, &requests)
+		initRefreshTestConfig(t, "refresh-token", 3600)
+
+		res := doWithToken(t, api.URL, "Bearer api-token", "")
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, 0, grants)
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	t.Run("returns the error of the OAuth server", func(t *testing.T) {
+		var grants int
+		fakeOAuthServer(t, &grants)
+		initRefreshTestConfig(t, "revoked-refresh-token", 3600)
+
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		err = RefreshToken(&c)
+		assert.EqualError(t, err, "Unknown or invalid refresh token.")
+	})
+
+	t.Run("returns an error without a refresh token", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		err = RefreshToken(&c)
+		assert.ErrorIs(t, err, errNoRefreshToken)
+	})
+}
+
+func TestTokenExpiresSoon(t *testing.T) {
+	t.Run("the token expires after the threshold", func(t *testing.T) {
+		initRefreshTestConfig(t, "refresh-token", 3600)
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.False(t, TokenExpiresSoon(&c))
+	})
+
+	t.Run("the token expires within the threshold", func(t *testing.T) {
+		initRefreshTestConfig(t, "refresh-token", 60)
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.True(t, TokenExpiresSoon(&c))
+	})
+
+	t.Run("the context has no expiry", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		c, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.True(t, TokenExpiresSoon(&c))
+	})
+
+	t.Run("the expiry of the context can not be read", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		c := config.Context{}
+		assert.True(t, TokenExpiresSoon(&c))
+	})
+}
+
+func TestNewRefreshTransport(t *testing.T) {
+	transport := NewRefreshTransport(nil)
+	assert.Equal(t, transport, NewRefreshTransport(transport))
+}
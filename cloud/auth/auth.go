@@ -43,7 +43,8 @@ const (
 )
 
 var (
-	httpClient          = httputil.NewHTTPClient()
+	// httpClient is created by getHTTPClient after the config is loaded, so it uses the http settings
+	httpClient          *httputil.HTTPClient
 	openURL             = browser.OpenURL
 	ErrorNoOrganization = errors.New("no organization found. Please contact your Astro Organization Owner to be invited to the organization")
 	errEmailNotFound    = errors.New("cannot retrieve email")
@@ -72,7 +73,7 @@ func requestUserInfo(authConfig astro.AuthConfig, accessToken string) (UserInfo,
 		Path:    addr,
 		Method:  http.MethodGet,
 	}
-	res, err := httpClient.Do(doOptions)
+	res, err := getHTTPClient().Do(doOptions)
 	if err != nil {
 		return UserInfo{}, fmt.Errorf("cannot retrieve userinfo: %w", err)
 	}
@@ -108,7 +109,7 @@ func requestToken(authConfig astro.AuthConfig, verifier, code string) (Result, e
 		Path:    addr,
 		Method:  http.MethodPost,
 	}
-	res, err := httpClient.Do(doOptions)
+	res, err := getHTTPClient().Do(doOptions)
 	if err != nil {
 		return Result{}, fmt.Errorf("cannot retrieve token: %w", err)
 	}
@@ -449,7 +450,7 @@ func FetchDomainAuthConfig(domain string) (astro.AuthConfig, error) {
 		Path:    addr,
 		Method:  http.MethodGet,
 	}
-	res, err := httpClient.Do(doOptions)
+	res, err := getHTTPClient().Do(doOptions)
 	if err != nil {
 		return authConfig, err
 	}
@@ -470,3 +471,11 @@ func FetchDomainAuthConfig(domain string) (astro.AuthConfig, error) {
 
 	return authConfig, errors.New("something went wrong! Try again or contact Astronomer Support")
 }
+
+// getHTTPClient returns the HTTP client of the auth requests, it is created when it is first used
+func getHTTPClient() *httputil.HTTPClient {
+	if httpClient == nil {
+		httpClient = httputil.NewHTTPClient()
+	}
+	return httpClient
+}
@@ -3,10 +3,13 @@ package auth
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -80,6 +83,7 @@ var (
 )
 
 func Test_FetchDomainAuthConfig(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
 	domain := "astronomer.io"
 	actual, err := FetchDomainAuthConfig(domain)
 	assert.NoError(t, err)
@@ -211,6 +215,27 @@ func TestRequestUserInfo(t *testing.T) {
 	})
 }
 
+func TestRequestUserInfoCABundle(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(UserInfo{Email: "test@astronomer.test"}) //nolint:errcheck
+	}))
+	defer server.Close()
+	authConfig := astro.AuthConfig{DomainURL: server.URL + "/"}
+	httpClient = nil
+	defer func() { httpClient = nil }()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	err := os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)
+	assert.NoError(t, err)
+	assert.NoError(t, config.CFG.HTTPCABundle.SetHomeString(caBundle))
+	defer config.CFG.HTTPCABundle.SetHomeString("") //nolint:errcheck
+
+	resp, err := requestUserInfo(authConfig, "access-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "test@astronomer.test", resp.Email)
+}
+
 func TestRequestToken(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.CloudPlatform)
 	mockResponse := postTokenResponse{
@@ -0,0 +1,110 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/astronomer/astro-cli/airflow"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/sbom"
+	"github.com/pkg/errors"
+)
+
+const dependencyChangesPromptMsg = "Some packages cross a major version with this deploy. Are you sure you want to continue?"
+
+var errDependencyChangesDeclined = errors.New("deploy canceled, review the dependency changes above")
+
+// packagesSnapshotFile is where the python packages of the last image deployed from this project are kept, so the
+// next deploy can be compared against them even when the image is no longer available locally
+func packagesSnapshotFile(path, deploymentID string) string {
+	return filepath.Join(reportDir(path), deploymentID+".packages.json")
+}
+
+// previousPackages returns the python packages of the image last deployed to the deployment, or nil when unknown.
+// The snapshot saved by the last deploy is preferred, the local image with the deploy tag is only used without one as
+// it may have been built for another deployment or not deployed at all. It must be called before the new image is
+// built since both share the same local tag.
+func previousPackages(path, deploymentID string, imageHandler airflow.ImageHandler) []sbom.Package {
+	if data, err := os.ReadFile(packagesSnapshotFile(path, deploymentID)); err == nil {
+		var packages []sbom.Package
+		if err := json.Unmarshal(data, &packages); err == nil {
+			return packages
+		}
+	}
+
+	if _, err := imageHandler.GetLabel(runtimeImageLabel); err != nil {
+		return nil
+	}
+	packages, err := imageHandler.ListPackages()
+	if err != nil {
+		return nil
+	}
+	return sbom.OfType(packages, sbom.PythonPackage)
+}
+
+// savePackages keeps the python packages of the image that was just deployed for the next deploy to compare against
+func savePackages(path, deploymentID string, packages []sbom.Package) error {
+	data, err := json.MarshalIndent(packages, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(reportDir(path), reportDirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(packagesSnapshotFile(path, deploymentID), data, reportFilePerm)
+}
+
+// checkDependencyChanges prints the python package changes between the previous and the new image and, when confirm
+// is set, asks before deploying packages that cross a major version. It returns the packages of the new image.
+func checkDependencyChanges(previous []sbom.Package, confirm bool, imageHandler airflow.ImageHandler, out io.Writer) ([]sbom.Package, error) {
+	packages, err := imageHandler.ListPackages()
+	if err != nil {
+		fmt.Fprintf(out, "Unable to list the python packages of the image, skipping dependency diff: %s\n", err.Error())
+		return nil, nil
+	}
+	current := sbom.OfType(packages, sbom.PythonPackage)
+	if previous == nil {
+		return current, nil
+	}
+
+	diff := sbom.Diff(previous, current)
+	if diff.Empty() {
+		fmt.Fprintln(out, "No python package changes since the last deploy")
+		return current, nil
+	}
+
+	fmt.Fprintln(out, "\nPython package changes since the last deploy:")
+	for _, p := range diff.Added {
+		fmt.Fprintf(out, "  %s %s %s\n", ansi.Green("+"), p.Name, p.Version)
+	}
+	for _, p := range diff.Removed {
+		fmt.Fprintf(out, "  %s %s %s\n", ansi.Red("-"), p.Name, p.Version)
+	}
+	for _, c := range diff.Changed {
+		direction := "upgraded"
+		if !c.IsUpgrade() {
+			direction = "downgraded"
+		}
+		line := fmt.Sprintf("  ~ %s %s -> %s (%s)", c.Name, c.From, c.To, direction)
+		if c.IsMajor() {
+			line = ansi.Bold(line + " major version change")
+		}
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintln(out)
+
+	if confirm && len(diff.MajorChanges()) > 0 {
+		i, err := input.Confirm(dependencyChangesPromptMsg)
+		if err != nil {
+			return nil, err
+		}
+		if !i {
+			return nil, errDependencyChangesDeclined
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,119 @@
+package deploy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/astronomer/astro-cli/airflow/mocks"
+	"github.com/astronomer/astro-cli/pkg/sbom"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	oldPackages = []sbom.Package{
+		{Name: "requests", Version: "2.28.1", Type: sbom.PythonPackage},
+		{Name: "flask", Version: "1.1.4", Type: sbom.PythonPackage},
+		{Name: "six", Version: "1.16.0", Type: sbom.PythonPackage},
+	}
+	newPackages = []sbom.Package{
+		{Name: "requests", Version: "2.31.0", Type: sbom.PythonPackage},
+		{Name: "flask", Version: "2.2.2", Type: sbom.PythonPackage},
+		{Name: "boto3", Version: "1.26.0", Type: sbom.PythonPackage},
+		{Name: "openssl", Version: "3.0.2", Type: sbom.DebianPackage},
+	}
+)
+
+func TestPreviousPackages(t *testing.T) {
+	t.Run("from local image", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("GetLabel", runtimeImageLabel).Return("7.2.0", nil).Once()
+		imageHandler.On("ListPackages").Return(newPackages, nil).Once()
+
+		packages := previousPackages(t.TempDir(), "test-id", imageHandler)
+		assert.Len(t, packages, 3)
+		imageHandler.AssertExpectations(t)
+	})
+
+	t.Run("from snapshot", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, savePackages(dir, "test-id", oldPackages))
+
+		imageHandler := new(mocks.ImageHandler)
+		packages := previousPackages(dir, "test-id", imageHandler)
+		assert.Equal(t, oldPackages, packages)
+		imageHandler.AssertNotCalled(t, "ListPackages")
+	})
+
+	t.Run("snapshot of another deployment", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, savePackages(dir, "other-id", oldPackages))
+
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("GetLabel", runtimeImageLabel).Return("", errMock).Once()
+
+		assert.Nil(t, previousPackages(dir, "test-id", imageHandler))
+		imageHandler.AssertExpectations(t)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("GetLabel", runtimeImageLabel).Return("", errMock).Once()
+
+		assert.Nil(t, previousPackages(t.TempDir(), "test-id", imageHandler))
+	})
+}
+
+func TestCheckDependencyChanges(t *testing.T) {
+	t.Run("prints diff", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(newPackages, nil).Once()
+
+		out := new(bytes.Buffer)
+		current, err := checkDependencyChanges(oldPackages, false, imageHandler, out)
+		assert.NoError(t, err)
+		assert.Len(t, current, 3)
+		assert.Contains(t, out.String(), "boto3 1.26.0")
+		assert.Contains(t, out.String(), "six 1.16.0")
+		assert.Contains(t, out.String(), "requests 2.28.1 -> 2.31.0 (upgraded)")
+		assert.Contains(t, out.String(), "flask 1.1.4 -> 2.2.2 (upgraded) major version change")
+	})
+
+	t.Run("no previous packages", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(newPackages, nil).Once()
+
+		out := new(bytes.Buffer)
+		current, err := checkDependencyChanges(nil, true, imageHandler, out)
+		assert.NoError(t, err)
+		assert.Len(t, current, 3)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("confirm major changes declined", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "n")()
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(newPackages, nil).Once()
+
+		_, err := checkDependencyChanges(oldPackages, true, imageHandler, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errDependencyChangesDeclined)
+	})
+
+	t.Run("confirm major changes accepted", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "y")()
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(newPackages, nil).Once()
+
+		_, err := checkDependencyChanges(oldPackages, true, imageHandler, new(bytes.Buffer))
+		assert.NoError(t, err)
+	})
+
+	t.Run("list packages error", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(nil, errMock).Once()
+
+		current, err := checkDependencyChanges(oldPackages, true, imageHandler, new(bytes.Buffer))
+		assert.NoError(t, err)
+		assert.Nil(t, current)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/astronomer/astro-cli/airflow"
 	"github.com/astronomer/astro-cli/airflow/types"
 	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
@@ -20,8 +21,10 @@ import (
 	"github.com/astronomer/astro-cli/pkg/ansi"
 	"github.com/astronomer/astro-cli/pkg/azure"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/hooks"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/sbom"
 	"github.com/astronomer/astro-cli/pkg/util"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/pkg/errors"
@@ -34,6 +37,7 @@ const (
 	runtimeImageLabel      = "io.astronomer.docker.runtime.version"
 	defaultRuntimeVersion  = "4.2.5"
 	dagParseAllowedVersion = "4.1.0"
+	bytesInMB              = 1024 * 1024
 
 	composeImageBuildingPromptMsg     = "Building image..."
 	composeSkipImageBuildingPromptMsg = "Skipping building image..."
@@ -60,7 +64,8 @@ var (
 	// Monkey patched to write unit tests
 	airflowImageHandler  = airflow.ImageHandlerInit
 	containerHandlerInit = airflow.ContainerHandlerInit
-	azureUploader        = azure.Upload
+	azureUploader        = uploadDags
+	runHooks             = hooks.Run
 )
 
 var (
@@ -80,16 +85,23 @@ type deploymentInfo struct {
 }
 
 type InputDeploy struct {
-	Path           string
-	RuntimeID      string
-	WsID           string
-	Pytest         string
-	EnvFile        string
-	ImageName      string
-	DeploymentName string
-	Prompt         bool
-	Dags           bool
-	DagsPath       string
+	Path                     string
+	RuntimeID                string
+	WsID                     string
+	Pytest                   string
+	EnvFile                  string
+	ImageName                string
+	DeploymentName           string
+	Prompt                   bool
+	Dags                     bool
+	DagsPath                 string
+	Buildx                   types.BuildxConfig
+	Report                   ImageReport
+	ConfirmDependencyChanges bool
+	// DependencyDiff prints the python package changes since the last deploy, ConfirmDependencyChanges implies it
+	DependencyDiff bool
+	// WaitTimeout is how long to wait for the deployment to be healthy and run the new image, 0 does not wait
+	WaitTimeout time.Duration
 }
 
 func getRegistryURL(domain string) string {
@@ -103,6 +115,18 @@ func getRegistryURL(domain string) string {
 	return registry
 }
 
+// uploadDags uploads the DAGs tarball with the block size, concurrency, retries and timeout set in the config, the
+// requests go through the configured HTTP client so they use the proxy, CA bundle and client certificate and are traced
+func uploadDags(sasLink string, dagFileReader io.Reader) (string, error) {
+	opts := azure.DefaultUploadOptions()
+	opts.ClientOptions = &azblob.ClientOptions{Transport: httputil.NewHTTPClient().HTTPClient}
+	opts.BlockSize = int64(config.CFG.DagUploadBlockSize.GetInt()) * bytesInMB
+	opts.Concurrency = config.CFG.DagUploadConcurrency.GetInt()
+	opts.MaxRetries = config.CFG.DagUploadMaxRetries.GetInt()
+	opts.Timeout = time.Duration(config.CFG.DagUploadTimeout.GetInt()) * time.Second
+	return azure.UploadWithOptions(sasLink, dagFileReader, opts)
+}
+
 func deployDags(path, dagsPath, runtimeID string, client astro.Client) (string, error) {
 	// Check the dags directory
 	monitoringDagPath := filepath.Join(dagsPath, "astronomer_monitoring_dag.py")
@@ -186,8 +210,20 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 
 	// Deploy dags if deployInput runtimeId is virtual runtime
 	if strings.HasPrefix(deployInput.RuntimeID, "vr-") {
+		// virtual runtimes have no deployment or Airflow UI to link to, the hooks only get the runtime ID
+		hookEnv := hooks.Env{DeploymentID: deployInput.RuntimeID}
+		err = runHooks(hooks.PreBuild, deployInput.Path, hookEnv, os.Stdout)
+		if err != nil {
+			return err
+		}
+		// pre build hooks may generate DAGs
+		dagFiles = fileutil.GetFilesWithSpecificExtension(dagsPath, ".py")
+
 		if len(dagFiles) == 0 && config.CFG.ShowWarnings.GetBool() {
-			i, _ := input.Confirm("Warning: No DAGs found. This will delete any existing DAGs. Are you sure you want to deploy?")
+			i, err := input.Confirm("Warning: No DAGs found. This will delete any existing DAGs. Are you sure you want to deploy?")
+			if err != nil {
+				return err
+			}
 
 			if !i {
 				fmt.Println("Canceling deploy...")
@@ -201,7 +237,8 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 		}
 
 		fmt.Println("\nSuccessfully uploaded DAGs with version " + ansi.Bold(versionID) + " to Astro. Go to the Astro UI to view your data pipeline. The Astro UI takes about 1 minute to update.")
-		return nil
+		hookEnv.DagVersion = versionID
+		return runHooks(hooks.PostDeploy, deployInput.Path, hookEnv, os.Stdout)
 	}
 
 	deployInfo, err := getDeploymentInfo(deployInput.RuntimeID, deployInput.WsID, deployInput.DeploymentName, deployInput.Prompt, domain, client)
@@ -218,9 +255,23 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 	if err != nil {
 		return err
 	}
+
+	hookEnv := hooks.Env{DeploymentID: deployInfo.deploymentID, DeploymentURL: deploymentURL, AirflowURL: deployInfo.webserverURL}
+	err = runHooks(hooks.PreBuild, deployInput.Path, hookEnv, os.Stdout)
+	if err != nil {
+		return err
+	}
+	// pre build hooks may generate DAGs
+	dagFiles = fileutil.GetFilesWithSpecificExtension(dagsPath, ".py")
+
+	// imageTag is the tag of the pushed image, DAG-only deploys do not push one
+	var imageTag string
 	if deployInput.Dags {
 		if len(dagFiles) == 0 && config.CFG.ShowWarnings.GetBool() {
-			i, _ := input.Confirm("Warning: No DAGs found. This will delete any existing DAGs. Are you sure you want to deploy?")
+			i, err := input.Confirm("Warning: No DAGs found. This will delete any existing DAGs. Are you sure you want to deploy?")
+			if err != nil {
+				return err
+			}
 
 			if !i {
 				fmt.Println("Canceling deploy...")
@@ -228,7 +279,7 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			}
 		}
 		if deployInput.Pytest != "" {
-			version, err := buildImage(deployInput.Path, deployInfo.currentVersion, deployInfo.deployImage, deployInput.ImageName, deployInfo.dagDeployEnabled, client)
+			version, err := buildImage(deployInput.Path, deployInfo.currentVersion, deployInfo.deployImage, deployInput.ImageName, deployInfo.dagDeployEnabled, deployInput.Buildx, client)
 			if err != nil {
 				return err
 			}
@@ -253,6 +304,8 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			return err
 		}
 
+		hookEnv.DagVersion = versionID
+
 		fmt.Println("\nSuccessfully uploaded DAGs with version " + ansi.Bold(versionID) + " to Astro. Navigate to the Airflow UI to confirm that your deploy was successful. The Airflow UI takes about 1 minute to update." +
 			"\n\n Access your Deployment: \n" +
 			fmt.Sprintf("\n Deployment View: %s", ansi.Bold(deploymentURL)) +
@@ -267,12 +320,27 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			fmt.Println("No DAGs found. Skipping DAG deploy.")
 		}
 
+		imageHandler := airflowImageHandler(deployInfo.deployImage)
+		// listing the packages runs the images, so it is only done when the diff is asked for
+		dependencyDiff := deployInput.DependencyDiff || deployInput.ConfirmDependencyChanges
+		var previous, packages []sbom.Package
+		if dependencyDiff {
+			previous = previousPackages(deployInput.Path, deployInfo.deploymentID, imageHandler)
+		}
+
 		// Build our image
-		version, err := buildImage(deployInput.Path, deployInfo.currentVersion, deployInfo.deployImage, deployInput.ImageName, deployInfo.dagDeployEnabled, client)
+		version, err := buildImage(deployInput.Path, deployInfo.currentVersion, deployInfo.deployImage, deployInput.ImageName, deployInfo.dagDeployEnabled, deployInput.Buildx, client)
 		if err != nil {
 			return err
 		}
 
+		if dependencyDiff {
+			packages, err = checkDependencyChanges(previous, deployInput.ConfirmDependencyChanges, imageHandler, os.Stdout)
+			if err != nil {
+				return err
+			}
+		}
+
 		if len(dagFiles) > 0 {
 			err = parseOrPytestDAG(deployInput.Pytest, version, deployInput.EnvFile, deployInfo.deployImage, deployInfo.namespace)
 			if err != nil {
@@ -282,6 +350,16 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			fmt.Println("No DAGs found. Skipping testing...")
 		}
 
+		nextTag := "deploy-" + time.Now().UTC().Format("2006-01-02T15-04")
+		imageTag = nextTag
+
+		if deployInput.Report.Format != "" {
+			err = generateImageReport(deployInput.Report, deployInput.Path, deployInfo.deploymentID, nextTag, imageHandler, os.Stdout)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Create the image
 		imageCreateInput := astro.CreateImageInput{
 			Tag:          version,
@@ -292,7 +370,6 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			return err
 		}
 
-		nextTag := "deploy-" + time.Now().UTC().Format("2006-01-02T15-04")
 		registry := getRegistryURL(domain)
 		repository := registry + "/" + deployInfo.organizationID + "/" + deployInfo.deploymentID
 		// TODO: Resolve the edge case where two people push the same nextTag at the same time
@@ -302,20 +379,31 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 		// Splitting out the Bearer part from the token
 		splittedToken := strings.Split(token, " ")[1]
 
-		imageHandler := airflowImageHandler(deployInfo.deployImage)
 		err = imageHandler.Push(registry, registryUsername, splittedToken, remoteImage)
 		if err != nil {
 			return err
 		}
 
+		hookEnv.ImageTag = nextTag
+		err = runHooks(hooks.PostPush, deployInput.Path, hookEnv, os.Stdout)
+		if err != nil {
+			return err
+		}
+
 		// Deploy the image
 		err = imageDeploy(imageCreateRes.ID, deployInfo.deploymentID, repository, nextTag, deployInfo.dagDeployEnabled, client)
 		if err != nil {
 			return err
 		}
 
+		if packages != nil {
+			if err := savePackages(deployInput.Path, deployInfo.deploymentID, packages); err != nil {
+				fmt.Println("Unable to save the python packages of this deploy: " + err.Error())
+			}
+		}
+
 		if deployInfo.dagDeployEnabled && len(dagFiles) > 0 {
-			_, err = deployDags(deployInput.Path, dagsPath, deployInfo.deploymentID, client)
+			hookEnv.DagVersion, err = deployDags(deployInput.Path, dagsPath, deployInfo.deploymentID, client)
 			if err != nil {
 				return err
 			}
@@ -327,7 +415,15 @@ func Deploy(deployInput InputDeploy, client astro.Client) error { //nolint
 			fmt.Sprintf("\n Airflow UI: %s", ansi.Bold(deployInfo.webserverURL)))
 	}
 
-	return nil
+	if deployInput.WaitTimeout > 0 {
+		fmt.Println()
+		err = deployment.WaitForHealthy(deployInfo.deploymentID, imageTag, deployInput.WaitTimeout, client, os.Stdout)
+		if err != nil {
+			return err
+		}
+	}
+	// post deploy hooks run once the deploy is live, after waiting for the deployment to become healthy
+	return runHooks(hooks.PostDeploy, deployInput.Path, hookEnv, os.Stdout)
 }
 
 func getDeploymentInfo(deploymentID, wsID, deploymentName string, prompt bool, cloudDomain string, client astro.Client) (deploymentInfo, error) {
@@ -470,7 +566,7 @@ func getImageName(cloudDomain, deploymentID string, client astro.Client) (deploy
 	return deploymentInfo{namespace: namespace, deployImage: deployImage, currentVersion: currentVersion, organizationID: organizationID, workspaceID: workspaceID, webserverURL: webserverURL, dagDeployEnabled: dagDeployEnabled}, nil
 }
 
-func buildImageWithoutDags(path string, imageHandler airflow.ImageHandler) error {
+func buildImageWithoutDags(path string, buildx types.BuildxConfig, imageHandler airflow.ImageHandler) error {
 	// flag to determine if we are setting the dags folder in dockerignore
 	dagsIgnoreSet := false
 	// flag to determine if dockerignore file was created on runtime
@@ -505,7 +601,7 @@ func buildImageWithoutDags(path string, imageHandler airflow.ImageHandler) error
 
 		dagsIgnoreSet = true
 	}
-	err = imageHandler.Build(types.ImageBuildConfig{Path: path, Output: true, TargetPlatforms: deployImagePlatformSupport})
+	err = imageHandler.Build(types.ImageBuildConfig{Path: path, Output: true, TargetPlatforms: deployImagePlatformSupport, Buildx: buildx})
 	if err != nil {
 		return err
 	}
@@ -552,7 +648,7 @@ func buildImageWithoutDags(path string, imageHandler airflow.ImageHandler) error
 	return nil
 }
 
-func buildImage(path, currentVersion, deployImage, imageName string, dagDeployEnabled bool, client astro.Client) (version string, err error) {
+func buildImage(path, currentVersion, deployImage, imageName string, dagDeployEnabled bool, buildx types.BuildxConfig, client astro.Client) (version string, err error) {
 	imageHandler := airflowImageHandler(deployImage)
 
 	if imageName == "" {
@@ -560,12 +656,12 @@ func buildImage(path, currentVersion, deployImage, imageName string, dagDeployEn
 		fmt.Println(composeImageBuildingPromptMsg)
 
 		if dagDeployEnabled {
-			err := buildImageWithoutDags(path, imageHandler)
+			err := buildImageWithoutDags(path, buildx, imageHandler)
 			if err != nil {
 				return "", err
 			}
 		} else {
-			err := imageHandler.Build(types.ImageBuildConfig{Path: path, Output: true, TargetPlatforms: deployImagePlatformSupport})
+			err := imageHandler.Build(types.ImageBuildConfig{Path: path, Output: true, TargetPlatforms: deployImagePlatformSupport, Buildx: buildx})
 			if err != nil {
 				return "", err
 			}
@@ -708,7 +804,10 @@ func CheckVersion(version string, out io.Writer) {
 		// if current runtime version is not greater than or equal to the latest runtime verion let the user know
 		fmt.Fprintf(out, "WARNING! You are currently running Astro Runtime Version %s\nConsider upgrading to the latest version, Astro Runtime %s\n", version, latestRuntimeVersion)
 	case versions.GreaterThan(version, latestRuntimeVersion):
-		i, _ := input.Confirm("WARNING! The Astro Runtime image in your Dockerfile is classified as \"Beta\" and may not be fit for pipelines in production. Are you sure you want to continue?\n")
+		i, err := input.Confirm("WARNING! The Astro Runtime image in your Dockerfile is classified as \"Beta\" and may not be fit for pipelines in production. Are you sure you want to continue?\n")
+		if err != nil {
+			fmt.Println(err)
+		}
 
 		if !i {
 			fmt.Fprintf(out, "Canceling deploy...")
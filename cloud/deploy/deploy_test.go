@@ -4,18 +4,24 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/astronomer/astro-cli/airflow"
 	"github.com/astronomer/astro-cli/airflow/mocks"
+	"github.com/astronomer/astro-cli/airflow/types"
 	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
 	"github.com/astronomer/astro-cli/astro-client"
 	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/hooks"
 	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/sbom"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -69,6 +75,7 @@ func TestDeployWithoutDagsDeploySuccess(t *testing.T) {
 		mockImageHandler.On("Build", mock.Anything).Return(nil)
 		mockImageHandler.On("Push", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", runtimeImageLabel).Return("", nil)
+		mockImageHandler.On("ListPackages").Return([]sbom.Package{}, nil)
 		mockImageHandler.On("TagLocalImage", mock.Anything).Return(nil)
 		return mockImageHandler
 	}
@@ -188,6 +195,7 @@ func TestDeployWithDagsDeploySuccess(t *testing.T) {
 		mockImageHandler.On("Build", mock.Anything).Return(nil)
 		mockImageHandler.On("Push", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", runtimeImageLabel).Return("", nil)
+		mockImageHandler.On("ListPackages").Return([]sbom.Package{}, nil)
 		mockImageHandler.On("TagLocalImage", mock.Anything).Return(nil)
 		return mockImageHandler
 	}
@@ -351,6 +359,7 @@ func TestDagsDeploySuccess(t *testing.T) {
 		mockImageHandler.On("Build", mock.Anything).Return(nil)
 		mockImageHandler.On("Push", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", runtimeImageLabel).Return("", nil)
+		mockImageHandler.On("ListPackages").Return([]sbom.Package{}, nil)
 		mockImageHandler.On("TagLocalImage", mock.Anything).Return(nil)
 		return mockImageHandler
 	}
@@ -489,6 +498,7 @@ func TestDagsDeployFailed(t *testing.T) {
 	airflowImageHandler = func(image string) airflow.ImageHandler {
 		mockImageHandler.On("Build", mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", runtimeImageLabel).Return("4.2.5", nil)
+		mockImageHandler.On("ListPackages").Return([]sbom.Package{}, nil)
 		return mockImageHandler
 	}
 
@@ -634,6 +644,7 @@ func TestDeployFailure(t *testing.T) {
 	airflowImageHandler = func(image string) airflow.ImageHandler {
 		mockImageHandler.On("Build", mock.Anything).Return(nil)
 		mockImageHandler.On("GetLabel", runtimeImageLabel).Return("4.2.5", nil)
+		mockImageHandler.On("ListPackages").Return([]sbom.Package{}, nil)
 		return mockImageHandler
 	}
 
@@ -692,7 +703,7 @@ func TestBuildImageFailure(t *testing.T) {
 		mockImageHandler.On("Build", mock.Anything, mock.Anything).Return(errMock).Once()
 		return mockImageHandler
 	}
-	_, err := buildImage("./testfiles/", "4.2.5", "", "", false, nil)
+	_, err := buildImage("./testfiles/", "4.2.5", "", "", false, types.BuildxConfig{}, nil)
 	assert.ErrorIs(t, err, errMock)
 
 	airflowImageHandler = func(image string) airflow.ImageHandler {
@@ -703,7 +714,7 @@ func TestBuildImageFailure(t *testing.T) {
 
 	// dockerfile parsing error
 	dockerfile = "Dockerfile.invalid"
-	_, err = buildImage("./testfiles/", "4.2.5", "", "", false, nil)
+	_, err = buildImage("./testfiles/", "4.2.5", "", "", false, types.BuildxConfig{}, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse dockerfile")
 
@@ -711,7 +722,7 @@ func TestBuildImageFailure(t *testing.T) {
 	dockerfile = "Dockerfile"
 	mockClient := new(astro_mocks.Client)
 	mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{}, errMock).Once()
-	_, err = buildImage("./testfiles/", "4.2.5", "", "", false, mockClient)
+	_, err = buildImage("./testfiles/", "4.2.5", "", "", false, types.BuildxConfig{}, mockClient)
 	assert.ErrorIs(t, err, errMock)
 	mockClient.AssertExpectations(t)
 	mockImageHandler.AssertExpectations(t)
@@ -776,3 +787,159 @@ func TestCheckPyTest(t *testing.T) {
 	assert.Contains(t, err.Error(), "at least 1 pytest in your tests directory failed. Fix the issues listed or rerun the command without the '--pytest' flag to deploy")
 	mockContainerHandler.AssertExpectations(t)
 }
+
+func TestDeployHooks(t *testing.T) {
+	mockDeplyResp := []astro.Deployment{
+		{
+			ID:             "test-id",
+			ReleaseName:    "test-name",
+			Workspace:      astro.Workspace{ID: ws},
+			RuntimeRelease: astro.RuntimeRelease{Version: "4.2.5"},
+			DeploymentSpec: astro.DeploymentSpec{
+				Webserver: astro.Webserver{URL: "test-url"},
+			},
+			CreatedAt:        time.Now(),
+			DagDeployEnabled: true,
+		},
+	}
+
+	deployInput := InputDeploy{
+		Path:      "./testfiles/",
+		RuntimeID: "test-id",
+		WsID:      ws,
+		Prompt:    true,
+		Dags:      true,
+		DagsPath:  "./testfiles/dags",
+	}
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	config.CFG.ShowWarnings.SetHomeString("false")
+	defer os.RemoveAll("./testfiles/dags/")
+
+	azureUploader = func(sasLink string, file io.Reader) (string, error) {
+		return "version-id", nil
+	}
+	defer func() { runHooks = hooks.Run }()
+
+	t.Run("hooks run around a dags deploy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{RuntimeReleases: []astro.RuntimeRelease{{Version: "4.2.5"}}}, nil)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockDeplyResp, nil)
+		mockClient.On("InitiateDagDeployment", mock.Anything).Return(astro.InitiateDagDeployment{ID: initiatedDagDeploymentID, DagURL: dagURL}, nil)
+		mockClient.On("ReportDagDeploymentStatus", mock.Anything).Return(astro.DagDeploymentStatus{}, nil)
+
+		var phases []string
+		var env hooks.Env
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			phases = append(phases, phase)
+			env = e
+			// pre build hooks can generate the DAGs to deploy
+			if phase == hooks.PreBuild {
+				assert.NoError(t, os.MkdirAll(deployInput.DagsPath, os.ModePerm))
+				assert.NoError(t, os.WriteFile(filepath.Join(deployInput.DagsPath, "generated.py"), []byte(""), os.ModePerm))
+			}
+			return nil
+		}
+
+		err := Deploy(deployInput, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hooks.PreBuild, hooks.PostDeploy}, phases)
+		assert.Equal(t, "test-id", env.DeploymentID)
+		assert.Equal(t, "version-id", env.DagVersion)
+		mockClient.AssertCalled(t, "InitiateDagDeployment", mock.Anything)
+	})
+
+	t.Run("hooks run around a virtual runtime dags deploy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("InitiateDagDeployment", astro.InitiateDagDeploymentInput{RuntimeID: "vr-test-id"}).Return(astro.InitiateDagDeployment{ID: initiatedDagDeploymentID, DagURL: dagURL}, nil).Once()
+		mockClient.On("ReportDagDeploymentStatus", mock.Anything).Return(astro.DagDeploymentStatus{}, nil).Once()
+
+		var phases []string
+		var env hooks.Env
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			phases = append(phases, phase)
+			env = e
+			return nil
+		}
+
+		vrInput := deployInput
+		vrInput.RuntimeID = "vr-test-id"
+		err := Deploy(vrInput, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hooks.PreBuild, hooks.PostDeploy}, phases)
+		assert.Equal(t, "vr-test-id", env.DeploymentID)
+		assert.Equal(t, "version-id", env.DagVersion)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("post deploy hooks run once the deployment is healthy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockDeplyResp, nil)
+		mockClient.On("InitiateDagDeployment", mock.Anything).Return(astro.InitiateDagDeployment{ID: initiatedDagDeploymentID, DagURL: dagURL}, nil)
+		mockClient.On("ReportDagDeploymentStatus", mock.Anything).Return(astro.DagDeploymentStatus{}, nil)
+
+		var phases []string
+		mockClient.On("GetDeployment", "test-id").Run(func(args mock.Arguments) {
+			phases = append(phases, "wait")
+		}).Return(astro.Deployment{ID: "test-id", Status: "HEALTHY"}, nil).Once()
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			phases = append(phases, phase)
+			return nil
+		}
+
+		waitInput := deployInput
+		waitInput.WaitTimeout = time.Minute
+		err := Deploy(waitInput, mockClient)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hooks.PreBuild, "wait", hooks.PostDeploy}, phases)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("post deploy hooks do not run if the deployment is unhealthy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockDeplyResp, nil)
+		mockClient.On("InitiateDagDeployment", mock.Anything).Return(astro.InitiateDagDeployment{ID: initiatedDagDeploymentID, DagURL: dagURL}, nil)
+		mockClient.On("ReportDagDeploymentStatus", mock.Anything).Return(astro.DagDeploymentStatus{}, nil)
+		mockClient.On("GetDeployment", "test-id").Return(astro.Deployment{ID: "test-id", Status: "UNHEALTHY"}, nil).Once()
+
+		var phases []string
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			phases = append(phases, phase)
+			return nil
+		}
+
+		waitInput := deployInput
+		waitInput.WaitTimeout = time.Minute
+		err := Deploy(waitInput, mockClient)
+		assert.ErrorContains(t, err, "unhealthy")
+		assert.Equal(t, []string{hooks.PreBuild}, phases)
+	})
+
+	t.Run("failing pre build hook aborts the deploy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockDeplyResp, nil)
+
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			return errMock
+		}
+
+		err := Deploy(deployInput, mockClient)
+		assert.ErrorIs(t, err, errMock)
+		mockClient.AssertNotCalled(t, "InitiateDagDeployment", mock.Anything)
+	})
+}
+
+func TestUploadDagsProxy(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer proxy.Close()
+	t.Setenv(config.CFG.HTTPProxy.EnvName(), proxy.URL)
+	t.Setenv(config.CFG.DagUploadMaxRetries.EnvName(), "0")
+
+	_, err := uploadDags("http://astronomer.invalid/dags/dags.tar?sig=test", bytes.NewReader([]byte("dags")))
+	assert.Error(t, err)
+	assert.Equal(t, "astronomer.invalid", proxied)
+}
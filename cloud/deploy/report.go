@@ -0,0 +1,129 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/astronomer/astro-cli/airflow"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/astronomer/astro-cli/pkg/sbom"
+	"github.com/pkg/errors"
+)
+
+const (
+	deployReportsDir = "deploys"
+	reportFilePerm   = 0o644
+	reportDirPerm    = 0o755
+)
+
+var (
+	errVulnerabilitiesFound = errors.New("vulnerabilities at or above the --fail-on-severity level were found in the image, canceling deploy")
+	errInvalidSBOMFormat    = errors.New("invalid SBOM format, use one of: spdx, cyclonedx. Got:")
+)
+
+// ImageReport configures the SBOM and vulnerability report generated for the image before it is pushed
+type ImageReport struct {
+	// Format is the SBOM format, spdx or cyclonedx, no report is generated when empty
+	Format string
+	// VulnerabilityDB is the path to an offline vulnerability database to check the SBOM against
+	VulnerabilityDB string
+	// FailOnSeverity blocks the deploy when a vulnerability of at least this severity is found
+	FailOnSeverity string
+}
+
+type vulnerabilityReport struct {
+	Image        string         `json:"image"`
+	DeploymentID string         `json:"deploymentId"`
+	Tag          string         `json:"tag"`
+	SBOM         string         `json:"sbom"`
+	Database     string         `json:"database"`
+	Findings     []sbom.Finding `json:"findings"`
+}
+
+// reportDir is where the SBOM and vulnerability reports of every deploy of the project are kept. It is in the
+// .dockerignore and .gitignore of new projects so reports are not built into the next image.
+func reportDir(path string) string {
+	return filepath.Join(path, config.ConfigDir, deployReportsDir)
+}
+
+// generateImageReport writes an SBOM of the built image to the project's deploy reports and checks it against the
+// configured vulnerability database, returning errVulnerabilitiesFound if the deploy should be blocked
+func generateImageReport(report ImageReport, path, deploymentID, tag string, imageHandler airflow.ImageHandler, out io.Writer) error {
+	if !sbom.ValidFormat(report.Format) {
+		return fmt.Errorf("%w %s", errInvalidSBOMFormat, report.Format)
+	}
+
+	fmt.Fprintln(out, "Generating SBOM of the image...")
+	packages, err := imageHandler.ListPackages()
+	if err != nil {
+		return err
+	}
+	doc, err := sbom.Generate(report.Format, deploymentID+":"+tag, packages, time.Now())
+	if err != nil {
+		return err
+	}
+
+	dir := reportDir(path)
+	if err := os.MkdirAll(dir, reportDirPerm); err != nil {
+		return err
+	}
+	sbomFile := filepath.Join(dir, fmt.Sprintf("%s-%s.%s.json", deploymentID, tag, report.Format))
+	if err := os.WriteFile(sbomFile, doc, reportFilePerm); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "SBOM with %d packages saved to %s\n", len(packages), sbomFile)
+
+	if report.VulnerabilityDB == "" {
+		return nil
+	}
+
+	db, err := sbom.LoadDatabase(report.VulnerabilityDB)
+	if err != nil {
+		return err
+	}
+	findings := db.Scan(packages)
+
+	reportFile := filepath.Join(dir, fmt.Sprintf("%s-%s.vulnerabilities.json", deploymentID, tag))
+	data, err := json.MarshalIndent(vulnerabilityReport{
+		Image:        deploymentID + ":" + tag,
+		DeploymentID: deploymentID,
+		Tag:          tag,
+		SBOM:         sbomFile,
+		Database:     report.VulnerabilityDB,
+		Findings:     findings,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(reportFile, data, reportFilePerm); err != nil {
+		return err
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No known vulnerabilities found in the image")
+		return nil
+	}
+
+	tab := printutil.Table{
+		Padding:        []int{20, 30, 20, 10, 20},
+		DynamicPadding: true,
+		Header:         []string{"ID", "PACKAGE", "VERSION", "SEVERITY", "FIXED VERSION"},
+	}
+	for _, f := range findings {
+		tab.AddRow([]string{f.ID, f.Package, f.Version, f.Severity, f.FixedVersion}, false)
+	}
+	fmt.Fprintf(out, "\n%d known vulnerabilities found in the image, full report saved to %s\n\n", len(findings), reportFile)
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+
+	if report.FailOnSeverity != "" && len(sbom.AtOrAbove(findings, report.FailOnSeverity)) > 0 {
+		return errVulnerabilitiesFound
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/airflow/mocks"
+	"github.com/astronomer/astro-cli/pkg/sbom"
+	"github.com/stretchr/testify/assert"
+)
+
+var reportPackages = []sbom.Package{
+	{Name: "requests", Version: "2.28.1", Type: sbom.PythonPackage},
+	{Name: "openssl", Version: "3.0.2", Type: sbom.DebianPackage},
+}
+
+func writeVulnerabilityDB(t *testing.T, dir string) string {
+	dbFile := filepath.Join(dir, "db.json")
+	err := os.WriteFile(dbFile, []byte(`{"advisories": [
+		{"id": "CVE-1", "type": "pypi", "package": "requests", "severity": "medium", "fixed": "2.31.0"},
+		{"id": "CVE-2", "type": "deb", "package": "openssl", "severity": "critical", "versions": ["3.0.2"]}
+	]}`), 0o600)
+	assert.NoError(t, err)
+	return dbFile
+}
+
+func TestGenerateImageReport(t *testing.T) {
+	t.Run("sbom only", func(t *testing.T) {
+		dir := t.TempDir()
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(reportPackages, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := generateImageReport(ImageReport{Format: sbom.CycloneDX}, dir, "test-id", "deploy-tag", imageHandler, out)
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(reportDir(dir), "test-id-deploy-tag.cyclonedx.json"))
+		assert.NoFileExists(t, filepath.Join(reportDir(dir), "test-id-deploy-tag.vulnerabilities.json"))
+		imageHandler.AssertExpectations(t)
+	})
+
+	t.Run("vulnerabilities below threshold", func(t *testing.T) {
+		dir := t.TempDir()
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(reportPackages[:1], nil).Once()
+
+		out := new(bytes.Buffer)
+		report := ImageReport{Format: sbom.SPDX, VulnerabilityDB: writeVulnerabilityDB(t, dir), FailOnSeverity: "high"}
+		err := generateImageReport(report, dir, "test-id", "deploy-tag", imageHandler, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "CVE-1")
+		assert.FileExists(t, filepath.Join(reportDir(dir), "test-id-deploy-tag.vulnerabilities.json"))
+	})
+
+	t.Run("vulnerabilities at threshold", func(t *testing.T) {
+		dir := t.TempDir()
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(reportPackages, nil).Once()
+
+		out := new(bytes.Buffer)
+		report := ImageReport{Format: sbom.SPDX, VulnerabilityDB: writeVulnerabilityDB(t, dir), FailOnSeverity: "high"}
+		err := generateImageReport(report, dir, "test-id", "deploy-tag", imageHandler, out)
+		assert.ErrorIs(t, err, errVulnerabilitiesFound)
+		assert.Contains(t, out.String(), "CVE-2")
+	})
+
+	t.Run("list packages error", func(t *testing.T) {
+		imageHandler := new(mocks.ImageHandler)
+		imageHandler.On("ListPackages").Return(nil, errMock).Once()
+
+		err := generateImageReport(ImageReport{Format: sbom.SPDX}, t.TempDir(), "test-id", "deploy-tag", imageHandler, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := generateImageReport(ImageReport{Format: "xml"}, t.TempDir(), "test-id", "deploy-tag", new(mocks.ImageHandler), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidSBOMFormat)
+	})
+}
@@ -1,7 +1,6 @@
 package deployment
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -28,7 +27,6 @@ import (
 var (
 	errInvalidDeployment    = errors.New("the Deployment specified was not found in this workspace. Your account or API Key may not have access to the deployment specified")
 	ErrInvalidDeploymentKey = errors.New("invalid Deployment selected")
-	errTimedOut             = errors.New("timed out waiting for the deployment to become healthy")
 	// Monkey patched to write unit tests
 	createDeployment = Create
 	CleanOutput      = false
@@ -41,17 +39,13 @@ const (
 	notApplicable  = "N/A"
 )
 
-var (
-	sleepTime  = 180
-	tickNum    = 10
-	timeoutNum = 180
-)
-
 func newTableOut() *printutil.Table {
 	return &printutil.Table{
 		Padding:        []int{30, 50, 10, 50, 10, 10, 10},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "NAMESPACE", "CLUSTER", "DEPLOYMENT ID", "RUNTIME VERSION", "DAG DEPLOY ENABLED"},
+		Fields:         []string{"name", "release_name", "cluster_name", "id", "runtime_version", "dag_deploy_enabled"},
+		FieldTypes:     map[string]printutil.FieldType{"dag_deploy_enabled": printutil.BoolField},
 	}
 }
 
@@ -60,6 +54,8 @@ func newTableOutAll() *printutil.Table {
 		Padding:        []int{30, 50, 10, 50, 10, 10, 10},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "WORKSPACE", "NAMESPACE", "CLUSTER", "DEPLOYMENT ID", "RUNTIME VERSION", "DAG DEPLOY ENABLED"},
+		Fields:         []string{"name", "workspace_name", "release_name", "cluster_name", "id", "runtime_version", "dag_deploy_enabled"},
+		FieldTypes:     map[string]printutil.FieldType{"dag_deploy_enabled": printutil.BoolField},
 	}
 }
 
@@ -99,57 +95,7 @@ func List(ws string, all bool, client astro.Client, out io.Writer) error {
 	return tab.Print(out)
 }
 
-func Logs(deploymentID, ws, deploymentName string, warnLogs, errorLogs, infoLogs bool, logCount int, client astro.Client) error {
-	logLevels := []string{}
-
-	// log level
-	if warnLogs {
-		logLevels = append(logLevels, "WARN")
-	}
-	if errorLogs {
-		logLevels = append(logLevels, "ERROR")
-	}
-	if infoLogs {
-		logLevels = append(logLevels, "INFO")
-	}
-	if len(logLevels) == 0 {
-		logLevels = []string{"WARN", "ERROR", "INFO"}
-	}
-
-	// get deployment
-	deployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
-	if err != nil {
-		return err
-	}
-
-	deploymentID = deployment.ID
-
-	// deployment logs request
-	vars := map[string]interface{}{
-		"deploymentId":  deploymentID,
-		"logCountLimit": logCount,
-		"start":         "-24hrs",
-		"logLevels":     logLevels,
-	}
-
-	deploymentHistoryResp, err := client.GetDeploymentHistory(vars)
-	if err != nil {
-		return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
-	}
-
-	Logs := deploymentHistoryResp.SchedulerLogs
-
-	if len(Logs) == 0 {
-		fmt.Println("No matching logs have been recorded in the past 24 hours for Deployment " + deployment.Label)
-		return nil
-	}
-
-	fmt.Println(Logs)
-
-	return nil
-}
-
-func Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitForStatus, highAvailability bool) error { //nolint
+func Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitTimeout time.Duration, highAvailability bool) error { //nolint
 	var organizationID string
 	var currentWorkspace astro.Workspace
 	var dagDeployEnabled bool
@@ -212,7 +158,10 @@ func Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeplo
 	// label input
 	if label == "" {
 		fmt.Println("Please specify a name for your Deployment")
-		label = input.Text(ansi.Bold("\nDeployment name: "))
+		label, err = input.Prompt(ansi.Bold("\nDeployment name: "), "pass --name")
+		if err != nil {
+			return err
+		}
 		if label == "" {
 			return errors.New("you must give your Deployment a name")
 		}
@@ -271,8 +220,8 @@ func Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeplo
 		return err
 	}
 
-	if waitForStatus {
-		err = healthPoll(d.ID, workspaceID, client)
+	if waitTimeout > 0 {
+		err = WaitForHealthy(d.ID, "", waitTimeout, client, os.Stdout)
 		if err != nil {
 			errOutput := createOutput(workspaceID, &d)
 			if errOutput != nil {
@@ -383,7 +332,10 @@ func selectCluster(clusterID, organizationID string, client astro.Client) (newCl
 		}
 
 		clusterTab.Print(os.Stdout)
-		choice := input.Text("\n> ")
+		choice, err := input.Prompt("\n> ", "pass --cluster-id")
+		if err != nil {
+			return "", err
+		}
 		selected, ok := clusterMap[choice]
 		if !ok {
 			return "", ErrInvalidDeploymentKey
@@ -446,40 +398,6 @@ func useSharedClusterOrSelectDedicatedCluster(cloudProvider, region, organizatio
 	return derivedClusterID, nil
 }
 
-func healthPoll(deploymentID, ws string, client astro.Client) error {
-	fmt.Printf("Waiting for the deployment to become healthy…\n\nThis may take a few minutes\n")
-	time.Sleep(time.Duration(sleepTime) * time.Second)
-	buf := new(bytes.Buffer)
-	timeout := time.After(time.Duration(timeoutNum) * time.Second)
-	ticker := time.NewTicker(time.Duration(tickNum) * time.Second)
-	for {
-		select {
-		// Got a timeout! fail with a timeout error
-		case <-timeout:
-			return errTimedOut
-		// Got a tick, we should check if deployment is healthy
-		case <-ticker.C:
-			buf.Reset()
-			deployments, err := GetDeployments(ws, client)
-			if err != nil {
-				return err
-			}
-
-			var currentDeployment astro.Deployment
-			for i := range deployments {
-				if deployments[i].ID == deploymentID {
-					currentDeployment = deployments[i]
-				}
-			}
-			if currentDeployment.Status == "HEALTHY" {
-				fmt.Printf("Deployment %s is now healthy\n", currentDeployment.Label)
-				return nil
-			}
-			continue
-		}
-	}
-}
-
 func Update(deploymentID, label, ws, description, deploymentName, dagDeploy, executor string, schedulerAU, schedulerReplicas int, wQueueList []astro.WorkerQueue, forceDeploy bool, client astro.Client) error {
 	var queueCreateUpdate, confirmWithUser bool
 	// get deployment
@@ -570,8 +488,11 @@ func Update(deploymentID, label, ws, description, deploymentName, dagDeploy, exe
 	// confirm changes with user only if force=false
 	if !forceDeploy {
 		if confirmWithUser {
-			y, _ := input.Confirm(
+			y, err := input.Confirm(
 				fmt.Sprintf("\nAre you sure you want to update the %s Deployment?", ansi.Bold(currentDeployment.Label)))
+			if err != nil {
+				return err
+			}
 
 			if !y {
 				fmt.Println("Canceling Deployment update")
@@ -614,8 +535,11 @@ func Delete(deploymentID, ws, deploymentName string, forceDelete bool, client as
 
 	// prompt user
 	if !forceDelete {
-		i, _ := input.Confirm(
+		i, err := input.Confirm(
 			fmt.Sprintf("\nAre you sure you want to delete the %s Deployment?", ansi.Bold(currentDeployment.Label)))
+		if err != nil {
+			return err
+		}
 
 		if !i {
 			fmt.Println("Canceling deployment deletion")
@@ -692,7 +616,10 @@ var SelectDeployment = func(deployments []astro.Deployment, message string) (ast
 	}
 
 	tab.Print(os.Stdout)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass --deployment-id or --deployment-name")
+	if err != nil {
+		return astro.Deployment{}, err
+	}
 	selected, ok := deployMap[choice]
 	if !ok {
 		return astro.Deployment{}, ErrInvalidDeploymentKey
@@ -769,7 +696,7 @@ func deploymentSelectionProcess(ws string, deployments []astro.Deployment, clien
 		schedulerReplicas := configOption.Components.Scheduler.Replicas.Default
 
 		// walk user through creating a deployment
-		err = createDeployment("", ws, "", "", runtimeVersion, "disable", CeleryExecutor, "", "", "medium", schedulerAU, schedulerReplicas, client, coreClient, false, false)
+		err = createDeployment("", ws, "", "", runtimeVersion, "disable", CeleryExecutor, "", "", "medium", schedulerAU, schedulerReplicas, client, coreClient, 0, false)
 		if err != nil {
 			return astro.Deployment{}, err
 		}
@@ -0,0 +1,70 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/pkg/errors"
+)
+
+const (
+	healthyStatus   = "HEALTHY"
+	unhealthyStatus = "UNHEALTHY"
+	// DefaultWaitTimeout is how long --wait waits for a deployment to become healthy by default
+	DefaultWaitTimeout = 10 * time.Minute
+)
+
+var (
+	errTimedOut  = errors.New("timed out waiting for the deployment to become healthy")
+	errUnhealthy = errors.New("the deployment is unhealthy")
+	// Monkey patched to write unit tests
+	healthPollInterval = 10 * time.Second
+)
+
+// WaitForHealthy polls a deployment until it is healthy and prints its status every time it changes.
+// If imageTag is not empty it also waits for the deployment to run that image. It returns errUnhealthy as soon
+// as the deployment is unhealthy and errTimedOut if it is not healthy after timeout.
+func WaitForHealthy(deploymentID, imageTag string, timeout time.Duration, client astro.Client, out io.Writer) error {
+	fmt.Fprintf(out, "Waiting up to %s for the deployment to become healthy…\n", timeout)
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+
+	var lastState string
+	for {
+		currentDeployment, err := client.GetDeployment(deploymentID)
+		if err != nil {
+			return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
+		}
+		state := currentDeployment.Status
+		imageLive := imageTag == "" || currentDeployment.DeploymentSpec.Image.Tag == imageTag
+		if !imageLive {
+			state += ", waiting for image " + imageTag
+		}
+		if state != lastState {
+			if lastState == "" {
+				fmt.Fprintf(out, "Deployment %s is %s\n", currentDeployment.Label, state)
+			} else {
+				fmt.Fprintf(out, "Deployment %s: %s → %s\n", currentDeployment.Label, lastState, state)
+			}
+			lastState = state
+		}
+
+		switch {
+		case currentDeployment.Status == unhealthyStatus:
+			return fmt.Errorf("%w: %s", errUnhealthy, currentDeployment.Label)
+		case currentDeployment.Status == healthyStatus && imageLive:
+			fmt.Fprintf(out, "Deployment %s is now healthy\n", ansi.Bold(currentDeployment.Label))
+			return nil
+		}
+
+		select {
+		case <-deadline:
+			return fmt.Errorf("%w after %s, its status is %s", errTimedOut, timeout, lastState)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package deployment
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForHealthy(t *testing.T) {
+	defer func(interval time.Duration) { healthPollInterval = interval }(healthPollInterval)
+	healthPollInterval = time.Millisecond
+
+	withImage := func(status, tag string) astro.Deployment {
+		d := astro.Deployment{ID: "test-id", Label: "test-deployment", Status: status}
+		d.DeploymentSpec.Image.Tag = tag
+		return d
+	}
+
+	t.Run("prints state transitions until healthy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "test-id").Return(withImage("CREATING", ""), nil).Twice()
+		mockClient.On("GetDeployment", "test-id").Return(withImage("DEPLOYING", ""), nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(withImage("HEALTHY", ""), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := WaitForHealthy("test-id", "", time.Second, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Deployment test-deployment is CREATING\n")
+		assert.Contains(t, out.String(), "Deployment test-deployment: CREATING → DEPLOYING\n")
+		assert.Contains(t, out.String(), "Deployment test-deployment: DEPLOYING → HEALTHY\n")
+		assert.Contains(t, out.String(), "is now healthy")
+		assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("is CREATING")))
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("waits for the deployed image", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "test-id").Return(withImage("HEALTHY", "old-tag"), nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(withImage("HEALTHY", "new-tag"), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := WaitForHealthy("test-id", "new-tag", time.Second, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Deployment test-deployment is HEALTHY, waiting for image new-tag\n")
+		assert.Contains(t, out.String(), "Deployment test-deployment: HEALTHY, waiting for image new-tag → HEALTHY\n")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fails fast when unhealthy", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "test-id").Return(withImage("DEPLOYING", ""), nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(withImage("UNHEALTHY", ""), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := WaitForHealthy("test-id", "", time.Minute, mockClient, out)
+		assert.ErrorIs(t, err, errUnhealthy)
+		assert.Contains(t, out.String(), "DEPLOYING → UNHEALTHY")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "test-id").Return(withImage("DEPLOYING", ""), nil)
+
+		out := new(bytes.Buffer)
+		err := WaitForHealthy("test-id", "", 20*time.Millisecond, mockClient, out)
+		assert.ErrorIs(t, err, errTimedOut)
+		assert.Contains(t, err.Error(), "its status is DEPLOYING")
+	})
+
+	t.Run("returns an error when the deployment can not be fetched", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("GetDeployment", "test-id").Return(astro.Deployment{}, errMock).Once()
+
+		err := WaitForHealthy("test-id", "", time.Second, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+		mockClient.AssertExpectations(t)
+	})
+}
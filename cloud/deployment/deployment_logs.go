@@ -0,0 +1,226 @@
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLogStart = "-24hrs"
+	jsonLogOutput   = "json"
+)
+
+var (
+	errInvalidLogOutput = errors.New("is not a valid output format, it can only be json")
+	errInvalidLogTime   = errors.New("is not a duration like 5m or 2h or an RFC3339 timestamp")
+	errFollowUntil      = errors.New("--follow can not be used with --until")
+
+	// Monkey patched to write unit tests
+	logPollInterval = 5 * time.Second
+)
+
+// LogOptions select which scheduler logs of a deployment are shown and how
+type LogOptions struct {
+	WarnLogs, ErrorLogs, InfoLogs bool
+	LogCount                      int
+	// Since and Until are durations before now like 30m or RFC3339 timestamps
+	Since, Until string
+	// Search is a regular expression the logs must match
+	Search string
+	// Follow keeps polling for new logs until the command is interrupted
+	Follow bool
+	// Output is empty to print raw logs or json to print one JSON object per line
+	Output string
+}
+
+// logEntry is a log printed with --output json
+type logEntry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+// Logs prints the scheduler logs of a deployment from the past 24 hours, or the window given by options.Since and
+// options.Until. Astro only filters logs by start time, so the search pattern and options.Until are applied to the
+// logs it returns, so to at most options.LogCount logs per request.
+// With options.Follow it keeps polling for logs newer than the last one printed.
+// The deployment history query only returns scheduler logs, triggerer and worker logs can not be selected until
+// Astro has a query for them.
+func Logs(deploymentID, ws, deploymentName string, options LogOptions, client astro.Client, out io.Writer) error {
+	if options.Output != "" && options.Output != jsonLogOutput {
+		return fmt.Errorf("%s %w", options.Output, errInvalidLogOutput)
+	}
+	if options.Follow && options.Until != "" {
+		return errFollowUntil
+	}
+	var search *regexp.Regexp
+	if options.Search != "" {
+		var err error
+		search, err = regexp.Compile(options.Search)
+		if err != nil {
+			return fmt.Errorf("--search %s: %w", options.Search, err)
+		}
+	}
+	now := time.Now()
+	vars, err := logVariables(&options, now)
+	if err != nil {
+		return err
+	}
+	var until time.Time
+	if options.Until != "" {
+		until, err = parseLogTime(options.Until, now)
+		if err != nil {
+			return fmt.Errorf("--until %s %w", options.Until, err)
+		}
+	}
+
+	// get deployment
+	deployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	vars["deploymentId"] = deployment.ID
+
+	printer := logPrinter{search: search, until: until, options: &options, out: out}
+	for {
+		deploymentHistoryResp, err := client.GetDeploymentHistory(vars)
+		if err != nil {
+			return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
+		}
+		err = printer.print(deploymentHistoryResp.SchedulerLogs)
+		if err != nil {
+			return err
+		}
+		if !options.Follow {
+			break
+		}
+		// only ask for the logs from the last one printed, printer skips the ones already printed at that time
+		if printer.lastTimestamp != "" {
+			vars["start"] = printer.lastTimestamp
+		}
+		time.Sleep(logPollInterval)
+	}
+
+	if printer.printed == 0 && options.Output == "" {
+		window := "in the past 24 hours"
+		if options.Since != "" || options.Until != "" {
+			window = "in the requested time window"
+		}
+		fmt.Fprintf(out, "No matching logs have been recorded %s for Deployment %s\n", window, deployment.Label)
+	}
+	return nil
+}
+
+// logVariables returns the variables of the deployment history request for options, with times relative to now
+func logVariables(options *LogOptions, now time.Time) (map[string]interface{}, error) {
+	logLevels := []string{}
+	if options.WarnLogs {
+		logLevels = append(logLevels, "WARN")
+	}
+	if options.ErrorLogs {
+		logLevels = append(logLevels, "ERROR")
+	}
+	if options.InfoLogs {
+		logLevels = append(logLevels, "INFO")
+	}
+	if len(logLevels) == 0 {
+		logLevels = []string{"WARN", "ERROR", "INFO"}
+	}
+	vars := map[string]interface{}{
+		"logCountLimit": options.LogCount,
+		"start":         defaultLogStart,
+		"logLevels":     logLevels,
+	}
+
+	if options.Since != "" {
+		start, err := parseLogTime(options.Since, now)
+		if err != nil {
+			return nil, fmt.Errorf("--since %s %w", options.Since, err)
+		}
+		vars["start"] = start.Format(time.RFC3339)
+	}
+	return vars, nil
+}
+
+// parseLogTime returns the time of value in UTC, value is either a duration before now or an RFC3339 timestamp
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d).UTC().Truncate(time.Second), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errInvalidLogTime
+	}
+	return t.UTC(), nil
+}
+
+// logPrinter prints the logs matching search that are not newer than until and remembers the ones it printed at the
+// last timestamp so that polling from that timestamp does not print them twice
+type logPrinter struct {
+	search        *regexp.Regexp
+	until         time.Time
+	options       *LogOptions
+	out           io.Writer
+	printed       int
+	lastTimestamp string
+	lastLogs      map[string]bool
+}
+
+func (p *logPrinter) print(logs []astro.SchedulerLog) error {
+	for i := range logs {
+		log := &logs[i]
+		if log.Timestamp == p.lastTimestamp && p.lastLogs[log.Raw] {
+			continue
+		}
+		if log.Timestamp != p.lastTimestamp || p.lastLogs == nil {
+			p.lastTimestamp = log.Timestamp
+			p.lastLogs = map[string]bool{}
+		}
+		p.lastLogs[log.Raw] = true
+
+		if p.search != nil && !p.search.MatchString(strings.TrimRight(log.Raw, "\n")) {
+			continue
+		}
+		if p.after(log) {
+			continue
+		}
+		if err := p.printLog(log); err != nil {
+			return err
+		}
+		p.printed++
+	}
+	return nil
+}
+
+// after is true if log is newer than until, logs without a timestamp that can be read are kept
+func (p *logPrinter) after(log *astro.SchedulerLog) bool {
+	if p.until.IsZero() {
+		return false
+	}
+	timestamp, err := time.Parse(time.RFC3339, log.Timestamp)
+	return err == nil && timestamp.After(p.until)
+}
+
+func (p *logPrinter) printLog(log *astro.SchedulerLog) error {
+	if p.options.Output == jsonLogOutput {
+		line, err := json.Marshal(logEntry{
+			Timestamp: log.Timestamp,
+			Level:     log.Level,
+			Message:   strings.TrimRight(log.Raw, "\n"),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(p.out, string(line))
+		return nil
+	}
+	fmt.Fprintln(p.out, strings.TrimRight(log.Raw, "\n"))
+	return nil
+}
@@ -0,0 +1,175 @@
+package deployment
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogs(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	deploymentID := "test-id"
+	logCount := 1
+	logLevels := []string{"WARN", "ERROR", "INFO"}
+	mockInput := map[string]interface{}{
+		"deploymentId":  deploymentID,
+		"logCountLimit": logCount,
+		"start":         "-24hrs",
+		"logLevels":     logLevels,
+	}
+	t.Run("success", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{DeploymentID: deploymentID, SchedulerLogs: []astro.SchedulerLog{{Raw: "test log line"}}}, nil).Once()
+
+		err := Logs(deploymentID, ws, "", LogOptions{WarnLogs: true, ErrorLogs: true, InfoLogs: true, LogCount: logCount}, mockClient, new(bytes.Buffer))
+		assert.NoError(t, err)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("success without deployment", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}, {ID: "test-id-1"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{DeploymentID: deploymentID, SchedulerLogs: []astro.SchedulerLog{}}, nil).Once()
+
+		// mock os.Stdin
+		input := []byte("1")
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = w.Write(input)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Close()
+		stdin := os.Stdin
+		// Restore stdin right after the test.
+		defer func() { os.Stdin = stdin }()
+		os.Stdin = r
+
+		err = Logs("", ws, "", LogOptions{LogCount: logCount}, mockClient, new(bytes.Buffer))
+		assert.NoError(t, err)
+
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{}, errMock).Once()
+
+		err := Logs(deploymentID, ws, "", LogOptions{WarnLogs: true, ErrorLogs: true, InfoLogs: true, LogCount: logCount}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestLogsFilters(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	deploymentLogs := []astro.SchedulerLog{
+		{Timestamp: "2023-01-01T00:00:00Z", Raw: "scheduler started", Level: "INFO"},
+		{Timestamp: "2023-01-01T00:00:01Z", Raw: "task failed\n", Level: "ERROR"},
+		{Timestamp: "2023-01-01T00:10:00.123Z", Raw: "task retried", Level: "WARN"},
+	}
+
+	t.Run("time window", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", map[string]interface{}{
+			"deploymentId":  "test-id",
+			"logCountLimit": 0,
+			"start":         "2023-01-01T00:00:00Z",
+			"logLevels":     []string{"WARN", "ERROR", "INFO"},
+		}).Return(astro.DeploymentHistory{SchedulerLogs: deploymentLogs}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		options := LogOptions{Since: "2023-01-01T01:00:00+01:00", Until: "2023-01-01T00:05:00Z"}
+		err := Logs("test-id", ws, "", options, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "scheduler started\ntask failed\n", buf.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("search and json output", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mock.Anything).Return(astro.DeploymentHistory{SchedulerLogs: deploymentLogs}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := Logs("test-id", ws, "", LogOptions{Search: "fail(ed)?$", Output: "json"}, mockClient, buf)
+		assert.NoError(t, err)
+		var entry logEntry
+		err = json.Unmarshal(buf.Bytes(), &entry)
+		assert.NoError(t, err)
+		assert.Equal(t, logEntry{Timestamp: "2023-01-01T00:00:01Z", Level: "ERROR", Message: "task failed"}, entry)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("no matching logs", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id", Label: "test"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mock.Anything).Return(astro.DeploymentHistory{SchedulerLogs: deploymentLogs}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := Logs("test-id", ws, "", LogOptions{Search: "nothing", Since: "1h"}, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "No matching logs have been recorded in the requested time window for Deployment test")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		err := Logs("test-id", ws, "", LogOptions{Output: "yaml"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidLogOutput)
+		err = Logs("test-id", ws, "", LogOptions{Follow: true, Until: "1h"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errFollowUntil)
+		err = Logs("test-id", ws, "", LogOptions{Since: "yesterday"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidLogTime)
+		err = Logs("test-id", ws, "", LogOptions{Until: "tomorrow"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errInvalidLogTime)
+		err = Logs("test-id", ws, "", LogOptions{Search: "("}, mockClient, new(bytes.Buffer))
+		assert.Error(t, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestLogsFollow(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	defer func(interval time.Duration) { logPollInterval = interval }(logPollInterval)
+	logPollInterval = 0
+
+	first := []astro.SchedulerLog{
+		{Timestamp: "2023-01-01T00:00:00Z", Raw: "line 1"},
+		{Timestamp: "2023-01-01T00:00:01Z", Raw: "line 2"},
+	}
+	// polling from the last timestamp returns the last log again
+	second := []astro.SchedulerLog{
+		{Timestamp: "2023-01-01T00:00:01Z", Raw: "line 2"},
+		{Timestamp: "2023-01-01T00:00:01Z", Raw: "line 3"},
+	}
+	mockClient := new(astro_mocks.Client)
+	mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+	mockClient.On("GetDeploymentHistory", mock.MatchedBy(func(vars map[string]interface{}) bool {
+		return vars["start"] == defaultLogStart
+	})).Return(astro.DeploymentHistory{SchedulerLogs: first}, nil).Once()
+	mockClient.On("GetDeploymentHistory", mock.MatchedBy(func(vars map[string]interface{}) bool {
+		return vars["start"] == "2023-01-01T00:00:01Z"
+	})).Return(astro.DeploymentHistory{SchedulerLogs: second}, nil).Once()
+	mockClient.On("GetDeploymentHistory", mock.Anything).Return(astro.DeploymentHistory{}, errMock).Once()
+
+	buf := new(bytes.Buffer)
+	err := Logs("test-id", ws, "", LogOptions{Follow: true}, mockClient, buf)
+	assert.ErrorIs(t, err, errMock)
+	assert.Equal(t, "line 1\nline 2\nline 3\n", buf.String())
+	mockClient.AssertExpectations(t)
+}
@@ -186,7 +186,7 @@ func TestGetDeployment(t *testing.T) {
 			},
 		}, nil).Once()
 		// mock createDeployment
-		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitForStatus, highAvailability bool) error {
+		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitTimeout time.Duration, highAvailability bool) error {
 			return errMock
 		}
 
@@ -229,7 +229,7 @@ func TestGetDeployment(t *testing.T) {
 			},
 		}, nil).Once()
 		// mock createDeployment
-		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitForStatus, highAvailability bool) error {
+		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitTimeout time.Duration, highAvailability bool) error {
 			return nil
 		}
 		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, errMock).Once()
@@ -272,7 +272,7 @@ func TestGetDeployment(t *testing.T) {
 			},
 		}, nil).Once()
 		// mock createDeployment
-		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitForStatus, highAvailability bool) error {
+		createDeployment = func(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize string, schedulerAU, schedulerReplicas int, client astro.Client, coreClient astrocore.CoreClient, waitTimeout time.Duration, highAvailability bool) error {
 			return nil
 		}
 
@@ -308,66 +308,6 @@ func TestGetDeployment(t *testing.T) {
 	})
 }
 
-func TestLogs(t *testing.T) {
-	testUtil.InitTestConfig(testUtil.CloudPlatform)
-	deploymentID := "test-id"
-	logCount := 1
-	logLevels := []string{"WARN", "ERROR", "INFO"}
-	mockInput := map[string]interface{}{
-		"deploymentId":  deploymentID,
-		"logCountLimit": logCount,
-		"start":         "-24hrs",
-		"logLevels":     logLevels,
-	}
-	t.Run("success", func(t *testing.T) {
-		mockClient := new(astro_mocks.Client)
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
-		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{DeploymentID: deploymentID, SchedulerLogs: []astro.SchedulerLog{{Raw: "test log line"}}}, nil).Once()
-
-		err := Logs(deploymentID, ws, "", true, true, true, logCount, mockClient)
-		assert.NoError(t, err)
-
-		mockClient.AssertExpectations(t)
-	})
-
-	t.Run("success without deployment", func(t *testing.T) {
-		mockClient := new(astro_mocks.Client)
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}, {ID: "test-id-1"}}, nil).Once()
-		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{DeploymentID: deploymentID, SchedulerLogs: []astro.SchedulerLog{}}, nil).Once()
-
-		// mock os.Stdin
-		input := []byte("1")
-		r, w, err := os.Pipe()
-		if err != nil {
-			t.Fatal(err)
-		}
-		_, err = w.Write(input)
-		if err != nil {
-			t.Error(err)
-		}
-		w.Close()
-		stdin := os.Stdin
-		// Restore stdin right after the test.
-		defer func() { os.Stdin = stdin }()
-		os.Stdin = r
-
-		err = Logs("", ws, "", false, false, false, logCount, mockClient)
-		assert.NoError(t, err)
-
-		mockClient.AssertExpectations(t)
-	})
-
-	t.Run("failure", func(t *testing.T) {
-		mockClient := new(astro_mocks.Client)
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
-		mockClient.On("GetDeploymentHistory", mockInput).Return(astro.DeploymentHistory{}, errMock).Once()
-
-		err := Logs(deploymentID, ws, "", true, true, true, logCount, mockClient)
-		assert.ErrorIs(t, err, errMock)
-		mockClient.AssertExpectations(t)
-	})
-}
-
 func TestCreate(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.CloudPlatform)
 
@@ -419,7 +359,7 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
 	})
@@ -467,7 +407,7 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err := Create("", ws, "test-desc", "", "4.2.5", dagDeploy, "KubernetesExecutor", "gcp", region, "small", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", "", "4.2.5", dagDeploy, "KubernetesExecutor", "gcp", region, "small", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
 		mockCoreClient.AssertExpectations(t)
@@ -502,7 +442,7 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, "KubeExecutor", "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, "KubeExecutor", "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
 		mockCoreClient.AssertExpectations(t)
@@ -535,21 +475,21 @@ func TestCreate(t *testing.T) {
 		defer testUtil.MockUserInput(t, "test-name")()
 
 		// setup wait for test
-		sleepTime = 1
-		tickNum = 2
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id", Status: "UNHEALTHY"}}, nil).Once()
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id", Status: "HEALTHY"}}, nil).Once()
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, true, false)
+		defer func(interval time.Duration) { healthPollInterval = interval }(healthPollInterval)
+		healthPollInterval = time.Millisecond
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(astro.Deployment{ID: "test-id", Status: "CREATING"}, nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(astro.Deployment{ID: "test-id", Status: "HEALTHY"}, nil).Once()
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, time.Second, false)
 		assert.NoError(t, err)
 
-		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{}}, nil).Once()
-
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		// timeout
-		timeoutNum = 1
-		err = Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, true, false)
-		assert.ErrorIs(t, err, errTimedOut)
+		// unhealthy
+		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeployment", "test-id").Return(astro.Deployment{ID: "test-id", Status: "UNHEALTHY"}, nil).Once()
+		err = Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, time.Second, false)
+		assert.ErrorIs(t, err, errUnhealthy)
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("returns an error when creating a deployment fails", func(t *testing.T) {
@@ -580,14 +520,14 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.ErrorIs(t, err, errMock)
 		mockClient.AssertExpectations(t)
 	})
 	t.Run("failed to validate resources", func(t *testing.T) {
 		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{}, errMock).Once()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.ErrorIs(t, err, errMock)
 		mockClient.AssertExpectations(t)
 	})
@@ -614,7 +554,7 @@ func TestCreate(t *testing.T) {
 		}, nil).Times(2)
 		mockClient.On("ListWorkspaces", "test-org-id").Return([]astro.Workspace{{ID: ws, OrganizationID: "test-org-id"}}, nil).Once()
 		mockClient.On("ListClusters", "test-org-id").Return([]astro.Cluster{}, errMock).Once()
-		err := Create("test-name", ws, "test-desc", "invalid-cluster-id", "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("test-name", ws, "test-desc", "invalid-cluster-id", "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.ErrorIs(t, err, errMock)
 		mockClient.AssertExpectations(t)
 	})
@@ -639,7 +579,7 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		}, nil).Times(1)
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 5, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 5, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 	})
 	t.Run("list workspace failure", func(t *testing.T) {
@@ -665,7 +605,7 @@ func TestCreate(t *testing.T) {
 		}, nil).Times(2)
 		mockClient.On("ListWorkspaces", "test-org-id").Return([]astro.Workspace{}, errMock).Once()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.ErrorIs(t, err, errMock)
 		mockClient.AssertExpectations(t)
 	})
@@ -692,7 +632,7 @@ func TestCreate(t *testing.T) {
 		}, nil).Times(2)
 		mockClient.On("ListWorkspaces", "test-org-id").Return([]astro.Workspace{{ID: ws, OrganizationID: "test-org-id"}}, nil).Once()
 
-		err := Create("", "test-invalid-id", "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err := Create("", "test-invalid-id", "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no workspaces with id")
 		mockClient.AssertExpectations(t)
@@ -731,7 +671,7 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err = Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, false, false)
+		err = Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 10, 3, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
 	})
@@ -778,7 +718,7 @@ func TestCreate(t *testing.T) {
 
 		defer testUtil.MockUserInput(t, "test-name")()
 
-		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 0, 0, mockClient, mockCoreClient, false, false)
+		err := Create("", ws, "test-desc", csID, "4.2.5", dagDeploy, CeleryExecutor, "", "", "", 0, 0, mockClient, mockCoreClient, 0, false)
 		assert.NoError(t, err)
 		mockClient.AssertExpectations(t)
 	})
@@ -1,7 +1,6 @@
 package deployment
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +8,7 @@ import (
 	"strings"
 
 	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/pkg/dotenv"
 	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/pkg/errors"
 )
@@ -18,6 +18,9 @@ func VariableList(deploymentID, variableKey, ws, envFile, deploymentName string,
 		Padding:        []int{5, 30, 30, 50},
 		DynamicPadding: true,
 		Header:         []string{"#", "KEY", "VALUE", "SECRET"},
+		Fields:         []string{"", "key", "value", "is_secret"},
+		FieldTypes:     map[string]printutil.FieldType{"is_secret": printutil.BoolField},
+		NoResultsMsg:   "\nNo variables found",
 	}
 
 	// get deployment
@@ -48,24 +51,12 @@ func VariableList(deploymentID, variableKey, ws, envFile, deploymentName string,
 		}
 	}
 
-	if nbEnvVarFound == 0 {
-		fmt.Fprintln(out, "\nNo variables found")
-		return nil
-	}
-	varTab.Print(out)
-
-	return nil
+	return varTab.Print(out)
 }
 
 // this function modifies a deployment's environment variable object
 // it is used to create and update deployment's environment variables
 func VariableModify(deploymentID, variableKey, variableValue, ws, envFile, deploymentName string, variableList []string, useEnvFile, makeSecret, updateVars bool, client astro.Client, out io.Writer) error {
-	varTab := printutil.Table{
-		Padding:        []int{5, 30, 30, 50},
-		DynamicPadding: true,
-		Header:         []string{"#", "KEY", "VALUE", "SECRET"},
-	}
-
 	// get deployment
 	currentDeployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
 	if err != nil {
@@ -119,6 +110,17 @@ func VariableModify(deploymentID, variableKey, variableValue, ws, envFile, deplo
 	if err != nil {
 		return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
 	}
+	printUpdatedVariables(environmentVariablesObjects, out)
+	return nil
+}
+
+// printUpdatedVariables prints a table of a deployment's variables after they were modified
+func printUpdatedVariables(environmentVariablesObjects []astro.EnvironmentVariablesObject, out io.Writer) {
+	varTab := printutil.Table{
+		Padding:        []int{5, 30, 30, 50},
+		DynamicPadding: true,
+		Header:         []string{"#", "KEY", "VALUE", "SECRET"},
+	}
 
 	// make variables table
 	var index int
@@ -129,12 +131,10 @@ func VariableModify(deploymentID, variableKey, variableValue, ws, envFile, deplo
 
 	if index == 0 {
 		fmt.Fprintln(out, "\nNo variables for this Deployment")
-		return nil
+		return
 	}
 	fmt.Fprintln(out, "\nUpdated list of your Deployment's variables:")
 	varTab.Print(out)
-
-	return nil
 }
 
 func contains(elems []string, v string) (exist bool, num int) {
@@ -146,22 +146,6 @@ func contains(elems []string, v string) (exist bool, num int) {
 	return false, 0
 }
 
-// readLines reads a whole file into memory and returns a slice of its lines.
-func readLines(path string) ([]string, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	return lines, scanner.Err()
-}
-
 // writes vars from cloud into a file
 func writeVarToFile(environmentVariablesObjects []astro.EnvironmentVariablesObject, variableKey, envFile string) error {
 	f, err := os.OpenFile(envFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gomnd
@@ -244,31 +228,20 @@ func addVariablesFromArgs(oldKeyList []string, oldEnvironmentVariables []astro.E
 	return newEnvironmentVariables
 }
 
-// Add variables from file
+// Add variables from file, lines that can not be parsed are skipped and nothing is added if the file can not be read
 func addVariablesFromFile(envFile string, oldKeyList []string, oldEnvironmentVariables []astro.EnvironmentVariablesObject, newEnvironmentVariables []astro.EnvironmentVariable, updateVars, makeSecret bool) []astro.EnvironmentVariable {
 	newKeyList := make([]string, 0)
-	vars, err := readLines(envFile)
+	vars, skipped, err := dotenv.ReadSkippingInvalid(envFile)
 	if err != nil {
 		fmt.Printf("unable to read file %s :\n", envFile)
 		fmt.Println(err)
 	}
+	for _, err := range skipped {
+		fmt.Printf("%s: %s, no variable created\n", envFile, err)
+	}
 	for i := range vars {
-		if strings.HasPrefix(vars[i], "#") {
-			continue
-		}
-		if vars[i] == "" {
-			continue
-		}
-		if len(strings.SplitN(vars[i], "=", 2)) == 1 { //nolint:gomnd
-			fmt.Printf("%s is an improperly formatted variable, no variable created\n", vars[i])
-			continue
-		}
-		key := strings.SplitN(vars[i], "=", 2)[0]   //nolint:gomnd
-		value := strings.SplitN(vars[i], "=", 2)[1] //nolint:gomnd
-		if key == "" {
-			fmt.Printf("empty key! skipping creating variable with value: %s\n", value)
-			continue
-		}
+		key := vars[i].Key
+		value := vars[i].Value
 		if value == "" {
 			fmt.Printf("empty value! skipping creating variable with key: %s\n", key)
 			continue
@@ -0,0 +1,258 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/dotenv"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/pkg/errors"
+)
+
+var (
+	errNoVariablesToDelete = errors.New("no variables to delete, pass keys or --pattern")
+	errVariableNotFound    = errors.New("variable does not exist")
+	errNoMatchingVariables = errors.New("no variables match pattern")
+)
+
+// variableChange is a variable that is different in an environment file and a deployment
+type variableChange struct {
+	key      string
+	from, to string
+	// secret values can not be read back from Astro so they are not compared, and they are never printed
+	secret bool
+}
+
+// variableDiff is how the variables of a deployment differ from an environment file
+type variableDiff struct {
+	added, changed, removed []variableChange
+}
+
+func (d *variableDiff) hasChanges(prune bool) bool {
+	return len(d.added) > 0 || len(d.changed) > 0 || (prune && len(d.removed) > 0)
+}
+
+// VariableDelete deletes the variables of a deployment whose key is in keys or matches the glob pattern.
+// It asks for confirmation unless force is true and returns an error if a key does not exist or nothing matches pattern.
+func VariableDelete(deploymentID, ws, deploymentName string, keys []string, pattern string, force bool, client astro.Client, out io.Writer) error {
+	if len(keys) == 0 && pattern == "" {
+		return errNoVariablesToDelete
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("--pattern %s: %w", pattern, err)
+	}
+
+	currentDeployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	oldEnvironmentVariables := currentDeployment.DeploymentSpec.EnvironmentVariablesObjects
+
+	existingKeys := make([]string, 0, len(oldEnvironmentVariables))
+	for i := range oldEnvironmentVariables {
+		existingKeys = append(existingKeys, oldEnvironmentVariables[i].Key)
+	}
+	for _, key := range keys {
+		if exist, _ := contains(existingKeys, key); !exist {
+			return fmt.Errorf("%w: %s", errVariableNotFound, key)
+		}
+	}
+
+	var deleted []string
+	newEnvironmentVariables := make([]astro.EnvironmentVariable, 0, len(oldEnvironmentVariables))
+	for i := range oldEnvironmentVariables {
+		key := oldEnvironmentVariables[i].Key
+		requested, _ := contains(keys, key)
+		matched, _ := path.Match(pattern, key)
+		if requested || (pattern != "" && matched) {
+			deleted = append(deleted, key)
+			continue
+		}
+		newEnvironmentVariables = append(newEnvironmentVariables, astro.EnvironmentVariable{
+			IsSecret: oldEnvironmentVariables[i].IsSecret,
+			Key:      key,
+			Value:    oldEnvironmentVariables[i].Value,
+		})
+	}
+	if len(deleted) == 0 {
+		return fmt.Errorf("%w: %s", errNoMatchingVariables, pattern)
+	}
+
+	if !force {
+		i, err := input.Confirm(
+			fmt.Sprintf("\nAre you sure you want to delete %s from the %s Deployment?", strings.Join(deleted, ", "), ansi.Bold(currentDeployment.Label)))
+		if err != nil {
+			return err
+		}
+		if !i {
+			fmt.Fprintln(out, "Canceling variable deletion")
+			return nil
+		}
+	}
+
+	environmentVariablesObjects, err := client.ModifyDeploymentVariable(astro.EnvironmentVariablesInput{
+		DeploymentID:         currentDeployment.ID,
+		EnvironmentVariables: newEnvironmentVariables,
+	})
+	if err != nil {
+		return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
+	}
+	for _, key := range deleted {
+		fmt.Fprintf(out, "deleted variable %s\n", key)
+	}
+	printUpdatedVariables(environmentVariablesObjects, out)
+	return nil
+}
+
+// VariableDiff prints the variables that would be added, changed and removed to make a deployment match envFile.
+// Values of secret variables can not be read back from Astro, they are never compared or printed. Values that
+// become secrets because makeSecret is true are not printed either.
+func VariableDiff(deploymentID, ws, deploymentName, envFile string, makeSecret bool, client astro.Client, out io.Writer) error {
+	fileVariables, err := dotenv.Read(envFile)
+	if err != nil {
+		return err
+	}
+	currentDeployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	diff := diffVariables(currentDeployment.DeploymentSpec.EnvironmentVariablesObjects, fileVariables, makeSecret)
+	printVariableDiff(&diff, currentDeployment.Label, envFile, true, out)
+	return nil
+}
+
+// VariableSync makes the variables of a deployment match envFile. Variables missing from envFile are only deleted
+// if prune is true, after confirmation unless force is true. New variables are created as secrets if makeSecret is true.
+func VariableSync(deploymentID, ws, deploymentName, envFile string, prune, makeSecret, force bool, client astro.Client, out io.Writer) error {
+	fileVariables, err := dotenv.Read(envFile)
+	if err != nil {
+		return err
+	}
+	currentDeployment, err := GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	oldEnvironmentVariables := currentDeployment.DeploymentSpec.EnvironmentVariablesObjects
+	diff := diffVariables(oldEnvironmentVariables, fileVariables, makeSecret)
+	printVariableDiff(&diff, currentDeployment.Label, envFile, prune, out)
+	if !diff.hasChanges(prune) {
+		return nil
+	}
+	if prune && len(diff.removed) > 0 && !force {
+		i, err := input.Confirm(fmt.Sprintf("\nAre you sure you want to delete %d variables from the %s Deployment?", len(diff.removed), ansi.Bold(currentDeployment.Label)))
+		if err != nil {
+			return err
+		}
+		if !i {
+			fmt.Fprintln(out, "Canceling variable sync")
+			return nil
+		}
+	}
+
+	fileValues := make(map[string]string, len(fileVariables))
+	for _, v := range fileVariables {
+		fileValues[v.Key] = v.Value
+	}
+	newEnvironmentVariables := make([]astro.EnvironmentVariable, 0, len(fileVariables))
+	for i := range oldEnvironmentVariables {
+		old := oldEnvironmentVariables[i]
+		value, inFile := fileValues[old.Key]
+		if !inFile {
+			if prune {
+				continue
+			}
+			value = old.Value
+		}
+		newEnvironmentVariables = append(newEnvironmentVariables, astro.EnvironmentVariable{
+			// you can only make variables secret a user can't make them not secret
+			IsSecret: old.IsSecret || (inFile && makeSecret),
+			Key:      old.Key,
+			Value:    value,
+		})
+	}
+	for _, added := range diff.added {
+		newEnvironmentVariables = append(newEnvironmentVariables, astro.EnvironmentVariable{IsSecret: makeSecret, Key: added.key, Value: added.to})
+	}
+
+	environmentVariablesObjects, err := client.ModifyDeploymentVariable(astro.EnvironmentVariablesInput{
+		DeploymentID:         currentDeployment.ID,
+		EnvironmentVariables: newEnvironmentVariables,
+	})
+	if err != nil {
+		return errors.Wrap(err, astro.AstronomerConnectionErrMsg)
+	}
+	printUpdatedVariables(environmentVariablesObjects, out)
+	return nil
+}
+
+// diffVariables compares the variables of a deployment to the variables of an environment file. The variables of the
+// file are secrets if makeSecret is true.
+func diffVariables(current []astro.EnvironmentVariablesObject, fileVariables []dotenv.Variable, makeSecret bool) variableDiff {
+	var diff variableDiff
+	currentIndex := make(map[string]int, len(current))
+	for i := range current {
+		currentIndex[current[i].Key] = i
+	}
+	inFile := make(map[string]bool, len(fileVariables))
+	for _, v := range fileVariables {
+		inFile[v.Key] = true
+		i, ok := currentIndex[v.Key]
+		switch {
+		case !ok:
+			diff.added = append(diff.added, variableChange{key: v.Key, to: v.Value, secret: makeSecret})
+		case current[i].IsSecret, makeSecret:
+			diff.changed = append(diff.changed, variableChange{key: v.Key, secret: true})
+		case current[i].Value != v.Value:
+			diff.changed = append(diff.changed, variableChange{key: v.Key, from: current[i].Value, to: v.Value})
+		}
+	}
+	for i := range current {
+		if !inFile[current[i].Key] {
+			diff.removed = append(diff.removed, variableChange{key: current[i].Key, from: current[i].Value, secret: current[i].IsSecret})
+		}
+	}
+	return diff
+}
+
+func printVariableDiff(diff *variableDiff, deploymentName, envFile string, prune bool, out io.Writer) {
+	if !diff.hasChanges(prune) {
+		fmt.Fprintf(out, "Variables of Deployment %s are up to date with %s\n", ansi.Bold(deploymentName), envFile)
+	} else {
+		fmt.Fprintf(out, "Variables of Deployment %s compared to %s:\n", ansi.Bold(deploymentName), envFile)
+		for _, added := range diff.added {
+			if added.secret {
+				fmt.Fprintf(out, "  %s %s: secret, the value is set from the file\n", ansi.Green("+"), added.key)
+				continue
+			}
+			fmt.Fprintf(out, "  %s %s: %s\n", ansi.Green("+"), added.key, added.to)
+		}
+		for _, changed := range diff.changed {
+			if changed.secret {
+				fmt.Fprintf(out, "  ~ %s: secret, the value is set from the file\n", changed.key)
+				continue
+			}
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", changed.key, changed.from, changed.to)
+		}
+		if prune {
+			for _, removed := range diff.removed {
+				if removed.secret {
+					fmt.Fprintf(out, "  %s %s: secret\n", ansi.Red("-"), removed.key)
+					continue
+				}
+				fmt.Fprintf(out, "  %s %s: %s\n", ansi.Red("-"), removed.key, removed.from)
+			}
+		}
+		removed := 0
+		if prune {
+			removed = len(diff.removed)
+		}
+		fmt.Fprintf(out, "%d to add, %d to change, %d to remove\n", len(diff.added), len(diff.changed), removed)
+	}
+	if !prune && len(diff.removed) > 0 {
+		fmt.Fprintf(out, "%d variables of the Deployment are not in %s, use --prune to delete them\n", len(diff.removed), envFile)
+	}
+}
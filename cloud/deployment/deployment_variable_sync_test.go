@@ -0,0 +1,264 @@
+package deployment
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	astro "github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/pkg/dotenv"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var syncTestDeployments = []astro.Deployment{
+	{
+		ID:    "test-id-1",
+		Label: "test-deployment",
+		DeploymentSpec: astro.DeploymentSpec{
+			EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+				{Key: "FOO", Value: "foo"},
+				{Key: "AIRFLOW__CORE__A", Value: "a"},
+				{Key: "AIRFLOW__CORE__B", Value: "b"},
+				{Key: "TOKEN", IsSecret: true},
+			},
+		},
+	},
+}
+
+func variableKeys(vars []astro.EnvironmentVariable) []string {
+	keys := make([]string, 0, len(vars))
+	for _, v := range vars {
+		keys = append(keys, v.Key)
+	}
+	return keys
+}
+
+func writeEnvFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	err := os.WriteFile(path, []byte(data), 0o644)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestVariableDelete(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("by key", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			return assert.ObjectsAreEqual([]string{"AIRFLOW__CORE__A", "AIRFLOW__CORE__B", "TOKEN"}, variableKeys(input.EnvironmentVariables))
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDelete("test-id-1", ws, "", []string{"FOO"}, "", true, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "deleted variable FOO")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("by pattern after confirmation", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "y")()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			return assert.ObjectsAreEqual([]string{"FOO", "TOKEN"}, variableKeys(input.EnvironmentVariables))
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDelete("test-id-1", ws, "", nil, "AIRFLOW__*", false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "deleted variable AIRFLOW__CORE__A")
+		assert.Contains(t, buf.String(), "deleted variable AIRFLOW__CORE__B")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "n")()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDelete("test-id-1", ws, "", []string{"FOO"}, "", false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Canceling variable deletion")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		err := VariableDelete("test-id-1", ws, "", []string{"BAR"}, "", true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errVariableNotFound)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("pattern matches nothing", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		err := VariableDelete("test-id-1", ws, "", nil, "BAR*", true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoMatchingVariables)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		err := VariableDelete("test-id-1", ws, "", nil, "[", true, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		err := VariableDelete("test-id-1", ws, "", nil, "", true, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoVariablesToDelete)
+	})
+}
+
+func TestVariableDiff(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("changes", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=bar\nAIRFLOW__CORE__A=a\nTOKEN=secret\nNEW=new\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDiff("test-id-1", ws, "", envFile, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "NEW: new")
+		assert.Contains(t, buf.String(), "~ FOO: foo -> bar")
+		assert.Contains(t, buf.String(), "~ TOKEN: secret, the value is set from the file")
+		assert.NotContains(t, buf.String(), "AIRFLOW__CORE__A")
+		assert.Contains(t, buf.String(), "AIRFLOW__CORE__B: b")
+		assert.Contains(t, buf.String(), "1 to add, 2 to change, 1 to remove")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("values that become secrets are not printed", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=new-secret-value\nNEW=added-secret-value\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDiff("test-id-1", ws, "", envFile, true, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "NEW: secret, the value is set from the file")
+		assert.Contains(t, buf.String(), "~ FOO: secret, the value is set from the file")
+		assert.Contains(t, buf.String(), "TOKEN: secret\n")
+		assert.NotContains(t, buf.String(), "new-secret-value")
+		assert.NotContains(t, buf.String(), "added-secret-value")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=foo\nAIRFLOW__CORE__A=a\nAIRFLOW__CORE__B=b\n")
+		deployments := []astro.Deployment{syncTestDeployments[0]}
+		deployments[0].DeploymentSpec.EnvironmentVariablesObjects = deployments[0].DeploymentSpec.EnvironmentVariablesObjects[:3]
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(deployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableDiff("test-id-1", ws, "", envFile, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "are up to date with")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := VariableDiff("test-id-1", ws, "", filepath.Join(t.TempDir(), ".env"), false, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.Error(t, err)
+	})
+}
+
+func TestVariableSync(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("without prune", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=bar\nNEW=new\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			vars := input.EnvironmentVariables
+			return assert.ObjectsAreEqual([]string{"FOO", "AIRFLOW__CORE__A", "AIRFLOW__CORE__B", "TOKEN", "NEW"}, variableKeys(vars)) &&
+				vars[0].Value == "bar" && vars[4].Value == "new" && vars[3].IsSecret
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableSync("test-id-1", ws, "", envFile, false, false, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "use --prune to delete them")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=foo\nNEW=new\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			vars := input.EnvironmentVariables
+			return assert.ObjectsAreEqual([]string{"FOO", "NEW"}, variableKeys(vars)) && vars[1].IsSecret
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableSync("test-id-1", ws, "", envFile, true, true, true, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "1 to add, 1 to change, 3 to remove")
+		assert.NotContains(t, buf.String(), "NEW: new")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("prune canceled", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "n")()
+		envFile := writeEnvFile(t, "FOO=foo\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableSync("test-id-1", ws, "", envFile, true, false, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "Canceling variable sync")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("nothing to sync", func(t *testing.T) {
+		envFile := writeEnvFile(t, "FOO=foo\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(syncTestDeployments, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableSync("test-id-1", ws, "", envFile, false, false, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "are up to date with")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDiffVariables(t *testing.T) {
+	current := []astro.EnvironmentVariablesObject{
+		{Key: "SAME", Value: "same"},
+		{Key: "CHANGED", Value: "old"},
+		{Key: "SECRET", IsSecret: true},
+		{Key: "REMOVED_SECRET", IsSecret: true},
+	}
+	fileVariables := []dotenv.Variable{
+		{Key: "SAME", Value: "same"},
+		{Key: "CHANGED", Value: "new"},
+		{Key: "SECRET", Value: "value"},
+		{Key: "ADDED", Value: "added"},
+	}
+	diff := diffVariables(current, fileVariables, false)
+	assert.Equal(t, []variableChange{{key: "ADDED", to: "added"}}, diff.added)
+	assert.Equal(t, []variableChange{{key: "CHANGED", from: "old", to: "new"}, {key: "SECRET", secret: true}}, diff.changed)
+	assert.Equal(t, []variableChange{{key: "REMOVED_SECRET", secret: true}}, diff.removed)
+	assert.True(t, diff.hasChanges(false))
+
+	// every variable of the file becomes a secret
+	diff = diffVariables(current, fileVariables, true)
+	assert.Equal(t, []variableChange{{key: "ADDED", to: "added", secret: true}}, diff.added)
+	assert.Equal(t, []variableChange{{key: "SAME", secret: true}, {key: "CHANGED", secret: true}, {key: "SECRET", secret: true}}, diff.changed)
+}
@@ -143,7 +143,7 @@ func TestVariableModify(t *testing.T) {
 		mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockCreateResponse, nil).Once()
 
 		buf := new(bytes.Buffer)
-		err := VariableModify("test-id-1", "test-key-2", "test-value-2", ws, "", "", []string{}, true, false, false, mockClient, buf)
+		err := VariableModify("test-id-1", "test-key-2", "test-value-2", ws, "./testfiles/test-env-file", "", []string{}, true, false, false, mockClient, buf)
 		assert.NoError(t, err)
 		assert.Contains(t, buf.String(), "test-key-1")
 		assert.Contains(t, buf.String(), "test-key-2")
@@ -152,6 +152,18 @@ func TestVariableModify(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 
+	t.Run("env file that can not be read is skipped", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", org, ws).Return(mockListResponse, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockCreateResponse, nil).Once()
+
+		buf := new(bytes.Buffer)
+		err := VariableModify("test-id-1", "test-key-2", "test-value-2", ws, "./testfiles/missing-env-file", "", []string{}, true, false, false, mockClient, buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "test-key-2")
+		mockClient.AssertExpectations(t)
+	})
+
 	t.Run("list deployment failure", func(t *testing.T) {
 		mockClient := new(astro_mocks.Client)
 		mockClient.On("ListDeployments", org, ws).Return([]astro.Deployment{}, errMock).Once()
@@ -237,12 +249,6 @@ func TestContains(t *testing.T) {
 	assert.Equal(t, 0, idx)
 }
 
-func TestReadLines(t *testing.T) {
-	resp, err := readLines("./testfiles/test-env-file")
-	assert.Contains(t, resp, "test-key-1=test-value-1")
-	assert.NoError(t, err)
-}
-
 func TestAddVariableFromFile(t *testing.T) {
 	resp := addVariablesFromFile(
 		"./testfiles/test-env-file", []string{"test-key-2"},
@@ -271,6 +277,12 @@ func TestAddVariableFromFile(t *testing.T) {
 		[]astro.EnvironmentVariable{{Key: "test-key-1", Value: "test-value-3"}}, false, false)
 
 	assert.Equal(t, []astro.EnvironmentVariable{{Key: "test-key-1", Value: "test-value-3"}}, resp)
+
+	resp = addVariablesFromFile(
+		"./testfiles/missing-env-file", []string{}, []astro.EnvironmentVariablesObject{},
+		[]astro.EnvironmentVariable{{Key: "test-key-1", Value: "test-value-3"}}, false, false)
+
+	assert.Equal(t, []astro.EnvironmentVariable{{Key: "test-key-1", Value: "test-value-3"}}, resp)
 }
 
 func TestWriteVarToFile(t *testing.T) {
@@ -0,0 +1,520 @@
+package fromfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+)
+
+var (
+	// ErrDriftDetected is returned by Apply when only a plan was requested and a live deployment does not match its
+	// deployment file
+	ErrDriftDetected = errors.New("the deployment does not match the deployment file")
+
+	errApplyFailed           = errors.New("failed to apply")
+	errDependencyFailed      = errors.New("dependency failed to apply")
+	errPruneWithoutWorkspace = errors.New("a workspace is required to prune deployments")
+)
+
+const (
+	addChange    = "add"
+	removeChange = "remove"
+	updateChange = "update"
+
+	secretMask = "****"
+
+	createdStatus   = "created"
+	updatedStatus   = "updated"
+	unchangedStatus = "unchanged"
+	driftStatus     = "drift"
+	failedStatus    = "failed"
+	skippedStatus   = "skipped"
+	prunedStatus    = "deleted"
+	keptStatus      = "kept"
+)
+
+// Change is a field that differs between a deployment file and the live deployment
+type Change struct {
+	Action string
+	Field  string
+	From   string
+	To     string
+}
+
+// Plan holds the changes apply makes to bring a deployment in line with its deployment file
+type Plan struct {
+	Deployment string
+	Create     bool
+	Changes    []Change
+}
+
+// HasChanges returns true if applying the deployment file changes the deployment
+func (p *Plan) HasChanges() bool {
+	return p.Create || len(p.Changes) > 0
+}
+
+func (p *Plan) add(field, value string) {
+	p.Changes = append(p.Changes, Change{Action: addChange, Field: field, To: value})
+}
+
+func (p *Plan) remove(field, value string) {
+	p.Changes = append(p.Changes, Change{Action: removeChange, Field: field, From: value})
+}
+
+func (p *Plan) update(field, from, to string) {
+	if from != to {
+		p.Changes = append(p.Changes, Change{Action: updateChange, Field: field, From: from, To: to})
+	}
+}
+
+func (p *Plan) count(action string) int {
+	n := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// ApplyOptions configures how Apply applies deployment files
+type ApplyOptions struct {
+	// PlanOnly prints the changes without applying them
+	PlanOnly bool
+	// Prune deletes the deployments of WorkspaceID that no deployment file declares
+	Prune       bool
+	WorkspaceID string
+	// Concurrency is the number of deployments applied at the same time
+	Concurrency int
+	// Vars are the values deployment files are rendered with, files are only rendered if they are templates if nil
+	Vars map[string]interface{}
+}
+
+// applyResult is the outcome of applying a deployment, printed in the summary table
+type applyResult struct {
+	name   string
+	source string
+	status string
+	err    error
+}
+
+// Apply takes a deployment file, a multi-document deployment file or a directory of deployment files and creates
+// the deployments that do not exist or updates the ones that do. Deployments are applied after the deployments
+// they depend on. The changes of every deployment are printed before they are made.
+// It returns ErrDriftDetected if only a plan was requested and a deployment does not match its file.
+// It returns errApplyFailed if any deployment failed to apply.
+func Apply(inputPath string, options ApplyOptions, client astro.Client, out io.Writer) error {
+	var (
+		err                 error
+		documents           []deploymentDocument
+		existingDeployments []astro.Deployment
+		levels              [][]int
+	)
+
+	documents, err = readDeploymentDocuments(inputPath, options.Vars)
+	if err != nil {
+		return err
+	}
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	existingDeployments, err = client.ListDeployments(c.Organization, "")
+	if err != nil {
+		return err
+	}
+	levels, err = dependencyLevels(documents, existingDeployments)
+	if err != nil {
+		return err
+	}
+	if options.Concurrency < 1 {
+		options.Concurrency = 1
+	}
+
+	results := make([]applyResult, len(documents))
+	failed := map[string]bool{}
+	var mu sync.Mutex
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, options.Concurrency)
+		for _, i := range level {
+			document := &documents[i]
+			results[i] = applyResult{name: document.name(), source: document.source}
+			if dependency := failedDependency(document, failed); dependency != "" {
+				results[i].status = skippedStatus
+				results[i].err = fmt.Errorf("%w: %s", errDependencyFailed, dependency)
+				continue
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				// buffer the output of each deployment so concurrent deployments are not interleaved
+				buf := new(bytes.Buffer)
+				status, err := applyDocument(&documents[i], options.PlanOnly, c.Organization, existingDeployments, client, buf)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					fmt.Fprintf(buf, "%s %s: %s\n", ansi.Red("Error"), documents[i].source, err.Error())
+				}
+				out.Write(buf.Bytes()) //nolint:errcheck
+				results[i].status = status
+				results[i].err = err
+			}(i)
+		}
+		wg.Wait()
+		for _, i := range level {
+			if results[i].err != nil {
+				failed[results[i].name] = true
+			}
+		}
+	}
+
+	// deployments are not pruned if a deployment failed to apply, the apply error is returned instead
+	if options.Prune && len(failed) > 0 {
+		fmt.Fprintln(out, "Deployments that are not declared are not pruned as some deployments failed to apply")
+	} else if options.Prune {
+		pruned, err := prune(documents, existingDeployments, options, client, out)
+		if err != nil {
+			return err
+		}
+		results = append(results, pruned...)
+	}
+	return printResults(results, options.PlanOnly, out)
+}
+
+// applyDocument creates the deployment of document if it does not exist or updates it if it does.
+// It prints the changes it makes before making them and stops there if planOnly is set.
+// It returns the status of the deployment for the summary table.
+func applyDocument(document *deploymentDocument, planOnly bool, organizationID string, existingDeployments []astro.Deployment, client astro.Client, out io.Writer) (string, error) {
+	var (
+		err                error
+		action, clusterID  string
+		liveDeployment     inspect.FormattedDeployment
+		existingDeployment astro.Deployment
+		nodePools          []astro.NodePool
+		plan               Plan
+	)
+
+	// create the deployment if it does not exist yet
+	action = createAction
+	if deploymentExists(existingDeployments, document.name()) {
+		action = updateAction
+		existingDeployment = deploymentFromName(existingDeployments, document.name())
+	}
+	// validate required fields
+	err = checkRequiredFields(&document.deployment, action)
+	if err != nil {
+		return failedStatus, err
+	}
+
+	plan = Plan{Deployment: document.name(), Create: action == createAction}
+	if action == updateAction {
+		liveDeployment, err = inspect.FormatDeployment(&existingDeployment)
+		if err != nil {
+			return failedStatus, err
+		}
+		plan = getPlan(&document.deployment, &liveDeployment)
+	}
+	printPlan(&plan, out)
+	if !plan.HasChanges() {
+		return unchangedStatus, nil
+	}
+	if planOnly {
+		return driftStatus, nil
+	}
+
+	// map cluster name to id and collect node pools for cluster
+	clusterID, nodePools, err = getClusterInfoFromName(document.deployment.Deployment.Configuration.ClusterName, organizationID, client)
+	if err != nil {
+		return failedStatus, err
+	}
+	err = createOrUpdate(&document.deployment, document.source, action, clusterID, organizationID, nodePools, existingDeployments, document.jsonOutput, client, out)
+	if err != nil {
+		return failedStatus, err
+	}
+	if action == createAction {
+		return createdStatus, nil
+	}
+	return updatedStatus, nil
+}
+
+// failedDependency returns the first dependency of document that failed to apply
+func failedDependency(document *deploymentDocument, failed map[string]bool) string {
+	for _, dependency := range document.deployment.Deployment.DependsOn {
+		if failed[dependency] {
+			return dependency
+		}
+	}
+	return ""
+}
+
+// prune deletes the deployments in the workspace that are not declared in documents after asking for confirmation.
+// Nothing is deleted if only a plan was requested.
+func prune(documents []deploymentDocument, existingDeployments []astro.Deployment, options ApplyOptions, client astro.Client, out io.Writer) ([]applyResult, error) {
+	if options.WorkspaceID == "" {
+		return nil, errPruneWithoutWorkspace
+	}
+	declared := map[string]bool{}
+	for i := range documents {
+		declared[documents[i].name()] = true
+	}
+	var undeclared []astro.Deployment
+	for i := range existingDeployments {
+		if existingDeployments[i].Workspace.ID == options.WorkspaceID && !declared[existingDeployments[i].Label] {
+			undeclared = append(undeclared, existingDeployments[i])
+		}
+	}
+	if len(undeclared) == 0 {
+		return nil, nil
+	}
+
+	results := make([]applyResult, len(undeclared))
+	names := make([]string, len(undeclared))
+	for i := range undeclared {
+		names[i] = undeclared[i].Label
+		results[i] = applyResult{name: undeclared[i].Label, status: prunedStatus}
+		fmt.Fprintf(out, "  %s deployment %s is not declared in any deployment file\n", ansi.Red("-"), undeclared[i].Label)
+	}
+	if options.PlanOnly {
+		for i := range results {
+			results[i].status = driftStatus
+		}
+		return results, nil
+	}
+
+	i, err := input.Confirm(fmt.Sprintf("Are you sure you want to delete %d deployments that are not declared in any deployment file: %s?", len(undeclared), strings.Join(names, ", ")))
+	if err != nil {
+		return nil, err
+	}
+	if !i {
+		fmt.Fprintln(out, "Skipping prune")
+		for i := range results {
+			results[i].status = keptStatus
+		}
+		return results, nil
+	}
+	for i := range undeclared {
+		_, err := client.DeleteDeployment(astro.DeleteDeploymentInput{ID: undeclared[i].ID})
+		if err != nil {
+			results[i].status = failedStatus
+			results[i].err = err
+		}
+	}
+	return results, nil
+}
+
+// printResults prints the summary table of an apply.
+// It returns errApplyFailed if a deployment failed and ErrDriftDetected if only a plan was requested and a
+// deployment does not match its file.
+func printResults(results []applyResult, planOnly bool, out io.Writer) error {
+	tab := printutil.Table{
+		Padding:        []int{30, 40, 10, 50},
+		DynamicPadding: true,
+		Header:         []string{"DEPLOYMENT", "FILE", "STATUS", "ERROR"},
+	}
+	var failures, drifts int
+	for _, result := range results {
+		errMsg := ""
+		if result.err != nil {
+			errMsg = result.err.Error()
+		}
+		switch result.status {
+		case failedStatus:
+			failures++
+		case driftStatus:
+			drifts++
+		}
+		tab.AddRow([]string{result.name, result.source, result.status, errMsg}, false)
+	}
+	fmt.Fprintln(out)
+	if err := tab.Print(out); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d deployments %w", failures, len(results), errApplyFailed)
+	}
+	if planOnly && drifts > 0 {
+		return ErrDriftDetected
+	}
+	return nil
+}
+
+// getPlan compares the deployment in a deployment file with the live deployment.
+// Fields apply leaves untouched are not compared: the runtime version changes with deploys and the workspace
+// and cluster of a deployment can not be changed. The configuration is updated as it is in the file, so
+// description, dag_deploy_enabled and scheduler_au are reset when they are left out and the plan shows it.
+// Worker queue options, environment variables and alert emails left out of the file keep their live values.
+func getPlan(deploymentFromFile, liveDeployment *inspect.FormattedDeployment) Plan {
+	plan := Plan{Deployment: deploymentFromFile.Deployment.Configuration.Name}
+
+	fileConfig := deploymentFromFile.Deployment.Configuration
+	liveConfig := liveDeployment.Deployment.Configuration
+	plan.update("deployment.configuration.description", liveConfig.Description, fileConfig.Description)
+	plan.update("deployment.configuration.dag_deploy_enabled", strconv.FormatBool(liveConfig.DagDeployEnabled), strconv.FormatBool(fileConfig.DagDeployEnabled))
+	plan.update("deployment.configuration.executor", liveConfig.Executor, fileConfig.Executor)
+	plan.update("deployment.configuration.scheduler_au", strconv.Itoa(liveConfig.SchedulerAU), strconv.Itoa(fileConfig.SchedulerAU))
+	plan.update("deployment.configuration.scheduler_count", strconv.Itoa(liveConfig.SchedulerCount), strconv.Itoa(fileConfig.SchedulerCount))
+
+	if hasQueues(deploymentFromFile) {
+		planQueues(&plan, deploymentFromFile.Deployment.WorkerQs, liveDeployment.Deployment.WorkerQs)
+	}
+	if hasEnvVars(deploymentFromFile) {
+		planEnvVars(&plan, deploymentFromFile.Deployment.EnvVars, liveDeployment.Deployment.EnvVars)
+	}
+	if hasAlertEmails(deploymentFromFile) {
+		planAlertEmails(&plan, deploymentFromFile.Deployment.AlertEmails, liveDeployment.Deployment.AlertEmails)
+	}
+	return plan
+}
+
+// planQueues adds the worker queue changes to plan. Queue options left out of the file get default values when
+// a queue is created and are not compared for existing queues.
+func planQueues(plan *Plan, fileQueues, liveQueues []inspect.Workerq) {
+	live := map[string]inspect.Workerq{}
+	for _, queue := range liveQueues {
+		live[queue.Name] = queue
+	}
+	requested := map[string]bool{}
+	for _, queue := range fileQueues {
+		requested[queue.Name] = true
+		field := fmt.Sprintf("deployment.worker_queues[%s]", queue.Name)
+		existing, ok := live[queue.Name]
+		if !ok {
+			plan.add(field, queue.WorkerType)
+			continue
+		}
+		plan.update(field+".worker_type", existing.WorkerType, queue.WorkerType)
+		if queue.MinWorkerCount != nil {
+			plan.update(field+".min_worker_count", intPointerToString(existing.MinWorkerCount), strconv.Itoa(*queue.MinWorkerCount))
+		}
+		if queue.MaxWorkerCount != 0 {
+			plan.update(field+".max_worker_count", strconv.Itoa(existing.MaxWorkerCount), strconv.Itoa(queue.MaxWorkerCount))
+		}
+		if queue.WorkerConcurrency != 0 {
+			plan.update(field+".worker_concurrency", strconv.Itoa(existing.WorkerConcurrency), strconv.Itoa(queue.WorkerConcurrency))
+		}
+		if queue.PodCPU != "" {
+			plan.update(field+".pod_cpu", existing.PodCPU, queue.PodCPU)
+		}
+		if queue.PodRAM != "" {
+			plan.update(field+".pod_ram", existing.PodRAM, queue.PodRAM)
+		}
+	}
+	for _, queue := range liveQueues {
+		if !requested[queue.Name] {
+			plan.remove(fmt.Sprintf("deployment.worker_queues[%s]", queue.Name), queue.WorkerType)
+		}
+	}
+}
+
+// planEnvVars adds the environment variable changes to plan. The API never returns the values of secrets so
+// their values are not compared and never printed.
+func planEnvVars(plan *Plan, fileVars, liveVars []inspect.EnvironmentVariable) {
+	live := map[string]inspect.EnvironmentVariable{}
+	for _, envVar := range liveVars {
+		live[envVar.Key] = envVar
+	}
+	requested := map[string]bool{}
+	for _, envVar := range fileVars {
+		requested[envVar.Key] = true
+		field := fmt.Sprintf("deployment.environment_variables[%s]", envVar.Key)
+		existing, ok := live[envVar.Key]
+		if !ok {
+			plan.add(field, envVarValue(envVar))
+			continue
+		}
+		plan.update(field+".is_secret", strconv.FormatBool(existing.IsSecret), strconv.FormatBool(envVar.IsSecret))
+		if !envVar.IsSecret && !existing.IsSecret {
+			plan.update(field+".value", existing.Value, envVar.Value)
+		}
+	}
+	for _, envVar := range liveVars {
+		if !requested[envVar.Key] {
+			plan.remove(fmt.Sprintf("deployment.environment_variables[%s]", envVar.Key), envVarValue(envVar))
+		}
+	}
+}
+
+func planAlertEmails(plan *Plan, fileEmails, liveEmails []string) {
+	live := map[string]bool{}
+	for _, email := range liveEmails {
+		live[email] = true
+	}
+	requested := map[string]bool{}
+	for _, email := range fileEmails {
+		requested[email] = true
+		if !live[email] {
+			plan.add("deployment.alert_emails", email)
+		}
+	}
+	for _, email := range liveEmails {
+		if !requested[email] {
+			plan.remove("deployment.alert_emails", email)
+		}
+	}
+}
+
+// printPlan prints the changes in plan, additions, removals and updates are sorted by field
+func printPlan(plan *Plan, out io.Writer) {
+	if plan.Create {
+		fmt.Fprintf(out, "Deployment %s does not exist and will be created\n", ansi.Bold(plan.Deployment))
+		return
+	}
+	if !plan.HasChanges() {
+		fmt.Fprintf(out, "Deployment %s is up to date with the deployment file\n", ansi.Bold(plan.Deployment))
+		return
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].Field < plan.Changes[j].Field
+	})
+	fmt.Fprintf(out, "Deployment %s will be updated:\n", ansi.Bold(plan.Deployment))
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case addChange:
+			fmt.Fprintf(out, "  %s %s: %s\n", ansi.Green("+"), change.Field, planValue(change.To))
+		case removeChange:
+			fmt.Fprintf(out, "  %s %s: %s\n", ansi.Red("-"), change.Field, planValue(change.From))
+		default:
+			fmt.Fprintf(out, "  ~ %s: %s -> %s\n", change.Field, planValue(change.From), planValue(change.To))
+		}
+	}
+	fmt.Fprintf(out, "%d to add, %d to change, %d to remove\n", plan.count(addChange), plan.count(updateChange), plan.count(removeChange))
+}
+
+// planValue quotes empty values so a field that is reset is visible in the plan
+func planValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	return value
+}
+
+func envVarValue(envVar inspect.EnvironmentVariable) string {
+	if envVar.IsSecret {
+		return secretMask
+	}
+	return envVar.Value
+}
+
+func intPointerToString(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
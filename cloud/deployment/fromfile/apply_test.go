@@ -0,0 +1,335 @@
+package fromfile
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/pkg/fileutil"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const applyTestFile = `
+deployment:
+  environment_variables:
+    - is_secret: false
+      key: foo
+      value: bar
+    - is_secret: true
+      key: token
+      value: secret-value
+  configuration:
+    name: test-deployment-label
+    description: description 1
+    runtime_version: 6.0.0
+    dag_deploy_enabled: true
+    executor: CeleryExecutor
+    scheduler_au: 5
+    scheduler_count: 3
+    cluster_name: test-cluster
+    workspace_name: test-workspace
+  worker_queues:
+    - name: default
+      max_worker_count: 130
+      min_worker_count: 12
+      worker_concurrency: 180
+      worker_type: test-worker-1
+  alert_emails:
+    - test1@test.com
+`
+
+func TestApply(t *testing.T) {
+	var (
+		orgID     = "test-org-id"
+		filePath  = "./deployment.yaml"
+		nodePools = []astro.NodePool{
+			{ID: "test-pool-id", NodeInstanceType: "test-worker-1"},
+			{ID: "test-pool-id-2", NodeInstanceType: "test-worker-2"},
+		}
+		cluster = astro.Cluster{ID: "test-cluster-id", Name: "test-cluster", NodePools: nodePools}
+	)
+	// matches applyTestFile
+	liveDeployment := func() astro.Deployment {
+		return astro.Deployment{
+			ID:               "test-deployment-id",
+			Label:            "test-deployment-label",
+			Description:      "description 1",
+			Workspace:        astro.Workspace{ID: "test-workspace-id", Label: "test-workspace"},
+			Cluster:          cluster,
+			DagDeployEnabled: true,
+			RuntimeRelease:   astro.RuntimeRelease{Version: "6.0.0"},
+			DeploymentSpec: astro.DeploymentSpec{
+				Executor:  "CeleryExecutor",
+				Scheduler: astro.Scheduler{AU: 5, Replicas: 3},
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+					{Key: "foo", Value: "bar"},
+					{Key: "token", IsSecret: true},
+				},
+			},
+			WorkerQueues: []astro.WorkerQueue{
+				{ID: "test-wq-id", Name: "default", IsDefault: true, MaxWorkerCount: 130, MinWorkerCount: 12, WorkerConcurrency: 180, NodePoolID: "test-pool-id"},
+			},
+			AlertEmails: []string{"test1@test.com"},
+		}
+	}
+	fileutil.WriteStringToFile(filePath, applyTestFile)
+	defer afero.NewOsFs().Remove(filePath)
+
+	t.Run("does nothing if the deployment matches the file", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{liveDeployment()}, nil).Once()
+		err := Apply(filePath, ApplyOptions{PlanOnly: true}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "is up to date with the deployment file")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns ErrDriftDetected and prints the plan if the deployment drifted", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		drifted := liveDeployment()
+		drifted.Description = "changed by hand"
+		drifted.DeploymentSpec.Scheduler.AU = 10
+		drifted.WorkerQueues[0].MaxWorkerCount = 100
+		drifted.WorkerQueues = append(drifted.WorkerQueues, astro.WorkerQueue{Name: "extra", NodePoolID: "test-pool-id-2"})
+		drifted.DeploymentSpec.EnvironmentVariablesObjects = []astro.EnvironmentVariablesObject{{Key: "foo", Value: "baz"}}
+		drifted.AlertEmails = []string{"test2@test.com"}
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{drifted}, nil).Once()
+		err := Apply(filePath, ApplyOptions{PlanOnly: true}, mockClient, out)
+		assert.ErrorIs(t, err, ErrDriftDetected)
+		assert.Contains(t, out.String(), "~ deployment.configuration.description: changed by hand -> description 1")
+		assert.Contains(t, out.String(), "~ deployment.configuration.scheduler_au: 10 -> 5")
+		assert.Contains(t, out.String(), "~ deployment.worker_queues[default].max_worker_count: 100 -> 130")
+		assert.Contains(t, out.String(), "deployment.worker_queues[extra]: test-worker-2")
+		assert.Contains(t, out.String(), "~ deployment.environment_variables[foo].value: baz -> bar")
+		assert.Contains(t, out.String(), "deployment.environment_variables[token]: ****")
+		assert.NotContains(t, out.String(), "secret-value")
+		assert.Contains(t, out.String(), "deployment.alert_emails: test1@test.com")
+		assert.Contains(t, out.String(), "deployment.alert_emails: test2@test.com")
+		assert.Contains(t, out.String(), "2 to add, 4 to change, 2 to remove")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("updates the deployment if it drifted", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		drifted := liveDeployment()
+		drifted.Description = "changed by hand"
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{drifted}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{cluster}, nil).Once()
+		mockClient.On("GetWorkerQueueOptions").Return(astro.WorkerQueueDefaultOptions{
+			MinWorkerCount:    astro.WorkerQueueOption{Floor: 1, Ceiling: 20, Default: 5},
+			MaxWorkerCount:    astro.WorkerQueueOption{Floor: 16, Ceiling: 200, Default: 125},
+			WorkerConcurrency: astro.WorkerQueueOption{Floor: 175, Ceiling: 275, Default: 180},
+		}, nil).Once()
+		mockClient.On("UpdateDeployment", mock.MatchedBy(func(input *astro.UpdateDeploymentInput) bool {
+			return input.ID == "test-deployment-id" && input.Description == "description 1"
+		})).Return(liveDeployment(), nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.Anything).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+		mockClient.On("UpdateAlertEmails", mock.Anything).Return(astro.DeploymentAlerts{}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{liveDeployment()}, nil).Once()
+		err := Apply(filePath, ApplyOptions{}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Deployment test-deployment-label will be updated")
+		assert.Contains(t, out.String(), "description: description 1")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("creates the deployment if it does not exist", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{cluster}, nil).Once()
+		mockClient.On("ListWorkspaces", orgID).Return([]astro.Workspace{{ID: "test-workspace-id", Label: "test-workspace"}}, nil).Once()
+		mockClient.On("GetWorkerQueueOptions").Return(astro.WorkerQueueDefaultOptions{
+			MinWorkerCount:    astro.WorkerQueueOption{Floor: 1, Ceiling: 20, Default: 5},
+			MaxWorkerCount:    astro.WorkerQueueOption{Floor: 16, Ceiling: 200, Default: 125},
+			WorkerConcurrency: astro.WorkerQueueOption{Floor: 175, Ceiling: 275, Default: 180},
+		}, nil).Once()
+		mockClient.On("CreateDeployment", mock.Anything).Return(liveDeployment(), nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.Anything).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+		mockClient.On("UpdateAlertEmails", mock.Anything).Return(astro.DeploymentAlerts{}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{liveDeployment()}, nil).Once()
+		err := Apply(filePath, ApplyOptions{}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "does not exist and will be created")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns ErrDriftDetected if the deployment does not exist and only a plan is requested", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil).Once()
+		err := Apply(filePath, ApplyOptions{PlanOnly: true}, mockClient, out)
+		assert.ErrorIs(t, err, ErrDriftDetected)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if listing deployments fails", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, errTest).Once()
+		err := Apply(filePath, ApplyOptions{PlanOnly: true}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errTest)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestGetPlan(t *testing.T) {
+	minCount := 1
+	t.Run("does not compare fields left out of the file", func(t *testing.T) {
+		fromFile := inspect.FormattedDeployment{}
+		fromFile.Deployment.Configuration.Name = "test"
+		fromFile.Deployment.WorkerQs = []inspect.Workerq{{Name: "default", WorkerType: "worker-1"}}
+		live := fromFile
+		live.Deployment.WorkerQs = []inspect.Workerq{{Name: "default", WorkerType: "worker-1", MinWorkerCount: &minCount, MaxWorkerCount: 10}}
+		live.Deployment.EnvVars = []inspect.EnvironmentVariable{{Key: "foo", Value: "bar"}}
+		live.Deployment.AlertEmails = []string{"test@test.com"}
+		plan := getPlan(&fromFile, &live)
+		assert.False(t, plan.HasChanges())
+	})
+	t.Run("reports the configuration fields that are reset when they are left out", func(t *testing.T) {
+		fromFile := inspect.FormattedDeployment{}
+		fromFile.Deployment.Configuration.Name = "test"
+		live := fromFile
+		live.Deployment.Configuration.Description = "description"
+		live.Deployment.Configuration.DagDeployEnabled = true
+		live.Deployment.Configuration.SchedulerAU = 5
+		plan := getPlan(&fromFile, &live)
+		assert.Equal(t, []Change{
+			{Action: updateChange, Field: "deployment.configuration.description", From: "description", To: ""},
+			{Action: updateChange, Field: "deployment.configuration.dag_deploy_enabled", From: "true", To: "false"},
+			{Action: updateChange, Field: "deployment.configuration.scheduler_au", From: "5", To: "0"},
+		}, plan.Changes)
+
+		out := new(bytes.Buffer)
+		printPlan(&plan, out)
+		assert.Contains(t, out.String(), `~ deployment.configuration.description: description -> ""`)
+	})
+	t.Run("reports secrets becoming plain variables without their values", func(t *testing.T) {
+		fromFile := inspect.FormattedDeployment{}
+		fromFile.Deployment.EnvVars = []inspect.EnvironmentVariable{{Key: "foo", Value: "bar"}}
+		live := inspect.FormattedDeployment{}
+		live.Deployment.EnvVars = []inspect.EnvironmentVariable{{Key: "foo", IsSecret: true}}
+		plan := getPlan(&fromFile, &live)
+		assert.Equal(t, []Change{{Action: updateChange, Field: "deployment.environment_variables[foo].is_secret", From: "true", To: "false"}}, plan.Changes)
+	})
+}
+
+func TestApplyMultipleDeployments(t *testing.T) {
+	orgID := "test-org-id"
+	cluster := astro.Cluster{ID: "test-cluster-id", Name: "test-cluster"}
+	deploymentDoc := func(name, dependsOn string) string {
+		doc := `
+deployment:
+  configuration:
+    name: ` + name + `
+    description: description
+    executor: CeleryExecutor
+    scheduler_au: 5
+    scheduler_count: 1
+    cluster_name: test-cluster
+    workspace_name: test-workspace
+`
+		if dependsOn != "" {
+			doc += "  depends_on:\n    - " + dependsOn + "\n"
+		}
+		return doc
+	}
+	live := func(name, description string) astro.Deployment {
+		return astro.Deployment{
+			ID:             name + "-id",
+			Label:          name,
+			Description:    description,
+			Workspace:      astro.Workspace{ID: "test-ws-id", Label: "test-workspace"},
+			Cluster:        cluster,
+			DeploymentSpec: astro.DeploymentSpec{Executor: "CeleryExecutor", Scheduler: astro.Scheduler{AU: 5, Replicas: 1}},
+		}
+	}
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "deployments.yaml"), deploymentDoc("first", "")+"---"+deploymentDoc("second", "first"))
+	writeTestFile(t, filepath.Join(dir, "third.yaml"), deploymentDoc("third", ""))
+
+	t.Run("skips deployments whose dependencies failed", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{live("first", "old"), live("third", "description")}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{cluster}, nil).Once()
+		mockClient.On("UpdateDeployment", mock.Anything).Return(astro.Deployment{}, errTest).Once()
+		err := Apply(dir, ApplyOptions{Concurrency: 2}, mockClient, out)
+		assert.ErrorIs(t, err, errApplyFailed)
+		assert.ErrorContains(t, err, "1 of 3 deployments failed to apply")
+		assert.Regexp(t, `first\s+\S+deployments.yaml\s+failed\s+test error`, out.String())
+		assert.Regexp(t, `second\s+\S+deployments.yaml#1\s+skipped\s+dependency failed to apply: first`, out.String())
+		assert.Regexp(t, `third\s+\S+third.yaml\s+unchanged`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("reports drift across every deployment", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{live("first", "description"), live("second", "description"), live("third", "old")}, nil).Once()
+		err := Apply(dir, ApplyOptions{PlanOnly: true, Concurrency: 4}, mockClient, out)
+		assert.ErrorIs(t, err, ErrDriftDetected)
+		assert.Regexp(t, `first\s+\S+\s+unchanged`, out.String())
+		assert.Regexp(t, `third\s+\S+\s+drift`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("prunes deployments that are not declared after confirmation", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		existing := []astro.Deployment{live("first", "description"), live("second", "description"), live("third", "description"), live("extra", "description")}
+		other := live("other-workspace", "description")
+		other.Workspace.ID = "other-ws-id"
+		existing = append(existing, other)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return(existing, nil).Once()
+		mockClient.On("DeleteDeployment", astro.DeleteDeploymentInput{ID: "extra-id"}).Return(astro.Deployment{}, nil).Once()
+		defer testUtil.MockUserInput(t, "y")()
+		err := Apply(dir, ApplyOptions{Prune: true, WorkspaceID: "test-ws-id", Concurrency: 1}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Regexp(t, `extra\s+deleted`, out.String())
+		assert.NotContains(t, out.String(), "other-workspace")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("keeps deployments that are not declared if prune is declined", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{live("first", "description"), live("second", "description"), live("third", "description"), live("extra", "description")}, nil).Once()
+		defer testUtil.MockUserInput(t, "n")()
+		err := Apply(dir, ApplyOptions{Prune: true, WorkspaceID: "test-ws-id"}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Regexp(t, `extra\s+kept`, out.String())
+		mockClient.AssertNotCalled(t, "DeleteDeployment", mock.Anything)
+	})
+	t.Run("does not prune deployments if a deployment failed to apply", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{live("first", "old"), live("second", "description"), live("third", "description"), live("extra", "description")}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{cluster}, nil).Once()
+		mockClient.On("UpdateDeployment", mock.Anything).Return(astro.Deployment{}, errTest).Once()
+		err := Apply(dir, ApplyOptions{Prune: true, WorkspaceID: "test-ws-id", Concurrency: 1}, mockClient, out)
+		assert.ErrorIs(t, err, errApplyFailed)
+		assert.Contains(t, out.String(), "are not pruned as some deployments failed to apply")
+		assert.NotContains(t, out.String(), "extra")
+		mockClient.AssertNotCalled(t, "DeleteDeployment", mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if prune is requested without a workspace", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{live("first", "description"), live("second", "description"), live("third", "description")}, nil).Once()
+		err := Apply(dir, ApplyOptions{Prune: true}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errPruneWithoutWorkspace)
+	})
+}
@@ -0,0 +1,165 @@
+package fromfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/input"
+)
+
+var (
+	errNoCloneName = errors.New("a name is required for the cloned deployment")
+	errNoNodePools = errors.New("has no node pools to run worker queues on")
+	errEmptySecret = errors.New("no value was given for secret environment variable")
+	promptSecret   = input.Password
+)
+
+// CloneOptions are where and how a deployment is cloned
+type CloneOptions struct {
+	// Name is the name of the new deployment
+	Name string
+	// WorkspaceID and ClusterID default to the ones of the source deployment
+	WorkspaceID string
+	ClusterID   string
+	// CopySecrets prompts for the values of the source deployment's secret environment variables
+	CopySecrets bool
+}
+
+// Clone creates a new deployment with the configuration, worker queues, environment variables and alert emails of the
+// deployment identified by sourceID or sourceName in wsID.
+// Worker types that do not exist on the target cluster are replaced by its default node pool.
+// Secret values can not be read back from Astro, they are only copied if options.CopySecrets is true by prompting for them.
+func Clone(wsID, sourceID, sourceName string, options CloneOptions, client astro.Client, out io.Writer) error {
+	if options.Name == "" {
+		return errNoCloneName
+	}
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return err
+	}
+	source, err := deployment.GetDeployment(wsID, sourceID, sourceName, client, nil)
+	if err != nil {
+		return err
+	}
+	formattedDeployment, err := inspect.FormatDeployment(&source)
+	if err != nil {
+		return err
+	}
+	clone := inspect.GetTemplate(&formattedDeployment)
+	clone.Deployment.Configuration.Name = options.Name
+
+	// the clone is created in the workspace and cluster of the source unless others are requested
+	if options.WorkspaceID != "" && options.WorkspaceID != source.Workspace.ID {
+		workspaceName, err := getWorkspaceNameFromID(options.WorkspaceID, c.Organization, client)
+		if err != nil {
+			return err
+		}
+		clone.Deployment.Configuration.WorkspaceName = workspaceName
+	}
+	clusterID := source.Cluster.ID
+	if options.ClusterID != "" {
+		clusterID = options.ClusterID
+	}
+	cluster, err := getClusterFromID(clusterID, c.Organization, client)
+	if err != nil {
+		return err
+	}
+	clone.Deployment.Configuration.ClusterName = cluster.Name
+	err = remapWorkerTypes(&clone, &cluster, out)
+	if err != nil {
+		return err
+	}
+
+	if options.CopySecrets {
+		secretEnvVars, err := getSecretEnvVars(source.DeploymentSpec.EnvironmentVariablesObjects)
+		if err != nil {
+			return err
+		}
+		clone.Deployment.EnvVars = append(clone.Deployment.EnvVars, secretEnvVars...)
+	}
+
+	existingDeployments, err := client.ListDeployments(c.Organization, "")
+	if err != nil {
+		return err
+	}
+	if deploymentExists(existingDeployments, options.Name) {
+		return fmt.Errorf("deployment: %s %w", options.Name, errCannotUpdateExistingDeployment)
+	}
+	fmt.Fprintf(out, "Cloning deployment %s to %s\n", source.Label, options.Name)
+	return createOrUpdate(&clone, "", createAction, cluster.ID, c.Organization, cluster.NodePools, existingDeployments, false, client, out)
+}
+
+// remapWorkerTypes replaces the worker types of clone that do not exist in cluster with the cluster's default node pool
+func remapWorkerTypes(clone *inspect.FormattedDeployment, cluster *astro.Cluster, out io.Writer) error {
+	for i := range clone.Deployment.WorkerQs {
+		queue := &clone.Deployment.WorkerQs[i]
+		if _, err := getNodePoolIDFromWorkerType(queue.WorkerType, cluster.Name, cluster.NodePools); err == nil {
+			continue
+		}
+		if len(cluster.NodePools) == 0 {
+			return fmt.Errorf("cluster: %s %w", cluster.Name, errNoNodePools)
+		}
+		pool := cluster.NodePools[0]
+		for _, nodePool := range cluster.NodePools {
+			if nodePool.IsDefault {
+				pool = nodePool
+				break
+			}
+		}
+		fmt.Fprintf(out, "worker queue %s: worker type %s does not exist in cluster %s, using %s\n", queue.Name, queue.WorkerType, cluster.Name, pool.NodeInstanceType)
+		queue.WorkerType = pool.NodeInstanceType
+	}
+	return nil
+}
+
+// getSecretEnvVars asks for the value of every secret in envVars and returns them as secret environment variables
+func getSecretEnvVars(envVars []astro.EnvironmentVariablesObject) ([]inspect.EnvironmentVariable, error) {
+	var secrets []inspect.EnvironmentVariable
+	for _, envVar := range envVars {
+		if !envVar.IsSecret {
+			continue
+		}
+		value, err := promptSecret(fmt.Sprintf("Value of secret environment variable %s: ", envVar.Key))
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%w %s", errEmptySecret, envVar.Key)
+		}
+		secrets = append(secrets, inspect.EnvironmentVariable{Key: envVar.Key, Value: value, IsSecret: true})
+	}
+	return secrets, nil
+}
+
+// getClusterFromID returns the cluster identified by clusterID in the organization
+func getClusterFromID(clusterID, organizationID string, client astro.Client) (astro.Cluster, error) {
+	clusters, err := client.ListClusters(organizationID)
+	if err != nil {
+		return astro.Cluster{}, err
+	}
+	for i := range clusters {
+		if clusters[i].ID == clusterID {
+			return clusters[i], nil
+		}
+	}
+	return astro.Cluster{}, fmt.Errorf("cluster_id: %s %w in organization: %s", clusterID, errNotFound, organizationID)
+}
+
+// getWorkspaceNameFromID returns the name of the workspace identified by workspaceID in the organization
+func getWorkspaceNameFromID(workspaceID, organizationID string, client astro.Client) (string, error) {
+	workspaces, err := client.ListWorkspaces(organizationID)
+	if err != nil {
+		return "", err
+	}
+	for i := range workspaces {
+		if workspaces[i].ID == workspaceID {
+			return workspaces[i].Label, nil
+		}
+	}
+	return "", fmt.Errorf("workspace_id: %s %w in organization: %s", workspaceID, errNotFound, organizationID)
+}
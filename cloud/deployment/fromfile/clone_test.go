@@ -0,0 +1,106 @@
+package fromfile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/pkg/input"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClone(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	var (
+		orgID         = "test-org-id"
+		sourceCluster = astro.Cluster{ID: "source-cluster-id", Name: "source-cluster", NodePools: []astro.NodePool{{ID: "source-pool-id", NodeInstanceType: "m5.xlarge"}}}
+		targetCluster = astro.Cluster{ID: "target-cluster-id", Name: "target-cluster", NodePools: []astro.NodePool{
+			{ID: "target-pool-id", NodeInstanceType: "e2-standard-4"},
+			{ID: "target-default-pool-id", NodeInstanceType: "e2-standard-8", IsDefault: true},
+		}}
+		workspaces   = []astro.Workspace{{ID: "test-ws-id", Label: "production"}, {ID: "staging-ws-id", Label: "staging"}}
+		queueOptions = astro.WorkerQueueDefaultOptions{
+			MinWorkerCount:    astro.WorkerQueueOption{Floor: 0, Ceiling: 20, Default: 5},
+			MaxWorkerCount:    astro.WorkerQueueOption{Floor: 1, Ceiling: 200, Default: 125},
+			WorkerConcurrency: astro.WorkerQueueOption{Floor: 1, Ceiling: 275, Default: 180},
+		}
+	)
+	source := astro.Deployment{
+		ID:             "source-id",
+		Label:          "prod",
+		Workspace:      astro.Workspace{ID: "test-ws-id", Label: "production"},
+		Cluster:        sourceCluster,
+		RuntimeRelease: astro.RuntimeRelease{Version: "6.0.0"},
+		DeploymentSpec: astro.DeploymentSpec{
+			Executor:  "CeleryExecutor",
+			Scheduler: astro.Scheduler{AU: 5, Replicas: 1},
+			EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+				{Key: "foo", Value: "bar"},
+				{Key: "token", IsSecret: true},
+			},
+		},
+		WorkerQueues: []astro.WorkerQueue{{Name: "default", IsDefault: true, MaxWorkerCount: 10, MinWorkerCount: 1, WorkerConcurrency: 16, NodePoolID: "source-pool-id"}},
+		AlertEmails:  []string{"team@test.com"},
+	}
+	cloned := astro.Deployment{ID: "clone-id", Label: "staging", Workspace: astro.Workspace{ID: "staging-ws-id"}}
+
+	t.Run("clones to another workspace and cluster and remaps worker types", func(t *testing.T) {
+		defer func() { promptSecret = input.Password }()
+		promptSecret = func(string) (string, error) { return "secret-value", nil }
+		out := new(bytes.Buffer)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "test-ws-id").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("ListWorkspaces", orgID).Return(workspaces, nil).Twice()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{sourceCluster, targetCluster}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("GetWorkerQueueOptions").Return(queueOptions, nil).Once()
+		mockClient.On("CreateDeployment", mock.MatchedBy(func(input *astro.CreateDeploymentInput) bool {
+			return input.Label == "staging" && input.WorkspaceID == "staging-ws-id" && input.ClusterID == "target-cluster-id" &&
+				input.WorkerQueues[0].NodePoolID == "target-default-pool-id"
+		})).Return(cloned, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			return len(input.EnvironmentVariables) == 2 && input.EnvironmentVariables[1].Value == "secret-value" && input.EnvironmentVariables[1].IsSecret
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+		mockClient.On("UpdateAlertEmails", mock.Anything).Return(astro.DeploymentAlerts{}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "staging-ws-id").Return([]astro.Deployment{cloned}, nil).Once()
+		err := Clone("test-ws-id", "source-id", "", CloneOptions{Name: "staging", WorkspaceID: "staging-ws-id", ClusterID: "target-cluster-id", CopySecrets: true}, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "worker queue default: worker type m5.xlarge does not exist in cluster target-cluster, using e2-standard-8")
+		assert.Contains(t, out.String(), "Cloning deployment prod to staging")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if the name is taken", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "test-ws-id").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{sourceCluster}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{source}, nil).Once()
+		err := Clone("test-ws-id", "source-id", "", CloneOptions{Name: "prod"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errCannotUpdateExistingDeployment)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if the target cluster does not exist", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "test-ws-id").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{sourceCluster}, nil).Once()
+		err := Clone("test-ws-id", "source-id", "", CloneOptions{Name: "staging", ClusterID: "missing-cluster-id"}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNotFound)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if a secret value is empty", func(t *testing.T) {
+		defer func() { promptSecret = input.Password }()
+		promptSecret = func(string) (string, error) { return "", nil }
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", orgID, "test-ws-id").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{sourceCluster}, nil).Once()
+		err := Clone("test-ws-id", "source-id", "", CloneOptions{Name: "staging", CopySecrets: true}, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errEmptySecret)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("requires a name", func(t *testing.T) {
+		err := Clone("test-ws-id", "source-id", "", CloneOptions{}, nil, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoCloneName)
+	})
+}
@@ -0,0 +1,222 @@
+package fromfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+)
+
+var (
+	errNoDeploymentFiles = errors.New("no deployment files found in")
+	errDuplicateName     = errors.New("is declared more than once")
+	errDependencyCycle   = errors.New("deployments depend on each other")
+)
+
+// deploymentFileExtensions are the files read from a directory of deployment files
+var deploymentFileExtensions = []string{".yaml", ".yml", ".json", templateExtension}
+
+// fragmentPrefix starts the names of files and directories that hold fragments used with include and extends, they
+// are skipped when a directory of deployment files is read
+const fragmentPrefix = "_"
+
+// deploymentDocument is a single deployment read from a deployment file
+type deploymentDocument struct {
+	// source is the file the deployment was read from followed by the document index for multi-document files
+	source     string
+	deployment inspect.FormattedDeployment
+	jsonOutput bool
+}
+
+func (d *deploymentDocument) name() string {
+	return d.deployment.Deployment.Configuration.Name
+}
+
+// readDeploymentDocuments reads the deployments in inputPath, rendering templates with vars.
+// inputPath can be a yaml file holding one or more documents, a json file or a directory of such files.
+// It returns an error if a deployment name is declared more than once.
+func readDeploymentDocuments(inputPath string, vars map[string]interface{}) ([]deploymentDocument, error) {
+	files, err := deploymentFiles(inputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []deploymentDocument
+	declared := map[string]string{}
+	for _, file := range files {
+		fileDocuments, err := readDeploymentFileDocuments(file, vars)
+		if err != nil {
+			return nil, err
+		}
+		for i := range fileDocuments {
+			name := fileDocuments[i].name()
+			if source, ok := declared[name]; ok && name != "" {
+				return nil, fmt.Errorf("deployment: %s %w: %s and %s", name, errDuplicateName, source, fileDocuments[i].source)
+			}
+			declared[name] = fileDocuments[i].source
+		}
+		documents = append(documents, fileDocuments...)
+	}
+	return documents, nil
+}
+
+// deploymentFiles returns inputPath if it is a file or the deployment files in it if it is a directory
+func deploymentFiles(inputPath string) ([]string, error) {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{inputPath}, nil
+	}
+	files, err := listDeploymentFiles(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w %s", errNoDeploymentFiles, inputPath)
+	}
+	return files, nil
+}
+
+// listDeploymentFiles returns the deployment files in dir and its sub directories sorted by path. Files and
+// directories whose name starts with fragmentPrefix are skipped.
+func listDeploymentFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		fragment := path != dir && strings.HasPrefix(d.Name(), fragmentPrefix)
+		if d.IsDir() {
+			if fragment {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if fragment {
+			return nil
+		}
+		for _, ext := range deploymentFileExtensions {
+			if strings.EqualFold(filepath.Ext(path), ext) {
+				files = append(files, path)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readDeploymentFileDocuments renders a deployment file with vars and reads every document in it, empty documents are
+// skipped
+func readDeploymentFileDocuments(inputFile string, vars map[string]interface{}) ([]deploymentDocument, error) {
+	dataBytes, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	// return errEmptyFile if we have no dataBytes
+	if len(bytes.TrimSpace(dataBytes)) == 0 {
+		return nil, fmt.Errorf("%s %w", inputFile, errEmptyFile)
+	}
+	rendered, err := renderTemplate(inputFile, vars, 0)
+	if err != nil {
+		return nil, err
+	}
+	renderedDocuments, err := splitDocuments(inputFile, rendered, vars, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []deploymentDocument
+	for index, renderedDocument := range renderedDocuments {
+		if renderedDocument == nil {
+			continue
+		}
+		var formattedDeployment inspect.FormattedDeployment
+		if err := decodeDocument(renderedDocument, &formattedDeployment); err != nil {
+			return nil, fmt.Errorf("%s: %w", inputFile, err)
+		}
+		source := inputFile
+		if index > 0 {
+			source = fmt.Sprintf("%s#%d", inputFile, index)
+		}
+		documents = append(documents, deploymentDocument{source: source, deployment: formattedDeployment, jsonOutput: isJSON(rendered)})
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("%s %w", inputFile, errEmptyFile)
+	}
+	return documents, nil
+}
+
+// dependencyLevels groups documents so every deployment comes after the deployments it depends on.
+// Deployments in the same level do not depend on each other and can be applied together.
+// Dependencies on deployments that are not in documents have to exist already.
+// It returns an error if a dependency does not exist or if deployments depend on each other.
+func dependencyLevels(documents []deploymentDocument, existingDeployments []astro.Deployment) ([][]int, error) {
+	index := map[string]int{}
+	for i := range documents {
+		index[documents[i].name()] = i
+	}
+
+	pending := make([]int, len(documents))
+	dependents := make([][]int, len(documents))
+	for i := range documents {
+		for _, dependency := range documents[i].deployment.Deployment.DependsOn {
+			j, ok := index[dependency]
+			if !ok {
+				if deploymentExists(existingDeployments, dependency) {
+					continue
+				}
+				return nil, fmt.Errorf("deployment: %s depends_on: %s %w", documents[i].name(), dependency, errNotFound)
+			}
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	var levels [][]int
+	var level []int
+	for i := range documents {
+		if pending[i] == 0 {
+			level = append(level, i)
+		}
+	}
+	resolved := 0
+	for len(level) > 0 {
+		levels = append(levels, level)
+		resolved += len(level)
+		var next []int
+		for _, i := range level {
+			for _, j := range dependents[i] {
+				pending[j]--
+				if pending[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		level = next
+	}
+	if resolved != len(documents) {
+		var cycle []string
+		for i := range documents {
+			if pending[i] > 0 {
+				cycle = append(cycle, documents[i].name())
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", errDependencyCycle, strings.Join(cycle, ", "))
+	}
+	return levels, nil
+}
@@ -0,0 +1,127 @@
+package fromfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path, []byte(data), os.ModePerm))
+}
+
+func TestReadDeploymentDocuments(t *testing.T) {
+	t.Run("reads every document of a multi-document file", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "deployments.yaml")
+		writeTestFile(t, file, `---
+deployment:
+  configuration:
+    name: first
+---
+# empty documents are skipped
+---
+deployment:
+  configuration:
+    name: second
+  depends_on:
+    - first
+`)
+		documents, err := readDeploymentDocuments(file, nil)
+		assert.NoError(t, err)
+		assert.Len(t, documents, 2)
+		assert.Equal(t, "first", documents[0].name())
+		assert.Equal(t, file, documents[0].source)
+		assert.Equal(t, "second", documents[1].name())
+		assert.Equal(t, file+"#2", documents[1].source)
+		assert.Equal(t, []string{"first"}, documents[1].deployment.Deployment.DependsOn)
+	})
+	t.Run("reads every deployment file of a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "b.yml"), "deployment:\n  configuration:\n    name: b\n")
+		writeTestFile(t, filepath.Join(dir, "a.json"), `{"deployment": {"configuration": {"name": "a"}}}`)
+		writeTestFile(t, filepath.Join(dir, "nested", "c.yaml"), "deployment:\n  configuration:\n    name: c\n")
+		writeTestFile(t, filepath.Join(dir, "README.md"), "not a deployment")
+		documents, err := readDeploymentDocuments(dir, nil)
+		assert.NoError(t, err)
+		assert.Len(t, documents, 3)
+		assert.Equal(t, "a", documents[0].name())
+		assert.True(t, documents[0].jsonOutput)
+		assert.Equal(t, "b", documents[1].name())
+		assert.Equal(t, "c", documents[2].name())
+	})
+	t.Run("skips the fragments of a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "a.yaml.tmpl"), "extends: _base.yaml\ndeployment:\n  configuration:\n    name: a\n  worker_queues:\n{{ include \"partials/_queues.yaml\" | indent 4 }}\n")
+		writeTestFile(t, filepath.Join(dir, "_base.yaml"), "deployment:\n  configuration:\n    description: base\n")
+		writeTestFile(t, filepath.Join(dir, "partials", "_queues.yaml"), "- name: default\n  worker_type: m5.xlarge\n")
+		writeTestFile(t, filepath.Join(dir, "_shared", "queues.yaml"), "- name: default\n")
+		documents, err := readDeploymentDocuments(dir, nil)
+		assert.NoError(t, err)
+		assert.Len(t, documents, 1)
+		assert.Equal(t, "a", documents[0].name())
+		assert.Equal(t, "base", documents[0].deployment.Deployment.Configuration.Description)
+		assert.Equal(t, "default", documents[0].deployment.Deployment.WorkerQs[0].Name)
+	})
+	t.Run("only renders the templates of a directory with vars", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "a.yaml"), "deployment:\n  configuration:\n    name: a\n    description: \"{{ ds }}\"\n")
+		writeTestFile(t, filepath.Join(dir, "b.yaml.tmpl"), "deployment:\n  configuration:\n    name: {{ .Vars.name }}\n")
+		documents, err := readDeploymentDocuments(dir, map[string]interface{}{"name": "b"})
+		assert.NoError(t, err)
+		assert.Len(t, documents, 2)
+		assert.Equal(t, "{{ ds }}", documents[0].deployment.Deployment.Configuration.Description)
+		assert.Equal(t, "b", documents[1].name())
+	})
+	t.Run("returns an error if a directory has no deployment files", func(t *testing.T) {
+		_, err := readDeploymentDocuments(t.TempDir(), nil)
+		assert.ErrorIs(t, err, errNoDeploymentFiles)
+	})
+	t.Run("returns an error if a deployment is declared twice", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "a.yaml"), "deployment:\n  configuration:\n    name: a\n")
+		writeTestFile(t, filepath.Join(dir, "b.yaml"), "deployment:\n  configuration:\n    name: a\n")
+		_, err := readDeploymentDocuments(dir, nil)
+		assert.ErrorIs(t, err, errDuplicateName)
+	})
+	t.Run("returns an error if a file is empty", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, "---\n")
+		_, err := readDeploymentDocuments(file, nil)
+		assert.ErrorIs(t, err, errEmptyFile)
+	})
+}
+
+func TestDependencyLevels(t *testing.T) {
+	document := func(name string, dependsOn ...string) deploymentDocument {
+		d := deploymentDocument{}
+		d.deployment.Deployment.Configuration.Name = name
+		d.deployment.Deployment.DependsOn = dependsOn
+		return d
+	}
+	t.Run("orders deployments after their dependencies", func(t *testing.T) {
+		documents := []deploymentDocument{
+			document("c", "b"),
+			document("b", "a"),
+			document("a"),
+			document("d", "a", "existing"),
+		}
+		levels, err := dependencyLevels(documents, []astro.Deployment{{Label: "existing"}})
+		assert.NoError(t, err)
+		assert.Equal(t, [][]int{{2}, {1, 3}, {0}}, levels)
+	})
+	t.Run("returns an error if a dependency does not exist", func(t *testing.T) {
+		_, err := dependencyLevels([]deploymentDocument{document("a", "missing")}, nil)
+		assert.ErrorIs(t, err, errNotFound)
+	})
+	t.Run("returns an error if deployments depend on each other", func(t *testing.T) {
+		_, err := dependencyLevels([]deploymentDocument{document("a", "b"), document("b", "a"), document("c")}, nil)
+		assert.ErrorIs(t, err, errDependencyCycle)
+		assert.ErrorContains(t, err, "a, b")
+	})
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"net/mail"
-	"os"
 	"sort"
 
 	"github.com/astronomer/astro-cli/astro-client"
@@ -14,7 +13,6 @@ import (
 	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
 	"github.com/astronomer/astro-cli/cloud/deployment/workerqueue"
 	"github.com/astronomer/astro-cli/config"
-	"github.com/ghodss/yaml"
 )
 
 var (
@@ -37,35 +35,19 @@ const (
 )
 
 // CreateOrUpdate takes a file and creates a deployment with the confiuration specified in the file.
-// inputFile can be in yaml or json format
+// inputFile can be in yaml or json format, it is rendered with vars if it is a template.
 // It returns an error if any required information is missing or incorrectly specified.
-func CreateOrUpdate(inputFile, action string, client astro.Client, out io.Writer) error {
+func CreateOrUpdate(inputFile, action string, vars map[string]interface{}, client astro.Client, out io.Writer) error {
 	var (
-		err                                            error
-		errHelp, clusterID, workspaceID, outputFormat  string
-		dataBytes                                      []byte
-		formattedDeployment                            inspect.FormattedDeployment
-		createInput                                    astro.CreateDeploymentInput
-		updateInput                                    astro.UpdateDeploymentInput
-		existingDeployment, createdOrUpdatedDeployment astro.Deployment
-		existingDeployments                            []astro.Deployment
-		nodePools                                      []astro.NodePool
-		jsonOutput                                     bool
+		err                 error
+		clusterID           string
+		formattedDeployment inspect.FormattedDeployment
+		existingDeployments []astro.Deployment
+		nodePools           []astro.NodePool
+		jsonOutput          bool
 	)
 
-	// get file contents as []byte
-	dataBytes, err = os.ReadFile(inputFile)
-	if err != nil {
-		return err
-	}
-	// return errEmptyFile if we have no dataBytes
-	if len(dataBytes) == 0 {
-		return fmt.Errorf("%s %w", inputFile, errEmptyFile)
-	}
-	// set outputFormat if json
-	jsonOutput = isJSON(dataBytes)
-	// unmarshal to a formattedDeployment
-	err = yaml.Unmarshal(dataBytes, &formattedDeployment)
+	formattedDeployment, jsonOutput, err = readDeploymentFile(inputFile, vars)
 	if err != nil {
 		return err
 	}
@@ -84,14 +66,43 @@ func CreateOrUpdate(inputFile, action string, client astro.Client, out io.Writer
 		return err
 	}
 
-	existingDeployments, err = client.ListDeployments(c.Organization, workspaceID)
+	existingDeployments, err = client.ListDeployments(c.Organization, "")
 	if err != nil {
 		return err
 	}
+	return createOrUpdate(&formattedDeployment, inputFile, action, clusterID, c.Organization, nodePools, existingDeployments, jsonOutput, client, out)
+}
+
+// readDeploymentFile renders inputFile with vars and reads it into an inspect.FormattedDeployment.
+// It returns true if inputFile is in json format and false if it is in yaml format.
+// It returns an error if the file is empty, can not be unmarshalled or holds more than one deployment.
+func readDeploymentFile(inputFile string, vars map[string]interface{}) (inspect.FormattedDeployment, bool, error) {
+	documents, err := readDeploymentFileDocuments(inputFile, vars)
+	if err != nil {
+		return inspect.FormattedDeployment{}, false, err
+	}
+	if len(documents) > 1 {
+		return inspect.FormattedDeployment{}, false, fmt.Errorf("%s %w", inputFile, errMultipleDocuments)
+	}
+	return documents[0].deployment, documents[0].jsonOutput, nil
+}
+
+// createOrUpdate creates or updates the deployment described by formattedDeployment based on the action requested.
+// It creates or updates the environment variables and alert emails of the deployment and prints the result in the
+// format of the deployment file.
+func createOrUpdate(formattedDeployment *inspect.FormattedDeployment, inputFile, action, clusterID, organizationID string, nodePools []astro.NodePool, existingDeployments []astro.Deployment, jsonOutput bool, client astro.Client, out io.Writer) error {
+	var (
+		err                                            error
+		errHelp, workspaceID, outputFormat             string
+		createInput                                    astro.CreateDeploymentInput
+		updateInput                                    astro.UpdateDeploymentInput
+		existingDeployment, createdOrUpdatedDeployment astro.Deployment
+	)
+
 	switch action {
 	case createAction:
 		// map workspace name to id
-		workspaceID, err = getWorkspaceIDFromName(formattedDeployment.Deployment.Configuration.WorkspaceName, c.Organization, client)
+		workspaceID, err = getWorkspaceIDFromName(formattedDeployment.Deployment.Configuration.WorkspaceName, organizationID, client)
 		if err != nil {
 			return err
 		}
@@ -104,7 +115,7 @@ func CreateOrUpdate(inputFile, action string, client astro.Client, out io.Writer
 		}
 		// this deployment does not exist so create it
 		// transform formattedDeployment to DeploymentCreateInput
-		createInput, _, err = getCreateOrUpdateInput(&formattedDeployment, clusterID, workspaceID, createAction, &astro.Deployment{}, nodePools, client)
+		createInput, _, err = getCreateOrUpdateInput(formattedDeployment, clusterID, workspaceID, createAction, &astro.Deployment{}, nodePools, client)
 		if err != nil {
 			return err
 		}
@@ -126,7 +137,7 @@ func CreateOrUpdate(inputFile, action string, client astro.Client, out io.Writer
 		workspaceID = existingDeployment.Workspace.ID
 
 		// transform formattedDeployment to DeploymentUpdateInput
-		_, updateInput, err = getCreateOrUpdateInput(&formattedDeployment, clusterID, workspaceID, updateAction, &existingDeployment, nodePools, client)
+		_, updateInput, err = getCreateOrUpdateInput(formattedDeployment, clusterID, workspaceID, updateAction, &existingDeployment, nodePools, client)
 		if err != nil {
 			return err
 		}
@@ -137,15 +148,15 @@ func CreateOrUpdate(inputFile, action string, client astro.Client, out io.Writer
 		}
 	}
 	// create environment variables
-	if hasEnvVars(&formattedDeployment) {
-		_, err = createEnvVars(&formattedDeployment, createdOrUpdatedDeployment.ID, client)
+	if hasEnvVars(formattedDeployment) {
+		_, err = createEnvVars(formattedDeployment, createdOrUpdatedDeployment.ID, client)
 		if err != nil {
 			return fmt.Errorf("%w \n failed to %s alert emails", err, action)
 		}
 	}
 	// create alert emails
-	if hasAlertEmails(&formattedDeployment) {
-		_, err = createAlertEmails(&formattedDeployment, createdOrUpdatedDeployment.ID, client)
+	if hasAlertEmails(formattedDeployment) {
+		_, err = createAlertEmails(formattedDeployment, createdOrUpdatedDeployment.ID, client)
 		if err != nil {
 			return err
 		}
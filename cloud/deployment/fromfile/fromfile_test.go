@@ -33,7 +33,7 @@ func TestCreateOrUpdate(t *testing.T) {
 
 	t.Run("common across create or update", func(t *testing.T) {
 		t.Run("returns an error if file does not exist", func(t *testing.T) {
-			err = CreateOrUpdate("deployment.yaml", "create", nil, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, nil, nil)
 			assert.ErrorContains(t, err, "open deployment.yaml: no such file or directory")
 		})
 		t.Run("returns an error if file exists but user provides incorrect path", func(t *testing.T) {
@@ -42,7 +42,7 @@ func TestCreateOrUpdate(t *testing.T) {
 			err = fileutil.WriteStringToFile(filePath, data)
 			assert.NoError(t, err)
 			defer afero.NewOsFs().RemoveAll("./2")
-			err = CreateOrUpdate("1/deployment.yaml", "create", nil, nil)
+			err = CreateOrUpdate("1/deployment.yaml", "create", nil, nil, nil)
 			assert.ErrorContains(t, err, "open 1/deployment.yaml: no such file or directory")
 		})
 		t.Run("returns an error if file is empty", func(t *testing.T) {
@@ -50,7 +50,7 @@ func TestCreateOrUpdate(t *testing.T) {
 			data = ""
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
-			err = CreateOrUpdate("deployment.yaml", "create", nil, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, nil, nil)
 			assert.ErrorIs(t, err, errEmptyFile)
 			assert.ErrorContains(t, err, "deployment.yaml has no content")
 		})
@@ -59,7 +59,7 @@ func TestCreateOrUpdate(t *testing.T) {
 			data = "test"
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
-			err = CreateOrUpdate("deployment.yaml", "create", nil, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, nil, nil)
 			assert.ErrorContains(t, err, "error unmarshaling JSON:")
 		})
 		t.Run("returns an error if required fields are missing", func(t *testing.T) {
@@ -112,7 +112,7 @@ deployment:
 `
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
-			err = CreateOrUpdate("deployment.yaml", "create", nil, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, nil, nil)
 			assert.ErrorContains(t, err, "missing required field: deployment.configuration.name")
 		})
 		t.Run("returns an error if getting context fails", func(t *testing.T) {
@@ -171,7 +171,7 @@ deployment:
 
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorContains(t, err, "no context set")
 			mockClient.AssertExpectations(t)
 		})
@@ -252,7 +252,7 @@ deployment:
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errNotFound)
 			mockClient.AssertExpectations(t)
 		})
@@ -323,7 +323,7 @@ deployment:
 			fileutil.WriteStringToFile(filePath, data)
 			defer afero.NewOsFs().Remove(filePath)
 			mockClient.On("ListClusters", orgID).Return([]astro.Cluster{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errTest)
 			mockClient.AssertExpectations(t)
 		})
@@ -405,7 +405,7 @@ deployment:
 			defer afero.NewOsFs().Remove(filePath)
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errTest)
 			mockClient.AssertExpectations(t)
 		})
@@ -527,7 +527,7 @@ deployment:
 			mockClient.On("CreateDeployment", mock.Anything).Return(astro.Deployment{}, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(astro.DeploymentAlerts{}, nil)
 			mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{createdDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.NotNil(t, out)
 			mockClient.AssertExpectations(t)
@@ -647,7 +647,7 @@ deployment:
 			mockClient.On("CreateDeployment", mock.Anything).Return(astro.Deployment{}, nil)
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return([]astro.EnvironmentVariablesObject{}, nil)
 			mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{createdDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.NotNil(t, out)
 			mockClient.AssertExpectations(t)
@@ -777,7 +777,7 @@ deployment:
 			mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
 			mockClient.On("CreateDeployment", mock.Anything).Return(astro.Deployment{}, nil)
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return([]astro.EnvironmentVariablesObject{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errTest)
 			assert.ErrorContains(t, err, "\n failed to create alert emails")
 			mockClient.AssertExpectations(t)
@@ -908,7 +908,7 @@ deployment:
 			mockClient.On("CreateDeployment", mock.Anything).Return(astro.Deployment{}, nil)
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return([]astro.EnvironmentVariablesObject{}, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(astro.DeploymentAlerts{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errTest)
 			mockClient.AssertExpectations(t)
 		})
@@ -1048,7 +1048,7 @@ deployment:
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockEnvVarResponse, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(mockAlertEmailResponse, nil)
 			mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{createdDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.Contains(t, out.String(), "configuration:\n        name: "+createdDeployment.Label)
 			assert.Contains(t, out.String(), "metadata:\n        deployment_id: "+createdDeployment.ID)
@@ -1202,7 +1202,7 @@ deployment:
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockEnvVarResponse, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(mockAlertEmailResponse, nil)
 			mockClient.On("ListDeployments", orgID, "test-workspace-id").Return([]astro.Deployment{createdDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.Contains(t, out.String(), "\"configuration\": {\n            \"name\": \""+createdDeployment.Label+"\"")
 			assert.Contains(t, out.String(), "\"metadata\": {\n            \"deployment_id\": \""+createdDeployment.ID+"\"")
@@ -1289,7 +1289,7 @@ deployment:
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil)
 			mockClient.On("ListWorkspaces", orgID).Return([]astro.Workspace{}, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errNotFound)
 			mockClient.AssertExpectations(t)
 		})
@@ -1374,7 +1374,7 @@ deployment:
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil)
 			mockClient.On("ListWorkspaces", orgID).Return([]astro.Workspace{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errTest)
 			mockClient.AssertExpectations(t)
 		})
@@ -1467,7 +1467,7 @@ deployment:
 			mockClient.On("ListWorkspaces", orgID).Return(existingWorkspaces, nil)
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return(existingDeployments, nil)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorContains(t, err, "deployment: test-deployment-label already exists: use deployment update --deployment-file deployment.yaml instead")
 			mockClient.AssertExpectations(t)
 		})
@@ -1594,7 +1594,7 @@ deployment:
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil)
 			mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.Error(t, err)
 			assert.ErrorContains(t, err, "worker queue option is invalid: worker concurrency")
 			mockClient.AssertExpectations(t)
@@ -1723,7 +1723,7 @@ deployment:
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil)
 			mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
 			mockClient.On("CreateDeployment", mock.Anything).Return(astro.Deployment{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "create", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "create", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errCreateFailed)
 			assert.ErrorContains(t, err, "test error: failed to create deployment with input")
 			mockClient.AssertExpectations(t)
@@ -1885,7 +1885,7 @@ deployment:
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockEnvVarResponse, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(mockAlertEmailResponse, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{updatedDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "update", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "update", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.Contains(t, out.String(), "configuration:\n        name: "+existingDeployment.Label)
 			assert.Contains(t, out.String(), "\n        description: "+updatedDeployment.Description)
@@ -2061,7 +2061,7 @@ deployment:
 			mockClient.On("ModifyDeploymentVariable", mock.Anything).Return(mockEnvVarResponse, nil)
 			mockClient.On("UpdateAlertEmails", mock.Anything).Return(mockAlertEmailResponse, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{updatedDeployment}, nil)
-			err = CreateOrUpdate("deployment.yaml", "update", mockClient, out)
+			err = CreateOrUpdate("deployment.yaml", "update", nil, mockClient, out)
 			assert.NoError(t, err)
 			assert.Contains(t, out.String(), "\"configuration\": {\n            \"name\": \""+existingDeployment.Label+"\"")
 			assert.Contains(t, out.String(), "\n            \"description\": \""+updatedDeployment.Description+"\"")
@@ -2146,7 +2146,7 @@ deployment:
 			defer afero.NewOsFs().Remove(filePath)
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil)
-			err = CreateOrUpdate("deployment.yaml", "update", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "update", nil, mockClient, nil)
 			assert.ErrorContains(t, err, "deployment: test-deployment-label does not exist: use deployment create --deployment-file deployment.yaml instead")
 			mockClient.AssertExpectations(t)
 		})
@@ -2277,7 +2277,7 @@ deployment:
 			mockClient.On("ListClusters", orgID).Return(existingClusters, nil)
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{existingDeployment}, nil)
 			mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
-			err = CreateOrUpdate("deployment.yaml", "update", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "update", nil, mockClient, nil)
 			assert.Error(t, err)
 			assert.ErrorContains(t, err, "worker queue option is invalid: worker concurrency")
 			mockClient.AssertExpectations(t)
@@ -2426,7 +2426,7 @@ deployment:
 			mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{existingDeployment}, nil)
 			mockClient.On("GetWorkerQueueOptions").Return(mockWorkerQueueDefaultOptions, nil).Once()
 			mockClient.On("UpdateDeployment", mock.Anything).Return(astro.Deployment{}, errTest)
-			err = CreateOrUpdate("deployment.yaml", "update", mockClient, nil)
+			err = CreateOrUpdate("deployment.yaml", "update", nil, mockClient, nil)
 			assert.ErrorIs(t, err, errUpdateFailed)
 			assert.ErrorContains(t, err, "test error: failed to update deployment with input")
 			mockClient.AssertExpectations(t)
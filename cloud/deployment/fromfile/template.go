@@ -0,0 +1,275 @@
+package fromfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	extendsKey = "extends"
+	// templateExtension marks deployment files that are rendered as templates, other files are read as they are
+	templateExtension = ".tmpl"
+	// maxTemplateDepth bounds nested include and extends so cycles fail instead of looping
+	maxTemplateDepth = 10
+)
+
+var (
+	errInvalidVar          = errors.New("invalid variable, use key=value")
+	errTemplateDepth       = errors.New("too many nested include or extends, check for cycles")
+	errInvalidExtends      = errors.New("extends must be the path of a deployment file")
+	errMultipleDocuments   = errors.New("holds more than one deployment, use deployment apply to apply it")
+	errRequiredTemplateVar = errors.New("required")
+)
+
+// templateData is what deployment file templates are executed with
+type templateData struct {
+	Vars map[string]interface{}
+	Env  map[string]string
+}
+
+// ParseVars returns the template values of --var key=value flags and --var-file files.
+// Var files are YAML or JSON maps read in order, values of --var flags take precedence over them.
+// It returns nil if there are no flags.
+func ParseVars(vars, varFiles []string) (map[string]interface{}, error) {
+	if len(vars) == 0 && len(varFiles) == 0 {
+		return nil, nil
+	}
+	values := map[string]interface{}{}
+	for _, varFile := range varFiles {
+		data, err := os.ReadFile(varFile)
+		if err != nil {
+			return nil, err
+		}
+		var fileValues map[string]interface{}
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("%s: %w", varFile, err)
+		}
+		for k, v := range fileValues {
+			values[k] = v
+		}
+	}
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidVar, v)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// Render prints the deployments in inputPath as they are applied, after rendering templates with vars and resolving
+// extends
+func Render(inputPath string, vars map[string]interface{}, out io.Writer) error {
+	documents, err := readDeploymentDocuments(inputPath, vars)
+	if err != nil {
+		return err
+	}
+	for i := range documents {
+		data, err := yamlv3.Marshal(documents[i].deployment)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "---\n# Source: %s\n%s", documents[i].source, string(data))
+	}
+	return nil
+}
+
+// renderTemplate executes the deployment file template in file with vars, missing vars are an error unless read with
+// index. Templates can use .Vars, .Env and the include, indent, env, default and required functions.
+// Only templates are rendered, other files are returned as they are so values that look like template actions are
+// kept even if vars are given.
+func renderTemplate(file string, vars map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxTemplateDepth {
+		return nil, fmt.Errorf("%s: %w", file, errTemplateDepth)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if !isTemplateFile(file) {
+		return data, nil
+	}
+	return executeTemplate(file, data, vars, depth)
+}
+
+// renderFragment executes the fragment in file with vars whatever its extension, it is included by a template
+func renderFragment(file string, vars map[string]interface{}, depth int) ([]byte, error) {
+	if depth > maxTemplateDepth {
+		return nil, fmt.Errorf("%s: %w", file, errTemplateDepth)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return executeTemplate(file, data, vars, depth)
+}
+
+func executeTemplate(file string, data []byte, vars map[string]interface{}, depth int) ([]byte, error) {
+	if vars == nil {
+		vars = map[string]interface{}{}
+	}
+	tmpl, err := template.New(filepath.Base(file)).Option("missingkey=error").Funcs(templateFuncs(file, vars, depth)).Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, templateData{Vars: vars, Env: environ()})
+	if err != nil {
+		return nil, err
+	}
+	return rendered.Bytes(), nil
+}
+
+// isTemplateFile is true if file is a deployment file template, like deployment.yaml.tmpl
+func isTemplateFile(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), templateExtension)
+}
+
+func templateFuncs(file string, vars map[string]interface{}, depth int) template.FuncMap {
+	return template.FuncMap{
+		// include renders a fragment relative to the including file
+		"include": func(path string) (string, error) {
+			rendered, err := renderFragment(relativeTo(file, path), vars, depth+1)
+			return strings.TrimRight(string(rendered), "\n"), err
+		},
+		"indent": func(spaces int, s string) string {
+			pad := strings.Repeat(" ", spaces)
+			return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+		},
+		"env": os.Getenv,
+		"default": func(def, value interface{}) interface{} {
+			if value == nil || value == "" {
+				return def
+			}
+			return value
+		},
+		"required": func(msg string, value interface{}) (interface{}, error) {
+			if value == nil || value == "" {
+				return nil, fmt.Errorf("%s %w", msg, errRequiredTemplateVar)
+			}
+			return value, nil
+		},
+	}
+}
+
+// renderDocuments renders file with vars and returns each of its documents with extends resolved
+func renderDocuments(file string, vars map[string]interface{}, depth int) ([]map[string]interface{}, error) {
+	rendered, err := renderTemplate(file, vars, depth)
+	if err != nil {
+		return nil, err
+	}
+	return splitDocuments(file, rendered, vars, depth)
+}
+
+// splitDocuments returns each document of the rendered file with extends resolved, empty documents are nil
+func splitDocuments(file string, rendered []byte, vars map[string]interface{}, depth int) ([]map[string]interface{}, error) {
+	var documents []map[string]interface{}
+	decoder := yamlv3.NewDecoder(bytes.NewReader(rendered))
+	for {
+		var node yamlv3.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			documents = append(documents, nil)
+			continue
+		}
+		// re-encode the document so it is unmarshalled the same way single deployment files are
+		documentBytes, err := yamlv3.Marshal(&node)
+		if err != nil {
+			return nil, err
+		}
+		var document map[string]interface{}
+		if err := yaml.Unmarshal(documentBytes, &document); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		document, err = resolveExtends(file, document, vars, depth)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// resolveExtends merges document on top of the deployment file it extends.
+// Maps are merged key by key, any other value of document replaces the one it extends. The extended file is only
+// rendered if it is a template.
+func resolveExtends(file string, document, vars map[string]interface{}, depth int) (map[string]interface{}, error) {
+	extends, ok := document[extendsKey]
+	if !ok {
+		return document, nil
+	}
+	delete(document, extendsKey)
+	basePath, ok := extends.(string)
+	if !ok || basePath == "" {
+		return nil, fmt.Errorf("%s: %w", file, errInvalidExtends)
+	}
+	baseFile := relativeTo(file, basePath)
+	bases, err := renderDocuments(baseFile, vars, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(bases) != 1 {
+		return nil, fmt.Errorf("%s %w", baseFile, errMultipleDocuments)
+	}
+	return mergeMaps(bases[0], document), nil
+}
+
+func mergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		baseMap, baseIsMap := merged[k].(map[string]interface{})
+		overrideMap, overrideIsMap := v.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[k] = mergeMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// decodeDocument turns a rendered document into the deployment it describes
+func decodeDocument(document map[string]interface{}, formattedDeployment interface{}) error {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, formattedDeployment)
+}
+
+func relativeTo(file, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(file), path)
+}
+
+func environ() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
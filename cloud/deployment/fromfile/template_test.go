@@ -0,0 +1,147 @@
+package fromfile
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVars(t *testing.T) {
+	t.Run("vars take precedence over var files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "vars.yaml"), "region: us-east-1\nau: 5\nqueues:\n  default: 10\n")
+		values, err := ParseVars([]string{"region=eu-west-1", "url=http://host?a=b"}, []string{filepath.Join(dir, "vars.yaml")})
+		assert.NoError(t, err)
+		assert.Equal(t, "eu-west-1", values["region"])
+		assert.Equal(t, "http://host?a=b", values["url"])
+		assert.Equal(t, float64(5), values["au"])
+		assert.Equal(t, map[string]interface{}{"default": float64(10)}, values["queues"])
+	})
+	t.Run("returns an error if a var is not key=value", func(t *testing.T) {
+		_, err := ParseVars([]string{"region"}, nil)
+		assert.ErrorIs(t, err, errInvalidVar)
+	})
+	t.Run("returns an error if a var file does not exist", func(t *testing.T) {
+		_, err := ParseVars(nil, []string{"missing.yaml"})
+		assert.ErrorContains(t, err, "no such file or directory")
+	})
+	t.Run("returns nil without vars", func(t *testing.T) {
+		values, err := ParseVars([]string{}, []string{})
+		assert.NoError(t, err)
+		assert.Nil(t, values)
+	})
+}
+
+func TestRenderDeploymentFiles(t *testing.T) {
+	t.Run("renders vars, env vars and includes", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("TEST_ALERT_EMAIL", "team@test.com")
+		vars := map[string]interface{}{"name": "prod", "au": "10"}
+		writeTestFile(t, filepath.Join(dir, "common", "queues.yaml"), `- name: default
+  worker_type: {{ index .Vars "worker_type" | default "m5.xlarge" }}`)
+		writeTestFile(t, filepath.Join(dir, "deployment.yaml.tmpl"), `deployment:
+  configuration:
+    name: {{ .Vars.name }}
+    scheduler_au: {{ .Vars.au }}
+  worker_queues:
+{{ include "common/queues.yaml" | indent 4 }}
+  alert_emails:
+    - {{ .Env.TEST_ALERT_EMAIL }}
+`)
+		formattedDeployment, _, err := readDeploymentFile(filepath.Join(dir, "deployment.yaml.tmpl"), vars)
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", formattedDeployment.Deployment.Configuration.Name)
+		assert.Equal(t, 10, formattedDeployment.Deployment.Configuration.SchedulerAU)
+		assert.Equal(t, "m5.xlarge", formattedDeployment.Deployment.WorkerQs[0].WorkerType)
+		assert.Equal(t, []string{"team@test.com"}, formattedDeployment.Deployment.AlertEmails)
+	})
+	t.Run("merges deployments over the file they extend", func(t *testing.T) {
+		dir := t.TempDir()
+		vars := map[string]interface{}{"env": "staging"}
+		writeTestFile(t, filepath.Join(dir, "base.yaml.tmpl"), `deployment:
+  configuration:
+    description: {{ .Vars.env }} deployment
+    executor: CeleryExecutor
+    scheduler_au: 5
+  worker_queues:
+    - name: default
+      worker_type: m5.xlarge
+`)
+		writeTestFile(t, filepath.Join(dir, "deployments", "staging.yaml"), `extends: ../base.yaml.tmpl
+deployment:
+  configuration:
+    name: staging
+    scheduler_au: 10
+`)
+		formattedDeployment, _, err := readDeploymentFile(filepath.Join(dir, "deployments", "staging.yaml"), vars)
+		assert.NoError(t, err)
+		assert.Equal(t, "staging", formattedDeployment.Deployment.Configuration.Name)
+		assert.Equal(t, "staging deployment", formattedDeployment.Deployment.Configuration.Description)
+		assert.Equal(t, "CeleryExecutor", formattedDeployment.Deployment.Configuration.Executor)
+		assert.Equal(t, 10, formattedDeployment.Deployment.Configuration.SchedulerAU)
+		assert.Equal(t, "m5.xlarge", formattedDeployment.Deployment.WorkerQs[0].WorkerType)
+	})
+	t.Run("returns an error if a var is missing", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml.tmpl")
+		writeTestFile(t, file, "deployment:\n  configuration:\n    name: {{ .Vars.name }}\n")
+		_, _, err := readDeploymentFile(file, map[string]interface{}{})
+		assert.ErrorContains(t, err, `map has no entry for key "name"`)
+	})
+	t.Run("returns an error if a required value is empty", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml.tmpl")
+		writeTestFile(t, file, "deployment:\n  configuration:\n    name: {{ env \"TEST_UNSET_NAME\" | required \"deployment name\" }}\n")
+		_, _, err := readDeploymentFile(file, map[string]interface{}{})
+		assert.ErrorIs(t, err, errRequiredTemplateVar)
+	})
+	t.Run("returns an error if files extend each other", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "a.yaml"), "extends: b.yaml\n")
+		writeTestFile(t, filepath.Join(dir, "b.yaml"), "extends: a.yaml\n")
+		_, _, err := readDeploymentFile(filepath.Join(dir, "a.yaml"), nil)
+		assert.ErrorIs(t, err, errTemplateDepth)
+	})
+	t.Run("returns an error if a single deployment file holds several deployments", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, "deployment:\n  configuration:\n    name: a\n---\ndeployment:\n  configuration:\n    name: b\n")
+		_, _, err := readDeploymentFile(file, nil)
+		assert.ErrorIs(t, err, errMultipleDocuments)
+	})
+	t.Run("does not render deployment files that are not templates", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, "deployment:\n  configuration:\n    name: test\n    description: \"{{ ds }}\"\n")
+		for _, vars := range []map[string]interface{}{nil, {"name": "test"}} {
+			formattedDeployment, _, err := readDeploymentFile(file, vars)
+			assert.NoError(t, err)
+			assert.Equal(t, "{{ ds }}", formattedDeployment.Deployment.Configuration.Description)
+		}
+	})
+	t.Run("renders templates without vars", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("TEST_DEPLOYMENT_NAME", "from-env")
+		writeTestFile(t, filepath.Join(dir, "queues.yaml"), `- name: default
+  worker_type: {{ index .Vars "worker_type" | default "m5.xlarge" }}`)
+		writeTestFile(t, filepath.Join(dir, "deployment.yaml.tmpl"), `deployment:
+  configuration:
+    name: {{ .Env.TEST_DEPLOYMENT_NAME }}
+  worker_queues:
+{{ include "queues.yaml" | indent 4 }}
+`)
+		formattedDeployment, _, err := readDeploymentFile(filepath.Join(dir, "deployment.yaml.tmpl"), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", formattedDeployment.Deployment.Configuration.Name)
+		assert.Equal(t, "m5.xlarge", formattedDeployment.Deployment.WorkerQs[0].WorkerType)
+	})
+}
+
+func TestRender(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "deployment.yaml.tmpl")
+	writeTestFile(t, file, "deployment:\n  configuration:\n    name: {{ .Vars.name }}\n    executor: CeleryExecutor\n")
+	out := new(bytes.Buffer)
+	err := Render(file, map[string]interface{}{"name": "rendered"}, out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "# Source: "+file)
+	assert.Contains(t, out.String(), "name: rendered")
+	assert.Contains(t, out.String(), "executor: CeleryExecutor")
+}
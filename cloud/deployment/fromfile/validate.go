@@ -0,0 +1,316 @@
+package fromfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/util"
+)
+
+var errInvalidDeploymentFiles = errors.New("problems found in deployment files")
+
+// problem is something wrong with a value of a deployment file
+type problem struct {
+	source  string
+	path    string
+	message string
+}
+
+func (p problem) String() string {
+	if p.path == "" {
+		return fmt.Sprintf("%s: %s", p.source, p.message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.source, p.path, p.message)
+}
+
+// onlineLookups are what deployment files are checked against when validating online
+type onlineLookups struct {
+	clusters            []astro.Cluster
+	workspaces          []astro.Workspace
+	runtimeReleases     []astro.RuntimeRelease
+	existingDeployments []astro.Deployment
+}
+
+// Validate checks the deployment files in inputPath and prints every problem it finds with the path of the value.
+// Structural checks against the deployment file schema need no connection. If online is true it also checks that
+// clusters, workspaces, worker types, runtime versions and dependencies exist in the current organization.
+// Deployment files are rendered with vars first. It returns an error if any problem is found.
+func Validate(inputPath string, vars map[string]interface{}, online bool, client astro.Client, out io.Writer) error {
+	files, err := deploymentFiles(inputPath)
+	if err != nil {
+		return err
+	}
+	var lookups *onlineLookups
+	if online {
+		lookups, err = getOnlineLookups(client)
+		if err != nil {
+			return err
+		}
+	}
+
+	var (
+		problems  []problem
+		documents []deploymentDocument
+	)
+	schema := inspect.DeploymentFileSchema()
+	for _, file := range files {
+		renderedDocuments, err := renderDocuments(file, vars, 0)
+		if err != nil {
+			problems = append(problems, problem{source: file, message: err.Error()})
+			continue
+		}
+		for index, renderedDocument := range renderedDocuments {
+			if renderedDocument == nil {
+				continue
+			}
+			source := file
+			if index > 0 {
+				source = fmt.Sprintf("%s#%d", file, index)
+			}
+			documentProblems := validateValue(schema, "", renderedDocument)
+			if len(documentProblems) > 0 {
+				for i := range documentProblems {
+					documentProblems[i].source = source
+				}
+				problems = append(problems, documentProblems...)
+				continue
+			}
+			var formattedDeployment inspect.FormattedDeployment
+			if err := decodeDocument(renderedDocument, &formattedDeployment); err != nil {
+				problems = append(problems, problem{source: source, message: err.Error()})
+				continue
+			}
+			documents = append(documents, deploymentDocument{source: source, deployment: formattedDeployment})
+		}
+	}
+	problems = append(problems, validateDocuments(inputPath, documents, lookups)...)
+
+	for _, p := range problems {
+		fmt.Fprintln(out, p.String())
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %d", errInvalidDeploymentFiles, len(problems))
+	}
+	fmt.Fprintf(out, "%s is valid\n", inputPath)
+	return nil
+}
+
+func getOnlineLookups(client astro.Client) (*onlineLookups, error) {
+	c, err := config.GetCurrentContext()
+	if err != nil {
+		return nil, err
+	}
+	var lookups onlineLookups
+	lookups.clusters, err = client.ListClusters(c.Organization)
+	if err != nil {
+		return nil, err
+	}
+	lookups.workspaces, err = client.ListWorkspaces(c.Organization)
+	if err != nil {
+		return nil, err
+	}
+	deploymentConfig, err := client.GetDeploymentConfig()
+	if err != nil {
+		return nil, err
+	}
+	lookups.runtimeReleases = deploymentConfig.RuntimeReleases
+	lookups.existingDeployments, err = client.ListDeployments(c.Organization, "")
+	if err != nil {
+		return nil, err
+	}
+	return &lookups, nil
+}
+
+// validateDocuments checks what the schema can not describe, such as the default queue, duplicate names and dependencies.
+// If lookups is not nil it also checks the values that refer to objects in the organization.
+func validateDocuments(inputPath string, documents []deploymentDocument, lookups *onlineLookups) []problem {
+	var problems []problem
+	declared := map[string]string{}
+	for i := range documents {
+		d := &documents[i].deployment.Deployment
+		source := documents[i].source
+		if previous, ok := declared[documents[i].name()]; ok {
+			problems = append(problems, problem{source, "deployment.configuration.name", fmt.Sprintf("%s %s in %s", documents[i].name(), errDuplicateName, previous)})
+		}
+		declared[documents[i].name()] = source
+		if len(d.WorkerQs) > 0 && d.WorkerQs[0].Name != defaultQueue {
+			problems = append(problems, problem{source, "deployment.worker_queues[0].name", fmt.Sprintf("must be %s, the default queue has to come first", defaultQueue)})
+		}
+		if lookups != nil {
+			problems = append(problems, lookups.validate(&documents[i])...)
+		}
+	}
+
+	// dependencies are only known to exist online, offline they are assumed to exist if no file declares them
+	var existingDeployments []astro.Deployment
+	if lookups != nil {
+		existingDeployments = lookups.existingDeployments
+	} else {
+		for i := range documents {
+			for _, dependency := range documents[i].deployment.Deployment.DependsOn {
+				existingDeployments = append(existingDeployments, astro.Deployment{Label: dependency})
+			}
+		}
+	}
+	if _, err := dependencyLevels(documents, existingDeployments); err != nil {
+		problems = append(problems, problem{source: inputPath, message: err.Error()})
+	}
+	return problems
+}
+
+// validate checks that the cluster, workspace, worker types and runtime version of document exist
+func (l *onlineLookups) validate(document *deploymentDocument) []problem {
+	var problems []problem
+	d := &document.deployment.Deployment
+	notFound := func(path, value string) {
+		problems = append(problems, problem{document.source, path, fmt.Sprintf("%s %s", value, errNotFound)})
+	}
+
+	var cluster *astro.Cluster
+	for i := range l.clusters {
+		if l.clusters[i].Name == d.Configuration.ClusterName {
+			cluster = &l.clusters[i]
+		}
+	}
+	if cluster == nil {
+		notFound("deployment.configuration.cluster_name", d.Configuration.ClusterName)
+	} else {
+		for i, queue := range d.WorkerQs {
+			if _, err := getNodePoolIDFromWorkerType(queue.WorkerType, cluster.Name, cluster.NodePools); err != nil {
+				notFound(fmt.Sprintf("deployment.worker_queues[%d].worker_type", i), queue.WorkerType)
+			}
+		}
+	}
+	if d.Configuration.WorkspaceName != "" && !workspaceExists(l.workspaces, d.Configuration.WorkspaceName) {
+		notFound("deployment.configuration.workspace_name", d.Configuration.WorkspaceName)
+	}
+	if d.Configuration.RunTimeVersion != "" && !runtimeReleaseExists(l.runtimeReleases, d.Configuration.RunTimeVersion) {
+		notFound("deployment.configuration.runtime_version", d.Configuration.RunTimeVersion)
+	}
+	// values of environment variables are required to create a deployment
+	if !deploymentExists(l.existingDeployments, document.name()) {
+		for i, envVar := range d.EnvVars {
+			if envVar.Value == "" {
+				problems = append(problems, problem{document.source, fmt.Sprintf("deployment.environment_variables[%d].value", i), errRequiredField.Error()})
+			}
+		}
+	}
+	return problems
+}
+
+func workspaceExists(workspaces []astro.Workspace, workspaceName string) bool {
+	for i := range workspaces {
+		if workspaces[i].Label == workspaceName {
+			return true
+		}
+	}
+	return false
+}
+
+func runtimeReleaseExists(runtimeReleases []astro.RuntimeRelease, version string) bool {
+	for i := range runtimeReleases {
+		if runtimeReleases[i].Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// validateValue returns every problem of value against schema, path is the YAML path of value
+func validateValue(schema *inspect.Schema, path string, value interface{}) []problem {
+	valueType := jsonType(value)
+	if !schema.Type.Allows(valueType) {
+		return []problem{{path: path, message: fmt.Sprintf("must be %s not %s", strings.Join(schema.Type, " or "), valueType)}}
+	}
+
+	var problems []problem
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, problem{path: joinPath(path, name), message: errRequiredField.Error()})
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propertySchema, ok := schema.Properties[key]
+			if !ok {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					problems = append(problems, problem{path: joinPath(path, key), message: "is not a known field"})
+				}
+				continue
+			}
+			problems = append(problems, validateValue(propertySchema, joinPath(path, key), v[key])...)
+		}
+	case []interface{}:
+		for i, item := range v {
+			problems = append(problems, validateValue(schema.Items, fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+	case string:
+		if schema.MinLength != nil && len(v) < *schema.MinLength {
+			problems = append(problems, problem{path: path, message: errRequiredField.Error()})
+		}
+		if len(schema.Enum) > 0 && !util.Contains(schema.Enum, v) {
+			problems = append(problems, problem{path: path, message: fmt.Sprintf("%s %s, it can be %s", v, errInvalidValue, strings.Join(schema.Enum, " or "))})
+		}
+		if v != "" && !validFormat(schema.Format, v) {
+			problems = append(problems, problem{path: path, message: fmt.Sprintf("%s is not a valid %s", v, schema.Format)})
+		}
+	case float64:
+		if schema.Minimum != nil && v < float64(*schema.Minimum) {
+			problems = append(problems, problem{path: path, message: fmt.Sprintf("must be at least %d", *schema.Minimum)})
+		}
+	}
+	return problems
+}
+
+// jsonType returns the JSON Schema type of a value decoded from JSON
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func validFormat(format, value string) bool {
+	switch format {
+	case "email":
+		return isValidEmail(value)
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	}
+	return true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
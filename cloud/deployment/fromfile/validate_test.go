@@ -0,0 +1,130 @@
+package fromfile
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestValidate(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	var (
+		orgID   = "test-org-id"
+		cluster = astro.Cluster{ID: "test-cluster-id", Name: "test-cluster", NodePools: []astro.NodePool{{ID: "test-pool-id", NodeInstanceType: "test-worker-1"}}}
+	)
+
+	t.Run("reports nothing for a valid deployment file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, applyTestFile)
+		out := new(bytes.Buffer)
+		err := Validate(file, nil, false, nil, out)
+		assert.NoError(t, err)
+		assert.Equal(t, file+" is valid\n", out.String())
+	})
+	t.Run("reports every structural problem with its path", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, `deployment:
+  configuration:
+    name: ""
+    executor: SequentialExecutor
+    scheduler_au: -1
+    scheduler_count: three
+  worker_queues:
+    - name: default
+    - name: other
+      worker_type: test-worker-1
+      max_workers: 10
+  alert_emails:
+    - not-an-email
+`)
+		out := new(bytes.Buffer)
+		err := Validate(file, nil, false, nil, out)
+		assert.ErrorIs(t, err, errInvalidDeploymentFiles)
+		assert.EqualError(t, err, "problems found in deployment files: 8")
+		assert.Contains(t, out.String(), file+": deployment.configuration.cluster_name: missing required field\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.name: missing required field\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.executor: SequentialExecutor is not valid, it can be CeleryExecutor or KubernetesExecutor\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.scheduler_au: must be at least 0\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.scheduler_count: must be integer not string\n")
+		assert.Contains(t, out.String(), file+": deployment.worker_queues[0].worker_type: missing required field\n")
+		assert.Contains(t, out.String(), file+": deployment.worker_queues[1].max_workers: is not a known field\n")
+		assert.Contains(t, out.String(), file+": deployment.alert_emails[0]: not-an-email is not a valid email\n")
+	})
+	t.Run("reports problems across the documents of a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "a.yaml"), applyTestFile)
+		writeTestFile(t, filepath.Join(dir, "b.yaml"), `deployment:
+  configuration:
+    name: test-deployment-label
+    executor: CeleryExecutor
+    cluster_name: test-cluster
+  worker_queues:
+    - name: other
+      worker_type: test-worker-1
+---
+deployment:
+  configuration:
+    name: broken
+    executor: CeleryExecutor
+    cluster_name: test-cluster
+  depends_on:
+    - broken
+`)
+		out := new(bytes.Buffer)
+		err := Validate(dir, nil, false, nil, out)
+		assert.EqualError(t, err, "problems found in deployment files: 3")
+		b := filepath.Join(dir, "b.yaml")
+		assert.Contains(t, out.String(), b+": deployment.configuration.name: test-deployment-label is declared more than once in "+filepath.Join(dir, "a.yaml"))
+		assert.Contains(t, out.String(), b+": deployment.worker_queues[0].name: must be default, the default queue has to come first")
+		assert.Contains(t, out.String(), dir+": deployments depend on each other: broken")
+	})
+	t.Run("reports values that do not exist in the organization online", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, `deployment:
+  environment_variables:
+    - key: foo
+  configuration:
+    name: new-deployment
+    runtime_version: 1.0.0
+    executor: CeleryExecutor
+    cluster_name: test-cluster
+    workspace_name: missing-workspace
+  worker_queues:
+    - name: default
+      worker_type: test-worker-2
+  depends_on:
+    - missing-deployment
+`)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListClusters", orgID).Return([]astro.Cluster{cluster}, nil).Once()
+		mockClient.On("ListWorkspaces", orgID).Return([]astro.Workspace{{ID: "test-ws-id", Label: "test-workspace"}}, nil).Once()
+		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{RuntimeReleases: []astro.RuntimeRelease{{Version: "6.0.0"}}}, nil).Once()
+		mockClient.On("ListDeployments", orgID, "").Return([]astro.Deployment{}, nil).Once()
+		out := new(bytes.Buffer)
+		err := Validate(file, nil, true, mockClient, out)
+		assert.EqualError(t, err, "problems found in deployment files: 5")
+		assert.Contains(t, out.String(), file+": deployment.worker_queues[0].worker_type: test-worker-2 does not exist\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.workspace_name: missing-workspace does not exist\n")
+		assert.Contains(t, out.String(), file+": deployment.configuration.runtime_version: 1.0.0 does not exist\n")
+		assert.Contains(t, out.String(), file+": deployment.environment_variables[0].value: missing required field\n")
+		assert.Contains(t, out.String(), "depends_on: missing-deployment does not exist")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if the organization can not be looked up", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "deployment.yaml")
+		writeTestFile(t, file, applyTestFile)
+		errTest := errors.New("test error")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListClusters", mock.Anything).Return(nil, errTest).Once()
+		err := Validate(file, nil, true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errTest)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,142 @@
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/config"
+)
+
+const (
+	// exportFilePerm is the mode of exported deployment files, which can hold secret placeholders but no secrets
+	exportFilePerm = 0o644
+	// exportFileExtension marks exported files as templates, so their secret placeholders are rendered when applied
+	exportFileExtension = ".yaml.tmpl"
+)
+
+var (
+	errNoDeploymentsToExport = errors.New("no deployments found to export")
+	slugInvalidCharacters    = regexp.MustCompile(`[^a-z0-9]+`)
+	envVarInvalidCharacters  = regexp.MustCompile(`[^A-Z0-9]+`)
+	// templateEscaper keeps values that look like template actions as they are when the exported file is rendered
+	templateEscaper = strings.NewReplacer("{{", `{{"{{"}}`)
+)
+
+// Export writes a deployment file for each requested deployment to outputDir, named by the slug of the deployment's name.
+// If all is true it exports every deployment in wsID or in the organization if wsID is empty.
+// The files include worker queues, non-secret environment variables and alert emails. Secret values can not be read
+// back from Astro so they are replaced with placeholders read from environment variables when the files are applied.
+func Export(wsID, deploymentName, deploymentID, outputDir string, all bool, client astro.Client, out io.Writer) error {
+	var deployments []astro.Deployment
+	if all {
+		c, err := config.GetCurrentContext()
+		if err != nil {
+			return err
+		}
+		deployments, err = client.ListDeployments(c.Organization, wsID)
+		if err != nil {
+			return err
+		}
+	} else {
+		requestedDeployment, err := deployment.GetDeployment(wsID, deploymentID, deploymentName, client, nil)
+		if err != nil {
+			return err
+		}
+		deployments = []astro.Deployment{requestedDeployment}
+	}
+	if len(deployments) == 0 {
+		return errNoDeploymentsToExport
+	}
+
+	err := os.MkdirAll(outputDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	written := map[string]bool{}
+	for i := range deployments {
+		formattedDeployment, err := FormatDeployment(&deployments[i])
+		if err != nil {
+			return err
+		}
+		exported, secretVars := getExportTemplate(&formattedDeployment)
+		data, err := yamlMarshal(exported)
+		if err != nil {
+			return err
+		}
+		file := exportFileName(outputDir, deployments[i].Label, written)
+		err = os.WriteFile(file, data, exportFilePerm)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Exported deployment %s to %s\n", deployments[i].Label, file)
+		for _, key := range sortedKeys(secretVars) {
+			fmt.Fprintf(out, "  secret environment variable %s is read from $%s when the file is applied\n", key, secretVars[key])
+		}
+	}
+	fmt.Fprintf(out, "%d deployments exported to %s\n", len(deployments), outputDir)
+	return nil
+}
+
+// getExportTemplate returns formattedDeployment as a deployment file template that can be applied to recreate it.
+// It has no metadata and no updatedAt timestamp for environment_variables. Values of secret environment variables are
+// placeholders for environment variables, it returns the name of the environment variable of each secret key.
+// Template delimiters in other values are escaped so they are applied as they are.
+func getExportTemplate(formattedDeployment *FormattedDeployment) (FormattedDeployment, map[string]string) {
+	template := *formattedDeployment
+	template.Deployment.Metadata = nil
+	secretVars := map[string]string{}
+	envVars := make([]EnvironmentVariable, 0, len(template.Deployment.EnvVars))
+	for _, envVar := range template.Deployment.EnvVars {
+		envVar.UpdatedAt = ""
+		if envVar.IsSecret {
+			name := secretEnvVarName(template.Deployment.Configuration.Name, envVar.Key)
+			envVar.Value = fmt.Sprintf("{{ .Env.%s }}", name)
+			secretVars[envVar.Key] = name
+		} else {
+			envVar.Value = templateEscaper.Replace(envVar.Value)
+		}
+		envVar.Key = templateEscaper.Replace(envVar.Key)
+		envVars = append(envVars, envVar)
+	}
+	template.Deployment.EnvVars = envVars
+	template.Deployment.Configuration.Name = templateEscaper.Replace(template.Deployment.Configuration.Name)
+	template.Deployment.Configuration.Description = templateEscaper.Replace(template.Deployment.Configuration.Description)
+	return template, secretVars
+}
+
+// exportFileName returns a file in outputDir named by the slug of deploymentName that has not been written yet
+func exportFileName(outputDir, deploymentName string, written map[string]bool) string {
+	slug := strings.Trim(slugInvalidCharacters.ReplaceAllString(strings.ToLower(deploymentName), "-"), "-")
+	if slug == "" {
+		slug = "deployment"
+	}
+	name := slug
+	for i := 2; written[name]; i++ {
+		name = fmt.Sprintf("%s-%d", slug, i)
+	}
+	written[name] = true
+	return filepath.Join(outputDir, name+exportFileExtension)
+}
+
+// secretEnvVarName returns the environment variable a secret value of a deployment is read from
+func secretEnvVarName(deploymentName, key string) string {
+	name := strings.ToUpper(deploymentName + "_" + key)
+	return strings.Trim(envVarInvalidCharacters.ReplaceAllString(name, "_"), "_")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package inspect
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExport(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	newDeployment := func(id, label string) astro.Deployment {
+		return astro.Deployment{
+			ID:        id,
+			Label:     label,
+			Workspace: astro.Workspace{ID: "test-ws-id", Label: "test-ws"},
+			Cluster: astro.Cluster{
+				ID:        "cluster-id",
+				Name:      "test-cluster",
+				NodePools: []astro.NodePool{{ID: "test-pool-id", NodeInstanceType: "test-instance-type"}},
+			},
+			RuntimeRelease: astro.RuntimeRelease{Version: "6.0.0"},
+			DeploymentSpec: astro.DeploymentSpec{
+				Executor:  "CeleryExecutor",
+				Scheduler: astro.Scheduler{AU: 5, Replicas: 3},
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+					{Key: "foo", Value: "bar", UpdatedAt: "NOW"},
+					{Key: "jinja", Value: "{{ ds }}"},
+					{Key: "api-token", IsSecret: true},
+				},
+			},
+			WorkerQueues: []astro.WorkerQueue{{Name: "default", IsDefault: true, MaxWorkerCount: 10, NodePoolID: "test-pool-id"}},
+			AlertEmails:  []string{"test@test.com"},
+		}
+	}
+	readExported := func(t *testing.T, file string) FormattedDeployment {
+		t.Helper()
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		var exported FormattedDeployment
+		assert.NoError(t, yaml.Unmarshal(data, &exported))
+		return exported
+	}
+
+	t.Run("exports every deployment of the organization named by slug", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "deployments")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "").Return([]astro.Deployment{
+			newDeployment("test-id-1", "Prod ETL"),
+			newDeployment("test-id-2", "prod etl"),
+		}, nil).Once()
+		out := new(bytes.Buffer)
+		err := Export("", "", "", dir, true, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Exported deployment Prod ETL to "+filepath.Join(dir, "prod-etl.yaml.tmpl"))
+		assert.Contains(t, out.String(), "Exported deployment prod etl to "+filepath.Join(dir, "prod-etl-2.yaml.tmpl"))
+		assert.Contains(t, out.String(), "secret environment variable api-token is read from $PROD_ETL_API_TOKEN when the file is applied")
+		assert.Contains(t, out.String(), "2 deployments exported to "+dir)
+
+		exported := readExported(t, filepath.Join(dir, "prod-etl.yaml.tmpl"))
+		assert.Equal(t, "Prod ETL", exported.Deployment.Configuration.Name)
+		assert.Nil(t, exported.Deployment.Metadata)
+		assert.Equal(t, "test-instance-type", exported.Deployment.WorkerQs[0].WorkerType)
+		assert.Equal(t, []string{"test@test.com"}, exported.Deployment.AlertEmails)
+		assert.Equal(t, []EnvironmentVariable{
+			{Key: "foo", Value: "bar"},
+			{Key: "jinja", Value: `{{"{{"}} ds }}`},
+			{Key: "api-token", Value: "{{ .Env.PROD_ETL_API_TOKEN }}", IsSecret: true},
+		}, exported.Deployment.EnvVars)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("exports every deployment of a workspace", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "test-ws-id").Return([]astro.Deployment{newDeployment("test-id-1", "test")}, nil).Once()
+		err := Export("test-ws-id", "", "", t.TempDir(), true, mockClient, new(bytes.Buffer))
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("exports the requested deployment", func(t *testing.T) {
+		dir := t.TempDir()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, "test-ws-id").Return([]astro.Deployment{newDeployment("test-id-1", "first"), newDeployment("test-id-2", "second")}, nil).Once()
+		err := Export("test-ws-id", "", "test-id-2", dir, false, mockClient, new(bytes.Buffer))
+		assert.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "second.yaml.tmpl"))
+		assert.NoFileExists(t, filepath.Join(dir, "first.yaml.tmpl"))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("returns an error if there are no deployments", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", "test-org-id", "").Return([]astro.Deployment{}, nil).Once()
+		err := Export("", "", "", t.TempDir(), true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoDeploymentsToExport)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -31,44 +31,46 @@ type deploymentMetadata struct {
 }
 
 type deploymentConfig struct {
-	Name             string `mapstructure:"name" yaml:"name" json:"name"`
+	Name             string `mapstructure:"name" yaml:"name" json:"name" jsonschema:"required"`
 	Description      string `mapstructure:"description" yaml:"description" json:"description"`
 	RunTimeVersion   string `mapstructure:"runtime_version" yaml:"runtime_version" json:"runtime_version"`
 	DagDeployEnabled bool   `mapstructure:"dag_deploy_enabled" yaml:"dag_deploy_enabled" json:"dag_deploy_enabled"`
-	Executor         string `mapstructure:"executor" yaml:"executor" json:"executor"`
-	SchedulerAU      int    `mapstructure:"scheduler_au" yaml:"scheduler_au" json:"scheduler_au"`
-	SchedulerCount   int    `mapstructure:"scheduler_count" yaml:"scheduler_count" json:"scheduler_count"`
-	ClusterName      string `mapstructure:"cluster_name" yaml:"cluster_name" json:"cluster_name"`
+	Executor         string `mapstructure:"executor" yaml:"executor" json:"executor" jsonschema:"required,enum=CeleryExecutor|KubernetesExecutor"`
+	SchedulerAU      int    `mapstructure:"scheduler_au" yaml:"scheduler_au" json:"scheduler_au" jsonschema:"minimum=0"`
+	SchedulerCount   int    `mapstructure:"scheduler_count" yaml:"scheduler_count" json:"scheduler_count" jsonschema:"minimum=0"`
+	ClusterName      string `mapstructure:"cluster_name" yaml:"cluster_name" json:"cluster_name" jsonschema:"required"`
 	WorkspaceName    string `mapstructure:"workspace_name" yaml:"workspace_name" json:"workspace_name"`
 }
 
 type Workerq struct {
-	Name              string `mapstructure:"name" yaml:"name" json:"name"`
-	MaxWorkerCount    int    `mapstructure:"max_worker_count,omitempty" yaml:"max_worker_count,omitempty" json:"max_worker_count,omitempty"`
-	MinWorkerCount    *int   `mapstructure:"min_worker_count,omitempty" yaml:"min_worker_count,omitempty" json:"min_worker_count,omitempty"`
-	WorkerConcurrency int    `mapstructure:"worker_concurrency,omitempty" yaml:"worker_concurrency,omitempty" json:"worker_concurrency,omitempty"`
-	WorkerType        string `mapstructure:"worker_type" yaml:"worker_type" json:"worker_type"`
+	Name              string `mapstructure:"name" yaml:"name" json:"name" jsonschema:"required"`
+	MaxWorkerCount    int    `mapstructure:"max_worker_count,omitempty" yaml:"max_worker_count,omitempty" json:"max_worker_count,omitempty" jsonschema:"minimum=0"`
+	MinWorkerCount    *int   `mapstructure:"min_worker_count,omitempty" yaml:"min_worker_count,omitempty" json:"min_worker_count,omitempty" jsonschema:"minimum=0"`
+	WorkerConcurrency int    `mapstructure:"worker_concurrency,omitempty" yaml:"worker_concurrency,omitempty" json:"worker_concurrency,omitempty" jsonschema:"minimum=0"`
+	WorkerType        string `mapstructure:"worker_type" yaml:"worker_type" json:"worker_type" jsonschema:"required"`
 	PodCPU            string `mapstructure:"pod_cpu,omitempty" yaml:"pod_cpu,omitempty" json:"pod_cpu,omitempty"`
 	PodRAM            string `mapstructure:"pod_ram,omitempty" yaml:"pod_ram,omitempty" json:"pod_ram,omitempty"`
 }
 
 type EnvironmentVariable struct {
 	IsSecret  bool   `mapstructure:"is_secret" yaml:"is_secret" json:"is_secret"`
-	Key       string `mapstructure:"key" yaml:"key" json:"key"`
+	Key       string `mapstructure:"key" yaml:"key" json:"key" jsonschema:"required"`
 	UpdatedAt string `mapstructure:"updated_at,omitempty" yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
 	Value     string `mapstructure:"value" yaml:"value" json:"value"`
 }
 
 type orderedPieces struct {
 	EnvVars       []EnvironmentVariable `mapstructure:"environment_variables,omitempty" yaml:"environment_variables,omitempty" json:"environment_variables,omitempty"`
-	Configuration deploymentConfig      `mapstructure:"configuration" yaml:"configuration" json:"configuration"`
+	Configuration deploymentConfig      `mapstructure:"configuration" yaml:"configuration" json:"configuration" jsonschema:"required"`
 	WorkerQs      []Workerq             `mapstructure:"worker_queues" yaml:"worker_queues" json:"worker_queues"`
 	Metadata      *deploymentMetadata   `mapstructure:"metadata,omitempty" yaml:"metadata,omitempty" json:"metadata,omitempty"`
-	AlertEmails   []string              `mapstructure:"alert_emails,omitempty" yaml:"alert_emails,omitempty" json:"alert_emails,omitempty"`
+	AlertEmails   []string              `mapstructure:"alert_emails,omitempty" yaml:"alert_emails,omitempty" json:"alert_emails,omitempty" jsonschema:"format=email"`
+	// DependsOn names the deployments that have to be applied before this one when applying several deployment files
+	DependsOn []string `mapstructure:"depends_on,omitempty" yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
 }
 
 type FormattedDeployment struct {
-	Deployment orderedPieces `mapstructure:"deployment" yaml:"deployment" json:"deployment"`
+	Deployment orderedPieces `mapstructure:"deployment" yaml:"deployment" json:"deployment" jsonschema:"required"`
 }
 
 var (
@@ -125,6 +127,23 @@ func Inspect(wsID, deploymentName, deploymentID, outputFormat string, client ast
 	return nil
 }
 
+// FormatDeployment returns sourceDeployment in the same shape it is inspected in and described by deployment files.
+// It returns an error if getting the deployment's information fails.
+func FormatDeployment(sourceDeployment *astro.Deployment) (FormattedDeployment, error) {
+	var formattedDeployment FormattedDeployment
+
+	deploymentInfoMap, err := getDeploymentInfo(sourceDeployment)
+	if err != nil {
+		return FormattedDeployment{}, err
+	}
+	printableDeployment := getPrintableDeployment(deploymentInfoMap, getDeploymentConfig(sourceDeployment), getAdditional(sourceDeployment))
+	err = decodeToStruct(printableDeployment, &formattedDeployment)
+	if err != nil {
+		return FormattedDeployment{}, err
+	}
+	return formattedDeployment, nil
+}
+
 func getDeploymentInfo(sourceDeployment *astro.Deployment) (map[string]interface{}, error) {
 	var (
 		deploymentURL string
@@ -239,7 +258,7 @@ func formatPrintableDeployment(outputFormat string, template bool, printableDepl
 		return []byte{}, err
 	}
 	if template {
-		formatWithOrder = getTemplate(&formatWithOrder)
+		formatWithOrder = GetTemplate(&formatWithOrder)
 	}
 	switch outputFormat {
 	case jsonFormat:
@@ -306,10 +325,10 @@ func getWorkerTypeFromNodePoolID(poolID string, nodePools []astro.NodePool) stri
 	return ""
 }
 
-// getTemplate returns a Formatted Deployment that can be used as a template.
+// GetTemplate returns a Formatted Deployment that can be used as a template.
 // It has no metadata, no name and no updatedAt timestamp for environment_variables.
 // The output templates can be modified and used to create deployments.
-func getTemplate(formattedDeployment *FormattedDeployment) FormattedDeployment {
+func GetTemplate(formattedDeployment *FormattedDeployment) FormattedDeployment {
 	template := *formattedDeployment
 	template.Deployment.Configuration.Name = ""
 	template.Deployment.Metadata = nil
@@ -1468,7 +1468,7 @@ func TestGetTemplate(t *testing.T) {
 			expected.Deployment.EnvVars[i].UpdatedAt = "NOW"
 		}
 
-		actual := getTemplate(&decoded)
+		actual := GetTemplate(&decoded)
 		assert.Equal(t, expected, actual)
 	})
 	t.Run("returns a template without env vars if they are empty", func(t *testing.T) {
@@ -1502,7 +1502,7 @@ func TestGetTemplate(t *testing.T) {
 			expected.Deployment.EnvVars[i].UpdatedAt = "NOW"
 		}
 		expected.Deployment.EnvVars = newEnvVars
-		actual := getTemplate(&decoded)
+		actual := GetTemplate(&decoded)
 		assert.Equal(t, expected, actual)
 	})
 	t.Run("returns a template without alert emails if they are empty", func(t *testing.T) {
@@ -1532,7 +1532,65 @@ func TestGetTemplate(t *testing.T) {
 			expected.Deployment.EnvVars[i].UpdatedAt = ""
 		}
 		expected.Deployment.EnvVars = newEnvVars
-		actual := getTemplate(&decoded)
+		actual := GetTemplate(&decoded)
 		assert.Equal(t, expected, actual)
 	})
 }
+
+func TestFormatDeployment(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	sourceDeployment := astro.Deployment{
+		ID:          "test-deployment-id",
+		Label:       "test-deployment-label",
+		Description: "description",
+		Workspace:   astro.Workspace{ID: "test-ws-id", Label: "test-ws"},
+		Cluster: astro.Cluster{
+			ID:   "cluster-id",
+			Name: "test-cluster",
+			NodePools: []astro.NodePool{
+				{
+					ID:               "test-pool-id",
+					NodeInstanceType: "test-instance-type",
+				},
+			},
+		},
+		RuntimeRelease: astro.RuntimeRelease{Version: "6.0.0", AirflowVersion: "2.4.0"},
+		DeploymentSpec: astro.DeploymentSpec{
+			Executor:  "CeleryExecutor",
+			Scheduler: astro.Scheduler{AU: 5, Replicas: 3},
+			EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{
+				{Key: "foo", Value: "bar"},
+			},
+		},
+		WorkerQueues: []astro.WorkerQueue{
+			{
+				Name:              "default",
+				IsDefault:         true,
+				MaxWorkerCount:    130,
+				MinWorkerCount:    12,
+				WorkerConcurrency: 110,
+				NodePoolID:        "test-pool-id",
+			},
+		},
+		AlertEmails: []string{"test@test.com"},
+	}
+	t.Run("returns the deployment as it is inspected", func(t *testing.T) {
+		formattedDeployment, err := FormatDeployment(&sourceDeployment)
+		assert.NoError(t, err)
+		assert.Equal(t, "test-deployment-label", formattedDeployment.Deployment.Configuration.Name)
+		assert.Equal(t, "test-cluster", formattedDeployment.Deployment.Configuration.ClusterName)
+		assert.Equal(t, 5, formattedDeployment.Deployment.Configuration.SchedulerAU)
+		assert.Equal(t, "test-instance-type", formattedDeployment.Deployment.WorkerQs[0].WorkerType)
+		assert.Equal(t, 12, *formattedDeployment.Deployment.WorkerQs[0].MinWorkerCount)
+		assert.Equal(t, "bar", formattedDeployment.Deployment.EnvVars[0].Value)
+		assert.Equal(t, []string{"test@test.com"}, formattedDeployment.Deployment.AlertEmails)
+		assert.Equal(t, "test-deployment-id", *formattedDeployment.Deployment.Metadata.DeploymentID)
+	})
+	t.Run("returns an error if decoding fails", func(t *testing.T) {
+		originalDecode := decodeToStruct
+		decodeToStruct = errorReturningDecode
+		defer restoreDecode(originalDecode)
+		_, err := FormatDeployment(&sourceDeployment)
+		assert.ErrorIs(t, err, errMarshal)
+	})
+}
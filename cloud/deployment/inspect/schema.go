@@ -0,0 +1,152 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	schemaID    = "https://www.astronomer.io/schemas/deployment-file.json"
+	schemaTag   = "jsonschema"
+)
+
+// Schema is the JSON Schema of a value in a deployment file
+type Schema struct {
+	Draft                string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 SchemaTypes        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// SchemaTypes are the JSON types a value can have, it is marshalled as a string when there is only one
+type SchemaTypes []string
+
+func (t SchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Allows returns true if valueType is one of t
+func (t SchemaTypes) Allows(valueType string) bool {
+	for _, allowed := range t {
+		// every integer is also a number
+		if allowed == valueType || (allowed == "number" && valueType == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// DeploymentFileSchema returns the JSON Schema of deployment files generated from FormattedDeployment.
+// Constraints are read from the jsonschema struct tag: required, format=<format>, enum=<a|b> and minimum=<n>.
+// Required strings have to be set to a non empty value.
+func DeploymentFileSchema() *Schema {
+	schema := typeSchema(reflect.TypeOf(FormattedDeployment{}), "")
+	schema.Draft = schemaDraft
+	schema.ID = schemaID
+	schema.Title = "Astro deployment file"
+	return schema
+}
+
+func typeSchema(t reflect.Type, tag string) *Schema {
+	schema := &Schema{}
+	nullable := t.Kind() == reflect.Pointer
+	if nullable {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		schema.Type = SchemaTypes{"string"}
+		schema.Format = "date-time"
+	case t.Kind() == reflect.Struct:
+		schema.Type = SchemaTypes{"object"}
+		schema.Properties = map[string]*Schema{}
+		additionalProperties := false
+		schema.AdditionalProperties = &additionalProperties
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fieldTag := field.Tag.Get(schemaTag)
+			schema.Properties[name] = typeSchema(field.Type, fieldTag)
+			if hasTagOption(fieldTag, "required") {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	case t.Kind() == reflect.Slice:
+		schema.Type = SchemaTypes{"array"}
+		// constraints of a list apply to its items
+		schema.Items = typeSchema(t.Elem(), tag)
+		return schema
+	case t.Kind() == reflect.Bool:
+		schema.Type = SchemaTypes{"boolean"}
+	case t.Kind() == reflect.Int:
+		schema.Type = SchemaTypes{"integer"}
+	default:
+		schema.Type = SchemaTypes{"string"}
+	}
+	if nullable {
+		schema.Type = append(schema.Type, "null")
+	}
+	applyTagOptions(schema, tag)
+	return schema
+}
+
+func applyTagOptions(schema *Schema, tag string) {
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			// required strings can not be empty either
+			if schema.Type.Allows("string") {
+				minLength := 1
+				schema.MinLength = &minLength
+			}
+		case "format":
+			schema.Format = value
+		case "enum":
+			schema.Enum = strings.Split(value, "|")
+		case "minimum":
+			if minimum, err := strconv.Atoi(value); err == nil {
+				schema.Minimum = &minimum
+			}
+		}
+	}
+}
+
+func hasTagOption(tag, option string) bool {
+	for _, o := range strings.Split(tag, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintDeploymentFileSchema prints the JSON Schema of deployment files to out
+func PrintDeploymentFileSchema(out io.Writer) error {
+	schema, err := jsonMarshal(DeploymentFileSchema(), "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(schema))
+	return nil
+}
@@ -0,0 +1,49 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentFileSchema(t *testing.T) {
+	schema := DeploymentFileSchema()
+	assert.Equal(t, SchemaTypes{"object"}, schema.Type)
+	assert.Equal(t, []string{"deployment"}, schema.Required)
+
+	deploymentSchema := schema.Properties["deployment"]
+	assert.False(t, *deploymentSchema.AdditionalProperties)
+	assert.Equal(t, []string{"configuration"}, deploymentSchema.Required)
+
+	configuration := deploymentSchema.Properties["configuration"]
+	assert.Equal(t, []string{"name", "executor", "cluster_name"}, configuration.Required)
+	assert.Equal(t, []string{"CeleryExecutor", "KubernetesExecutor"}, configuration.Properties["executor"].Enum)
+	assert.Equal(t, 1, *configuration.Properties["name"].MinLength)
+	assert.Equal(t, SchemaTypes{"integer"}, configuration.Properties["scheduler_au"].Type)
+	assert.Equal(t, 0, *configuration.Properties["scheduler_au"].Minimum)
+	assert.Equal(t, SchemaTypes{"boolean"}, configuration.Properties["dag_deploy_enabled"].Type)
+
+	queues := deploymentSchema.Properties["worker_queues"]
+	assert.Equal(t, SchemaTypes{"array"}, queues.Type)
+	assert.Equal(t, []string{"name", "worker_type"}, queues.Items.Required)
+	assert.Equal(t, SchemaTypes{"integer", "null"}, queues.Items.Properties["min_worker_count"].Type)
+
+	assert.Equal(t, "email", deploymentSchema.Properties["alert_emails"].Items.Format)
+	metadata := deploymentSchema.Properties["metadata"]
+	assert.Equal(t, SchemaTypes{"object", "null"}, metadata.Type)
+	assert.Equal(t, "date-time", metadata.Properties["created_at"].Format)
+}
+
+func TestPrintDeploymentFileSchema(t *testing.T) {
+	out := new(bytes.Buffer)
+	err := PrintDeploymentFileSchema(out)
+	assert.NoError(t, err)
+
+	var schema map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+	assert.Equal(t, schemaDraft, schema["$schema"])
+	assert.Equal(t, "object", schema["type"])
+	assert.Contains(t, out.String(), `"type": [`)
+}
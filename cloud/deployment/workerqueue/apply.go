@@ -0,0 +1,283 @@
+package workerqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/input"
+)
+
+var (
+	errNoQueuesInFile      = errors.New("no worker queues in")
+	errDuplicateQueue      = errors.New("is listed more than once")
+	errMissingDefaultQueue = errors.New("the default queue must be in the file, it can not be deleted")
+	errMissingWorkerType   = errors.New("has no worker_type")
+	errPodResources        = errors.New("KubernetesExecutor calculates pod_cpu and pod_ram from the worker type, change worker_type instead")
+)
+
+// queueChange is a worker queue that is created, updated or deleted by Apply
+type queueChange struct {
+	name string
+	// changes are the properties that are different in the file, like max_worker_count: 10 -> 20
+	changes []string
+}
+
+// queuesDiff is how the worker queues of a deployment differ from a queues file
+type queuesDiff struct {
+	added, changed, removed []queueChange
+}
+
+func (d *queuesDiff) hasChanges() bool {
+	return len(d.added) > 0 || len(d.changed) > 0 || len(d.removed) > 0
+}
+
+// Apply creates, updates and deletes the worker queues of a deployment to match the queues in inputFile.
+// The queues are validated against the deployment's executor before anything is changed. Apply prints how the
+// queues change and asks for confirmation unless force is true.
+func Apply(ws, deploymentID, deploymentName, inputFile string, force bool, client astro.Client, out io.Writer) error {
+	file, err := readQueuesFile(inputFile)
+	if err != nil {
+		return err
+	}
+	requestedDeployment, err := deployment.GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	executor := requestedDeployment.DeploymentSpec.Executor
+	// the pod resources of the live queues are compared to the file, sanitizing the queues removes them
+	liveQueues := append([]astro.WorkerQueue(nil), requestedDeployment.WorkerQueues...)
+	existingQueues := sanitizeExistingQueues(requestedDeployment.WorkerQueues, executor)
+
+	requestedQueues, err := getRequestedQueues(file, &requestedDeployment, liveQueues, client)
+	if err != nil {
+		return err
+	}
+
+	diff := diffQueues(existingQueues, requestedQueues, requestedDeployment.Cluster.NodePools)
+	if !diff.hasChanges() {
+		fmt.Fprintf(out, "Worker queues of %s are up to date with %s\n", ansi.Bold(requestedDeployment.Label), inputFile)
+		return nil
+	}
+	printQueuesDiff(&diff, requestedDeployment.Label, inputFile, out)
+	if !force {
+		i, err := input.Confirm(
+			"\nAre you sure you want to apply these changes? If there are any tasks in your DAGs assigned to an updated or deleted worker queue, the tasks might get stuck in a queued state and fail to execute")
+		if err != nil {
+			return err
+		}
+		if !i {
+			fmt.Fprintln(out, "Canceling worker queue apply")
+			return nil
+		}
+	}
+
+	// update the deployment with the new list of worker queues
+	err = deployment.Update(requestedDeployment.ID, "", ws, "", "", "", "", 0, 0, requestedQueues, true, client)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "worker queues for %s in %s workspace applied from %s\n", requestedDeployment.Label, ws, inputFile)
+	return nil
+}
+
+// readQueuesFile reads a queues file in yaml or json format, it returns an error for unknown properties
+func readQueuesFile(inputFile string) (QueuesFile, error) {
+	var file QueuesFile
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return file, err
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return file, fmt.Errorf("%s: %w", inputFile, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&file)
+	if err != nil {
+		return file, fmt.Errorf("%s: %w", inputFile, err)
+	}
+	if len(file.WorkerQueues) == 0 {
+		return file, fmt.Errorf("%w %s", errNoQueuesInFile, inputFile)
+	}
+	return file, nil
+}
+
+// getRequestedQueues returns the queues of file as the complete list of worker queues of requestedDeployment.
+// Worker counts and concurrency that are not in the file get the default values of the API. existingQueues are the
+// live queues of requestedDeployment with their pod resources.
+func getRequestedQueues(file QueuesFile, requestedDeployment *astro.Deployment, existingQueues []astro.WorkerQueue, client astro.Client) ([]astro.WorkerQueue, error) {
+	var (
+		defaultOptions astro.WorkerQueueDefaultOptions
+		err            error
+	)
+	executor := requestedDeployment.DeploymentSpec.Executor
+	if executor == deployment.CeleryExecutor {
+		// get defaults for min-count, max-count and concurrency from API
+		defaultOptions, err = GetWorkerQueueDefaultOptions(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	existing := map[string]astro.WorkerQueue{}
+	for _, queue := range existingQueues {
+		existing[queue.Name] = queue
+	}
+	requestedQueues := make([]astro.WorkerQueue, 0, len(file.WorkerQueues))
+	hasDefault := false
+	for i := range file.WorkerQueues {
+		fileQueue := &file.WorkerQueues[i]
+		for j := range requestedQueues {
+			if requestedQueues[j].Name == fileQueue.Name {
+				return nil, fmt.Errorf("worker queue %s %w", fileQueue.Name, errDuplicateQueue)
+			}
+		}
+		if fileQueue.WorkerType == "" {
+			return nil, fmt.Errorf("worker queue %s %w", fileQueue.Name, errMissingWorkerType)
+		}
+		nodePoolID, err := selectNodePool(fileQueue.WorkerType, requestedDeployment.Cluster.NodePools, nil)
+		if err != nil {
+			return nil, fmt.Errorf("worker queue %s: %w", fileQueue.Name, err)
+		}
+		queue := astro.WorkerQueue{
+			Name:              fileQueue.Name,
+			IsDefault:         fileQueue.Name == defaultQueueName,
+			NodePoolID:        nodePoolID,
+			MaxWorkerCount:    fileQueue.MaxWorkerCount,
+			WorkerConcurrency: fileQueue.WorkerConcurrency,
+			PodCPU:            fileQueue.PodCPU,
+			PodRAM:            fileQueue.PodRAM,
+		}
+		hasDefault = hasDefault || queue.IsDefault
+
+		switch executor {
+		case deployment.CeleryExecutor:
+			// only existing queues of CeleryExecutor are updated by ID
+			queue.ID = existing[fileQueue.Name].ID
+			// -1 is the CLI default to allow users to request wQueueMin=0
+			wQueueMin := -1
+			if fileQueue.MinWorkerCount != nil {
+				wQueueMin = *fileQueue.MinWorkerCount
+				queue.MinWorkerCount = wQueueMin
+			}
+			SetWorkerQueueValues(wQueueMin, queue.MaxWorkerCount, queue.WorkerConcurrency, &queue, defaultOptions)
+			err = IsCeleryWorkerQueueInputValid(&queue, defaultOptions)
+		case deployment.KubeExecutor:
+			if fileQueue.MinWorkerCount != nil {
+				queue.MinWorkerCount = *fileQueue.MinWorkerCount
+			}
+			err = checkPodResources(&queue, existing[fileQueue.Name])
+			if err == nil {
+				err = IsKubernetesWorkerQueueInputValid(&queue)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("worker queue %s: %w", fileQueue.Name, err)
+		}
+		requestedQueues = append(requestedQueues, queue)
+	}
+	if !hasDefault {
+		return nil, errMissingDefaultQueue
+	}
+	sort.Slice(requestedQueues, func(i, j int) bool {
+		return requestedQueues[i].Name < requestedQueues[j].Name
+	})
+	return requestedQueues, nil
+}
+
+// checkPodResources compares the pod_cpu and pod_ram of a KubernetesExecutor queue in a file to the live queue. They
+// are calculated from the worker type, so the live values, like the ones list prints, are accepted and not sent.
+// Other values are an error that shows how they differ.
+func checkPodResources(queue *astro.WorkerQueue, live astro.WorkerQueue) error {
+	var changes []string
+	if queue.PodCPU != "" && queue.PodCPU != live.PodCPU {
+		changes = append(changes, fmt.Sprintf("pod_cpu: %s -> %s", live.PodCPU, queue.PodCPU))
+	}
+	if queue.PodRAM != "" && queue.PodRAM != live.PodRAM {
+		changes = append(changes, fmt.Sprintf("pod_ram: %s -> %s", live.PodRAM, queue.PodRAM))
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(changes, ", "), errPodResources)
+	}
+	queue.PodCPU, queue.PodRAM = "", ""
+	return nil
+}
+
+// diffQueues compares the existing worker queues of a deployment to the requested ones
+func diffQueues(existingQueues, requestedQueues []astro.WorkerQueue, nodePools []astro.NodePool) queuesDiff {
+	var diff queuesDiff
+	existing := map[string]astro.WorkerQueue{}
+	for _, queue := range existingQueues {
+		existing[queue.Name] = queue
+	}
+	requested := map[string]bool{}
+	for i := range requestedQueues {
+		queue := &requestedQueues[i]
+		requested[queue.Name] = true
+		current, ok := existing[queue.Name]
+		if !ok {
+			diff.added = append(diff.added, queueChange{name: queue.Name, changes: queueProperties(queue, nodePools)})
+			continue
+		}
+		var changes []string
+		if current.NodePoolID != queue.NodePoolID {
+			changes = append(changes, fmt.Sprintf("worker_type: %s -> %s", workerTypeOf(current.NodePoolID, nodePools), workerTypeOf(queue.NodePoolID, nodePools)))
+		}
+		if current.MinWorkerCount != queue.MinWorkerCount {
+			changes = append(changes, fmt.Sprintf("min_worker_count: %d -> %d", current.MinWorkerCount, queue.MinWorkerCount))
+		}
+		if current.MaxWorkerCount != queue.MaxWorkerCount {
+			changes = append(changes, fmt.Sprintf("max_worker_count: %d -> %d", current.MaxWorkerCount, queue.MaxWorkerCount))
+		}
+		if current.WorkerConcurrency != queue.WorkerConcurrency {
+			changes = append(changes, fmt.Sprintf("worker_concurrency: %d -> %d", current.WorkerConcurrency, queue.WorkerConcurrency))
+		}
+		if len(changes) > 0 {
+			diff.changed = append(diff.changed, queueChange{name: queue.Name, changes: changes})
+		}
+	}
+	for i := range existingQueues {
+		if !requested[existingQueues[i].Name] {
+			diff.removed = append(diff.removed, queueChange{name: existingQueues[i].Name})
+		}
+	}
+	return diff
+}
+
+// queueProperties returns the properties of a queue that is created
+func queueProperties(queue *astro.WorkerQueue, nodePools []astro.NodePool) []string {
+	properties := []string{"worker_type: " + workerTypeOf(queue.NodePoolID, nodePools)}
+	if queue.MaxWorkerCount != 0 || queue.WorkerConcurrency != 0 {
+		properties = append(properties,
+			fmt.Sprintf("min_worker_count: %d", queue.MinWorkerCount),
+			fmt.Sprintf("max_worker_count: %d", queue.MaxWorkerCount),
+			fmt.Sprintf("worker_concurrency: %d", queue.WorkerConcurrency))
+	}
+	return properties
+}
+
+func printQueuesDiff(diff *queuesDiff, deploymentName, inputFile string, out io.Writer) {
+	fmt.Fprintf(out, "Worker queues of %s compared to %s:\n", ansi.Bold(deploymentName), inputFile)
+	for _, added := range diff.added {
+		fmt.Fprintf(out, "  %s %s: %s\n", ansi.Green("+"), added.name, strings.Join(added.changes, ", "))
+	}
+	for _, changed := range diff.changed {
+		fmt.Fprintf(out, "  ~ %s: %s\n", changed.name, strings.Join(changed.changes, ", "))
+	}
+	for _, removed := range diff.removed {
+		fmt.Fprintf(out, "  %s %s\n", ansi.Red("-"), removed.name)
+	}
+	fmt.Fprintf(out, "%d to create, %d to update, %d to delete\n", len(diff.added), len(diff.changed), len(diff.removed))
+}
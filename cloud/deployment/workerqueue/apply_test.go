@@ -0,0 +1,173 @@
+package workerqueue
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var applyTestDefaultOptions = astro.WorkerQueueDefaultOptions{
+	MinWorkerCount:    astro.WorkerQueueOption{Floor: 0, Ceiling: 20, Default: 5},
+	MaxWorkerCount:    astro.WorkerQueueOption{Floor: 20, Ceiling: 200, Default: 125},
+	WorkerConcurrency: astro.WorkerQueueOption{Floor: 175, Ceiling: 275, Default: 180},
+}
+
+func writeQueuesFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "queues.yaml")
+	err := os.WriteFile(path, []byte(data), 0o644)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestApply(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	queuesFile := writeQueuesFile(t, `worker_queues:
+  - name: default
+    worker_type: test-instance-type
+    min_worker_count: 12
+    max_worker_count: 150
+    worker_concurrency: 200
+  - name: new-queue
+    worker_type: test-instance-type-1
+`)
+
+	t.Run("creates, updates and deletes queues", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Twice()
+		mockClient.On("GetWorkerQueueOptions").Return(applyTestDefaultOptions, nil).Once()
+		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{}, nil).Once()
+		mockClient.On("UpdateDeployment", mock.MatchedBy(func(input *astro.UpdateDeploymentInput) bool {
+			queues := input.WorkerQueues
+			return len(queues) == 2 &&
+				queues[0] == astro.WorkerQueue{ID: "test-wq-id", Name: "default", IsDefault: true, MinWorkerCount: 12, MaxWorkerCount: 150, WorkerConcurrency: 200, NodePoolID: "test-pool-id"} &&
+				queues[1] == astro.WorkerQueue{Name: "new-queue", MinWorkerCount: 5, MaxWorkerCount: 125, WorkerConcurrency: 180, NodePoolID: "test-pool-id-1"}
+		})).Return(astro.Deployment{}, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := Apply("test-ws-id", "test-deployment-id", "", queuesFile, true, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "new-queue: worker_type: test-instance-type-1, min_worker_count: 5, max_worker_count: 125, worker_concurrency: 180")
+		assert.Contains(t, out.String(), "~ default: max_worker_count: 130 -> 150")
+		assert.Contains(t, out.String(), "test-queue-1")
+		assert.Contains(t, out.String(), "1 to create, 1 to update, 1 to delete")
+		assert.Contains(t, out.String(), "worker queues for test-deployment-label in test-ws-id workspace applied from "+queuesFile)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("cancels when the user does not confirm", func(t *testing.T) {
+		defer testUtil.MockUserInput(t, "n")()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+		mockClient.On("GetWorkerQueueOptions").Return(applyTestDefaultOptions, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := Apply("test-ws-id", "test-deployment-id", "", queuesFile, false, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Canceling worker queue apply")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("does nothing when the queues are up to date", func(t *testing.T) {
+		upToDate := writeQueuesFile(t, `worker_queues:
+  - name: default
+    worker_type: test-instance-type
+    min_worker_count: 12
+    max_worker_count: 130
+    worker_concurrency: 200
+  - name: test-queue-1
+    worker_type: test-instance-type-1
+    min_worker_count: 8
+    max_worker_count: 175
+    worker_concurrency: 180
+`)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+		mockClient.On("GetWorkerQueueOptions").Return(applyTestDefaultOptions, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := Apply("test-ws-id", "test-deployment-id", "", upToDate, false, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "are up to date with")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("validates queues before applying", func(t *testing.T) {
+		tests := []struct {
+			file string
+			err  error
+		}{
+			{file: "worker_queues:\n  - name: default\n    worker_type: test-instance-type\n    max_worker_count: 500\n", err: errInvalidWorkerQueueOption},
+			{file: "worker_queues:\n  - name: queue\n    worker_type: test-instance-type\n", err: errMissingDefaultQueue},
+			{file: "worker_queues:\n  - name: default\n", err: errMissingWorkerType},
+			{file: "worker_queues:\n  - name: default\n    worker_type: unknown\n", err: errInvalidNodePool},
+			{file: "worker_queues:\n  - name: default\n    worker_type: test-instance-type\n  - name: default\n    worker_type: test-instance-type\n", err: errDuplicateQueue},
+		}
+		for _, tt := range tests {
+			mockClient := new(astro_mocks.Client)
+			mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+			mockClient.On("GetWorkerQueueOptions").Return(applyTestDefaultOptions, nil).Once()
+
+			err := Apply("test-ws-id", "test-deployment-id", "", writeQueuesFile(t, tt.file), true, mockClient, new(bytes.Buffer))
+			assert.ErrorIs(t, err, tt.err, tt.file)
+			mockClient.AssertExpectations(t)
+		}
+	})
+
+	t.Run("validates queues of KubernetesExecutor", func(t *testing.T) {
+		deployments := queuesTestDeployments()
+		deployments[0].DeploymentSpec.Executor = deployment.KubeExecutor
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deployments, nil).Once()
+
+		err := Apply("test-ws-id", "test-deployment-id", "", queuesFile, true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, ErrNotSupported)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("compares the pod resources of KubernetesExecutor", func(t *testing.T) {
+		deployments := queuesTestDeployments()
+		deployments[0].DeploymentSpec.Executor = deployment.KubeExecutor
+		deployments[0].WorkerQueues = []astro.WorkerQueue{{ID: "test-wq-id", Name: "default", IsDefault: true, NodePoolID: "test-pool-id", PodCPU: "0.5", PodRAM: "1Gi"}}
+
+		// the live values, like the ones list prints, are up to date
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deployments, nil).Once()
+		out := new(bytes.Buffer)
+		file := writeQueuesFile(t, "worker_queues:\n  - name: default\n    worker_type: test-instance-type\n    pod_cpu: \"0.5\"\n    pod_ram: 1Gi\n")
+		err := Apply("test-ws-id", "test-deployment-id", "", file, true, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "are up to date with")
+		mockClient.AssertExpectations(t)
+
+		// changed values are not silently ignored
+		deployments = queuesTestDeployments()
+		deployments[0].DeploymentSpec.Executor = deployment.KubeExecutor
+		deployments[0].WorkerQueues = []astro.WorkerQueue{{ID: "test-wq-id", Name: "default", IsDefault: true, NodePoolID: "test-pool-id", PodCPU: "0.5", PodRAM: "1Gi"}}
+		mockClient = new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deployments, nil).Once()
+		file = writeQueuesFile(t, "worker_queues:\n  - name: default\n    worker_type: test-instance-type\n    pod_cpu: \"1\"\n    pod_ram: 2Gi\n")
+		err = Apply("test-ws-id", "test-deployment-id", "", file, true, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errPodResources)
+		assert.ErrorContains(t, err, "pod_cpu: 0.5 -> 1, pod_ram: 1Gi -> 2Gi")
+		mockClient.AssertNotCalled(t, "UpdateDeployment", mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		err := Apply("test-ws-id", "test-deployment-id", "", writeQueuesFile(t, "worker_queues: []\n"), true, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.ErrorIs(t, err, errNoQueuesInFile)
+		err = Apply("test-ws-id", "test-deployment-id", "", writeQueuesFile(t, "worker_queues:\n  - name: default\n    workers: 2\n"), true, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.ErrorContains(t, err, "unknown field")
+		err = Apply("test-ws-id", "test-deployment-id", "", filepath.Join(t.TempDir(), "missing.yaml"), true, new(astro_mocks.Client), new(bytes.Buffer))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,103 @@
+package workerqueue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	"github.com/ghodss/yaml"
+)
+
+// notApplicable is the worker type of a queue whose node pool is not in the deployment's cluster
+const notApplicable = "N/A"
+
+// QueuesFile is the file worker queues are applied from. List prints it with --output json or yaml.
+type QueuesFile struct {
+	WorkerQueues []inspect.Workerq `json:"worker_queues"`
+}
+
+// List prints the worker queues of a deployment with their worker type, worker counts and concurrency.
+// output is an output format of printutil, the JSON and YAML outputs are a queues file that can be applied.
+func List(ws, deploymentID, deploymentName, output string, client astro.Client, out io.Writer) error {
+	tableOut, err := printutil.NewWriter(out, output)
+	if err != nil {
+		return err
+	}
+	requestedDeployment, err := deployment.GetDeployment(ws, deploymentID, deploymentName, client, nil)
+	if err != nil {
+		return err
+	}
+	queues := requestedDeployment.WorkerQueues
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].Name < queues[j].Name
+	})
+
+	switch output {
+	case printutil.JSONOutput:
+		data, err := json.MarshalIndent(toQueuesFile(queues, requestedDeployment.Cluster.NodePools), "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case printutil.YAMLOutput:
+		data, err := yaml.Marshal(toQueuesFile(queues, requestedDeployment.Cluster.NodePools))
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(data))
+		return nil
+	}
+
+	tab := printutil.Table{
+		Padding:        []int{30, 10, 20, 20, 20, 20, 30},
+		DynamicPadding: true,
+		Header:         []string{"NAME", "ISDEFAULT", "WORKER TYPE", "MIN WORKER COUNT", "MAX WORKER COUNT", "WORKER CONCURRENCY", "ID"},
+		Fields:         []string{"name", "is_default", "worker_type", "min_worker_count", "max_worker_count", "worker_concurrency", "id"},
+		FieldTypes: map[string]printutil.FieldType{
+			"is_default": printutil.BoolField, "min_worker_count": printutil.IntField, "max_worker_count": printutil.IntField, "worker_concurrency": printutil.IntField,
+		},
+	}
+	for i := range queues {
+		q := &queues[i]
+		tab.AddRow([]string{
+			q.Name, strconv.FormatBool(q.IsDefault), workerTypeOf(q.NodePoolID, requestedDeployment.Cluster.NodePools),
+			strconv.Itoa(q.MinWorkerCount), strconv.Itoa(q.MaxWorkerCount), strconv.Itoa(q.WorkerConcurrency), q.ID,
+		}, false)
+	}
+	return tab.Print(tableOut)
+}
+
+// toQueuesFile returns queues in the format of a queues file
+func toQueuesFile(queues []astro.WorkerQueue, nodePools []astro.NodePool) QueuesFile {
+	file := QueuesFile{WorkerQueues: make([]inspect.Workerq, 0, len(queues))}
+	for i := range queues {
+		minWorkerCount := queues[i].MinWorkerCount
+		file.WorkerQueues = append(file.WorkerQueues, inspect.Workerq{
+			Name:              queues[i].Name,
+			MaxWorkerCount:    queues[i].MaxWorkerCount,
+			MinWorkerCount:    &minWorkerCount,
+			WorkerConcurrency: queues[i].WorkerConcurrency,
+			WorkerType:        workerTypeOf(queues[i].NodePoolID, nodePools),
+			PodCPU:            queues[i].PodCPU,
+			PodRAM:            queues[i].PodRAM,
+		})
+	}
+	return file
+}
+
+// workerTypeOf returns the worker type of the node pool identified by nodePoolID or notApplicable if it is unknown
+func workerTypeOf(nodePoolID string, nodePools []astro.NodePool) string {
+	for i := range nodePools {
+		if nodePools[i].ID == nodePoolID {
+			return nodePools[i].NodeInstanceType
+		}
+	}
+	return notApplicable
+}
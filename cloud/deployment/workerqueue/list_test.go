@@ -0,0 +1,124 @@
+package workerqueue
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/astronomer/astro-cli/astro-client"
+	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/pkg/printutil"
+	testUtil "github.com/astronomer/astro-cli/pkg/testing"
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func queuesTestDeployments() []astro.Deployment {
+	return []astro.Deployment{
+		{
+			ID:    "test-deployment-id",
+			Label: "test-deployment-label",
+			Cluster: astro.Cluster{
+				NodePools: []astro.NodePool{
+					{ID: "test-pool-id", NodeInstanceType: "test-instance-type"},
+					{ID: "test-pool-id-1", IsDefault: true, NodeInstanceType: "test-instance-type-1"},
+				},
+			},
+			DeploymentSpec: astro.DeploymentSpec{Executor: deployment.CeleryExecutor},
+			WorkerQueues: []astro.WorkerQueue{
+				{ID: "test-wq-id-1", Name: "test-queue-1", MaxWorkerCount: 175, MinWorkerCount: 8, WorkerConcurrency: 180, NodePoolID: "test-pool-id-1"},
+				{ID: "test-wq-id", Name: "default", IsDefault: true, MaxWorkerCount: 130, MinWorkerCount: 12, WorkerConcurrency: 200, NodePoolID: "test-pool-id"},
+			},
+		},
+	}
+}
+
+func TestList(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("table", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := List("test-ws-id", "test-deployment-id", "", printutil.TableOutput, mockClient, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "WORKER TYPE")
+		assert.Regexp(t, `default\s+true\s+test-instance-type\s+12\s+130\s+200\s+test-wq-id`, out.String())
+		assert.Regexp(t, `test-queue-1\s+false\s+test-instance-type-1\s+8\s+175\s+180\s+test-wq-id-1`, out.String())
+		assert.Less(t, bytes.Index(out.Bytes(), []byte("default")), bytes.Index(out.Bytes(), []byte("test-queue-1")))
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("json is a queues file", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := List("test-ws-id", "test-deployment-id", "", printutil.JSONOutput, mockClient, out)
+		assert.NoError(t, err)
+		var file QueuesFile
+		err = json.Unmarshal(out.Bytes(), &file)
+		assert.NoError(t, err)
+		assert.Len(t, file.WorkerQueues, 2)
+		assert.Equal(t, "default", file.WorkerQueues[0].Name)
+		assert.Equal(t, "test-instance-type", file.WorkerQueues[0].WorkerType)
+		assert.Equal(t, 12, *file.WorkerQueues[0].MinWorkerCount)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("unknown node pool", func(t *testing.T) {
+		deployments := queuesTestDeployments()
+		deployments[0].WorkerQueues[0].NodePoolID = "deleted-pool"
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deployments, nil).Once()
+
+		out := new(bytes.Buffer)
+		err := List("test-ws-id", "test-deployment-id", "", printutil.TableOutput, mockClient, out)
+		assert.NoError(t, err)
+		assert.Regexp(t, `test-queue-1\s+false\s+N/A`, out.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("yaml is a queues file", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := List("test-ws-id", "test-deployment-id", "", printutil.YAMLOutput, mockClient, out)
+		assert.NoError(t, err)
+		var file QueuesFile
+		err = yaml.Unmarshal(out.Bytes(), &file)
+		assert.NoError(t, err)
+		assert.Len(t, file.WorkerQueues, 2)
+		assert.Equal(t, "test-queue-1", file.WorkerQueues[1].Name)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(queuesTestDeployments(), nil).Once()
+
+		out := new(bytes.Buffer)
+		err := List("test-ws-id", "test-deployment-id", "", "template={{.Name}} {{.WorkerType}}", mockClient, out)
+		assert.NoError(t, err)
+		assert.Equal(t, "default test-instance-type\ntest-queue-1 test-instance-type-1\n", out.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		err := List("test-ws-id", "test-deployment-id", "", "xml", new(astro_mocks.Client), new(bytes.Buffer))
+		assert.ErrorIs(t, err, printutil.ErrInvalidOutput)
+	})
+
+	t.Run("returns an error when listing deployments fails", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(nil, errGetDeployment).Once()
+
+		err := List("test-ws-id", "test-deployment-id", "", printutil.TableOutput, mockClient, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errGetDeployment)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -106,8 +106,11 @@ func CreateOrUpdate(ws, deploymentID, deploymentName, name, action, workerType s
 	case updateAction:
 		if QueueExists(existingQueues, queueToCreateOrUpdate) {
 			if !force {
-				i, _ := input.Confirm(
+				i, err := input.Confirm(
 					fmt.Sprintf("\nAre you sure you want to %s the %s worker queue? If there are any tasks in your DAGs assigned to this worker queue, the tasks might get stuck in a queued state and fail to execute", action, ansi.Bold(queueToCreateOrUpdate.Name)))
+				if err != nil {
+					return err
+				}
 
 				if !i {
 					fmt.Fprintf(out, "Canceling worker queue %s\n", action)
@@ -287,7 +290,10 @@ func selectNodePool(workerType string, nodePools []astro.NodePool, out io.Writer
 		}
 
 		tab.Print(out)
-		choice := input.Text("\n> ")
+		choice, err := input.Prompt("\n> ", "pass --worker-type")
+		if err != nil {
+			return nodePoolID, err
+		}
 		selectedPool, ok := nodePoolMap[choice]
 		if !ok {
 			// returning an error as choice was not in nodePoolMap
@@ -349,8 +355,11 @@ func Delete(ws, deploymentID, deploymentName, name string, force bool, client as
 
 	if QueueExists(existingQueues, queueToDelete) {
 		if !force {
-			i, _ := input.Confirm(
+			i, err := input.Confirm(
 				fmt.Sprintf("\nAre you sure you want to delete the %s worker queue? If there are any tasks in your DAGs assigned to this worker queue, the tasks might get stuck in a queued state and fail to execute", ansi.Bold(queueToDelete.Name)))
+			if err != nil {
+				return err
+			}
 
 			if !i {
 				fmt.Fprintf(out, "Canceling worker queue deletion\n")
@@ -407,7 +416,10 @@ func selectQueue(queueList []astro.WorkerQueue, out io.Writer) (string, error) {
 	}
 
 	tab.Print(out)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass --name")
+	if err != nil {
+		return queueName, err
+	}
 	queueToDelete, ok := queueMap[choice]
 	if !ok {
 		// returning an error as choice was not in queueMap
@@ -465,7 +477,10 @@ func getQueueName(name, action string, requestedDeployment *astro.Deployment, ou
 		switch action {
 		case createAction:
 			// prompt for name if one was not provided
-			queueName = input.Text("Enter a name for the worker queue\n> ")
+			queueName, err = input.Prompt("Enter a name for the worker queue\n> ", "pass --name")
+			if err != nil {
+				return "", err
+			}
 		case updateAction:
 			// user selects a queue as no name was provided
 			queueName, err = selectQueue(requestedDeployment.WorkerQueues, out)
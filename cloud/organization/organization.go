@@ -30,6 +30,7 @@ func newTableOut() *printutil.Table {
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "ID"},
+		Fields:         []string{"name", "id"},
 		ColorRowCode:   [2]string{"\033[1;32m", "\033[0m"},
 	}
 }
@@ -71,9 +72,7 @@ func List(out io.Writer, coreClient astrocore.CoreClient) error {
 		tab.AddRow([]string{name, organizationID}, color)
 	}
 
-	tab.Print(out)
-
-	return nil
+	return tab.Print(out)
 }
 
 func getOrganizationSelection(out io.Writer, coreClient astrocore.CoreClient) (*astrocore.Organization, error) {
@@ -105,7 +104,10 @@ func getOrganizationSelection(out io.Writer, coreClient astrocore.CoreClient) (*
 		deployMap[strconv.Itoa(index)] = or[i]
 	}
 	tab.Print(out)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass the name or ID of the organization as an argument")
+	if err != nil {
+		return nil, err
+	}
 	selected, ok := deployMap[choice]
 	if !ok {
 		return nil, errInvalidOrganizationKey
@@ -170,7 +170,10 @@ func selectUser(users []astrocore.User, workspace bool) (astrocore.User, error)
 	}
 
 	table.Print(os.Stdout)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass the email of the user as an argument")
+	if err != nil {
+		return astrocore.User{}, err
+	}
 	selected, ok := userMap[choice]
 	if !ok {
 		return astrocore.User{}, ErrInvalidUserKey
@@ -221,6 +224,7 @@ func ListOrgUsers(out io.Writer, client astrocore.CoreClient) error {
 		Padding:        []int{30, 50, 10, 50, 10, 10, 10},
 		DynamicPadding: true,
 		Header:         []string{"FULLNAME", "EMAIL", "ID", "ORGANIZATION ROLE", "CREATE DATE"},
+		Fields:         []string{"full_name", "email", "id", "role", "created_at"},
 	}
 	users, err := GetOrgUsers(client)
 	if err != nil {
@@ -237,8 +241,7 @@ func ListOrgUsers(out io.Writer, client astrocore.CoreClient) error {
 		}, false)
 	}
 
-	table.Print(out)
-	return nil
+	return table.Print(out)
 }
 
 func AddWorkspaceUser(email, role, workspace string, out io.Writer, client astrocore.CoreClient) error {
@@ -380,6 +383,7 @@ func ListWorkspaceUsers(out io.Writer, client astrocore.CoreClient, workspace st
 		Padding:        []int{30, 50, 10, 50, 10, 10, 10},
 		DynamicPadding: true,
 		Header:         []string{"FULLNAME", "EMAIL", "ID", "WORKSPACE ROLE", "CREATE DATE"},
+		Fields:         []string{"full_name", "email", "id", "role", "created_at"},
 	}
 	users, err := GetWorkspaceUsers(client, workspace, userPagnationLimit)
 	if err != nil {
@@ -396,8 +400,7 @@ func ListWorkspaceUsers(out io.Writer, client astrocore.CoreClient, workspace st
 		}, false)
 	}
 
-	table.Print(out)
-	return nil
+	return table.Print(out)
 }
 
 func RemoveWorkspaceUser(email, workspace string, out io.Writer, client astrocore.CoreClient) error {
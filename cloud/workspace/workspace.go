@@ -19,6 +19,7 @@ func newTableOut() *printutil.Table {
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "ID"},
+		Fields:         []string{"name", "id"},
 		ColorRowCode:   [2]string{"\033[1;32m", "\033[0m"},
 	}
 }
@@ -65,9 +66,7 @@ func List(client astro.Client, out io.Writer) error {
 		tab.AddRow([]string{name, workspace}, color)
 	}
 
-	tab.Print(out)
-
-	return nil
+	return tab.Print(out)
 }
 
 var GetWorkspaceSelection = func(client astro.Client, out io.Writer) (string, error) {
@@ -99,7 +98,10 @@ var GetWorkspaceSelection = func(client astro.Client, out io.Writer) (string, er
 		deployMap[strconv.Itoa(index)] = ws[i]
 	}
 	tab.Print(out)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass the ID of the workspace")
+	if err != nil {
+		return "", err
+	}
 	selected, ok := deployMap[choice]
 	if !ok {
 		return "", errInvalidWorkspaceKey
@@ -12,6 +12,7 @@ import (
 	"github.com/astronomer/astro-cli/cmd/utils"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/docker"
 	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/ansi"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
@@ -455,8 +456,11 @@ func airflowInit(cmd *cobra.Command, args []string) error {
 	emptyDir := fileutil.IsEmptyDir(config.WorkingPath)
 
 	if !emptyDir {
-		i, _ := input.Confirm(
+		i, err := input.Confirm(
 			fmt.Sprintf("%s \nYou are not in an empty directory. Are you sure you want to initialize a project?", config.WorkingPath))
+		if err != nil {
+			return err
+		}
 
 		if !i {
 			fmt.Println("Canceling project initialization...")
@@ -755,16 +759,36 @@ func airflowSettingsExport(cmd *cobra.Command, args []string) error {
 }
 
 func prepareDefaultAirflowImageTag(airflowVersion string, httpClient *airflowversions.Client) string {
+	// offline, the image tag of an existing Dockerfile is kept instead of the latest cached version
+	if config.IsOffline() && airflowVersion == "" {
+		if tag := dockerfileImageTag(filepath.Join(config.WorkingPath, dockerfile)); tag != "" {
+			return tag
+		}
+	}
 	defaultImageTag, _ := getDefaultImageTag(httpClient, airflowVersion)
 
 	if defaultImageTag == "" {
+		issue := "There was a network issue getting"
+		if config.IsOffline() {
+			issue = "There are no cached versions to use offline for"
+		}
 		if useAstronomerCertified {
-			fmt.Println("WARNING! There was a network issue getting the latest Astronomer Certified image. Your Dockerfile may not contain the latest version")
+			fmt.Printf("WARNING! %s the latest Astronomer Certified image. Your Dockerfile may not contain the latest version\n", issue)
 			defaultImageTag = airflowversions.DefaultAirflowVersion
 		} else {
-			fmt.Println("WARNING! There was a network issue getting the latest Astro Runtime image. Your Dockerfile may not contain the latest version")
+			fmt.Printf("WARNING! %s the latest Astro Runtime image. Your Dockerfile may not contain the latest version\n", issue)
 			defaultImageTag = airflowversions.DefaultRuntimeVersion
 		}
 	}
 	return defaultImageTag
 }
+
+// dockerfileImageTag returns the tag of the image of a Dockerfile, it is empty if there is no Dockerfile
+func dockerfileImageTag(path string) string {
+	cmds, err := docker.ParseFile(path)
+	if err != nil {
+		return ""
+	}
+	_, tag := docker.GetImageTagFromParsedFile(cmds)
+	return tag
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/astronomer/astro-cli/airflow/types"
 	cloud "github.com/astronomer/astro-cli/cloud/deploy"
+	"github.com/astronomer/astro-cli/cloud/deployment"
 	"github.com/astronomer/astro-cli/cmd/utils"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/git"
+	"github.com/astronomer/astro-cli/pkg/sbom"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -40,12 +43,28 @@ var (
 	envFile        string
 	imageName      string
 	deploymentName string
+
+	buildx          bool
+	builder         string
+	builderEndpoint string
+	cacheFrom       []string
+	cacheTo         []string
+	buildSecrets    []string
+
+	sbomFormat      string
+	vulnerabilityDB string
+	failOnSeverity  string
+
+	dependencyDiff           bool
+	confirmDependencyChanges bool
 )
 
 const (
 	registryUncommitedChangesMsg = "Project directory has uncommitted changes, use `astro deploy [deployment-id] -f` to force deploy."
 )
 
+var errFailOnSeverityWithoutDB = errors.New("--fail-on-severity requires a vulnerability database, set one with --vulnerability-db or the sbom.vulnerability_db config")
+
 func NewDeployCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "deploy DEPLOYMENT-ID",
@@ -68,6 +87,19 @@ func NewDeployCmd() *cobra.Command {
 	cmd.Flags().StringVar(&dagsPath, "dags-path", "", "If set deploy dags from this path instead of the dags from working directory")
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to deploy to")
 	cmd.Flags().BoolVar(&parse, "parse", false, "Succeed only if all DAGs in your Astro project parse without errors")
+	cmd.Flags().BoolVar(&buildx, "buildx", false, "Build the image with Docker BuildKit (docker buildx build)")
+	cmd.Flags().StringVar(&builder, "builder", "", "Name of the buildx builder to build the image with")
+	cmd.Flags().StringVar(&builderEndpoint, "builder-endpoint", "", "Address of a remote BuildKit daemon to build the image with, e.g. tcp://buildkitd:1234")
+	cmd.Flags().StringArrayVar(&cacheFrom, "cache-from", []string{}, "External cache source for the image build, e.g. type=registry,ref=<image> or type=local,src=<dir>")
+	cmd.Flags().StringArrayVar(&cacheTo, "cache-to", []string{}, "Cache export destination for the image build, e.g. type=registry,ref=<image> or type=local,dest=<dir>")
+	cmd.Flags().StringArrayVar(&buildSecrets, "build-secrets", []string{}, "Secret to expose to the image build, e.g. id=pip,src=pip.conf or id=pip,env=PIP_INDEX_URL")
+	cmd.Flags().StringVar(&sbomFormat, "sbom", "", "Generate an SBOM of the image before pushing it. Possible values are spdx or cyclonedx")
+	cmd.Flags().StringVar(&vulnerabilityDB, "vulnerability-db", "", "Location of an offline vulnerability database file to check the SBOM against")
+	cmd.Flags().StringVar(&failOnSeverity, "fail-on-severity", "", "Cancel the deploy if a vulnerability of at least this severity is found. Possible values are low, medium, high or critical")
+	cmd.Flags().BoolVar(&dependencyDiff, "dependency-diff", false, "Print the python package changes since the last deploy. It can also be set with the deploy.dependency_diff config")
+	cmd.Flags().BoolVar(&confirmDependencyChanges, "confirm-dependency-changes", false, "Prompt for confirmation when a python package crosses a major version since the last deploy, implies --dependency-diff")
+	cmd.Flags().BoolVar(&waitForStatus, "wait", false, "Wait for the Deployment to become healthy and run the new image before ending the command")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", deployment.DefaultWaitTimeout, "How long --wait waits for the Deployment to become healthy, like 5m or 1h")
 	cmd.Flags().MarkHidden("dags-path") //nolint:errcheck
 	return cmd
 }
@@ -124,6 +156,16 @@ func deploy(cmd *cobra.Command, args []string) error {
 		pytestFile = deployTests(parse, pytest, forceDeploy, pytestFile)
 	}
 
+	report, err := imageReport()
+	if err != nil {
+		return err
+	}
+
+	timeout, err := healthWaitTimeout()
+	if err != nil {
+		return err
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
@@ -138,7 +180,53 @@ func deploy(cmd *cobra.Command, args []string) error {
 		Prompt:         forcePrompt,
 		Dags:           dags,
 		DagsPath:       dagsPath,
+		Buildx: types.BuildxConfig{
+			Enabled:         buildx,
+			Builder:         builder,
+			BuilderEndpoint: builderEndpoint,
+			CacheFrom:       cacheFrom,
+			CacheTo:         cacheTo,
+			Secrets:         buildSecrets,
+		},
+		Report:                   report,
+		DependencyDiff:           dependencyDiff || config.CFG.DeployDependencyDiff.GetBool(),
+		ConfirmDependencyChanges: confirmDependencyChanges,
+		WaitTimeout:              timeout,
 	}
 
 	return DeployImage(deployInput, astroClient)
 }
+
+// imageReport resolves the SBOM options from the flags, falling back to the config
+func imageReport() (cloud.ImageReport, error) {
+	report := cloud.ImageReport{
+		Format:          sbomFormat,
+		VulnerabilityDB: vulnerabilityDB,
+		FailOnSeverity:  failOnSeverity,
+	}
+	if report.Format == "" {
+		report.Format = config.CFG.SBOMFormat.GetString()
+	}
+	if report.VulnerabilityDB == "" {
+		report.VulnerabilityDB = config.CFG.SBOMVulnerabilityDB.GetString()
+	}
+	if report.FailOnSeverity == "" {
+		report.FailOnSeverity = config.CFG.SBOMFailOnSeverity.GetString()
+	}
+	if report.Format != "" && !sbom.ValidFormat(report.Format) {
+		return report, fmt.Errorf("invalid --sbom format %s, use one of: %s, %s", report.Format, sbom.SPDX, sbom.CycloneDX) //nolint
+	}
+	if report.FailOnSeverity != "" {
+		if err := sbom.ValidSeverity(report.FailOnSeverity); err != nil {
+			return report, err
+		}
+		if report.VulnerabilityDB == "" {
+			return report, errFailOnSeverityWithoutDB
+		}
+	}
+	// checking against a vulnerability database needs an SBOM to check
+	if report.Format == "" && report.VulnerabilityDB != "" {
+		report.Format = sbom.SPDX
+	}
+	return report, nil
+}
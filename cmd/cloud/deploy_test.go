@@ -62,3 +62,50 @@ func TestDeployImage(t *testing.T) {
 	err = execDeployCmd([]string{"vr-Id"}...)
 	assert.NoError(t, err)
 }
+
+func TestDeployImageBuildx(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+
+	DeployImage = func(deployInput cloud.InputDeploy, client astro.Client) error {
+		assert.Equal(t, "tcp://buildkitd:1234", deployInput.Buildx.BuilderEndpoint)
+		assert.Equal(t, []string{"type=registry,ref=example.com/cache"}, deployInput.Buildx.CacheFrom)
+		assert.Equal(t, []string{"type=local,dest=/tmp/cache"}, deployInput.Buildx.CacheTo)
+		assert.Equal(t, []string{"id=pip,src=pip.conf"}, deployInput.Buildx.Secrets)
+		return nil
+	}
+
+	err := execDeployCmd([]string{
+		"-f", "test-deployment-id",
+		"--builder-endpoint", "tcp://buildkitd:1234",
+		"--cache-from", "type=registry,ref=example.com/cache",
+		"--cache-to", "type=local,dest=/tmp/cache",
+		"--build-secrets", "id=pip,src=pip.conf",
+	}...)
+	assert.NoError(t, err)
+}
+
+func TestDeployImageReport(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	EnsureProjectDir = func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+
+	DeployImage = func(deployInput cloud.InputDeploy, client astro.Client) error {
+		assert.Equal(t, cloud.ImageReport{Format: "cyclonedx", VulnerabilityDB: "db.json", FailOnSeverity: "high"}, deployInput.Report)
+		return nil
+	}
+
+	err := execDeployCmd([]string{"-f", "test-deployment-id", "--sbom", "cyclonedx", "--vulnerability-db", "db.json", "--fail-on-severity", "high"}...)
+	assert.NoError(t, err)
+
+	err = execDeployCmd([]string{"-f", "test-deployment-id", "--sbom", "xml", "--vulnerability-db", "", "--fail-on-severity", ""}...)
+	assert.ErrorContains(t, err, "invalid --sbom format")
+
+	err = execDeployCmd([]string{"-f", "test-deployment-id", "--sbom", "spdx", "--fail-on-severity", "high"}...)
+	assert.ErrorIs(t, err, errFailOnSeverityWithoutDB)
+}
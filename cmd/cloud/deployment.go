@@ -3,6 +3,9 @@ package cloud
 import (
 	"fmt"
 	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/astronomer/astro-cli/astro-client"
 
@@ -10,8 +13,11 @@ import (
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	"github.com/astronomer/astro-cli/cloud/deployment"
 	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
+	"github.com/astronomer/astro-cli/cloud/deployment/inspect"
 	"github.com/astronomer/astro-cli/cloud/organization"
+	"github.com/astronomer/astro-cli/cloud/workspace"
 	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +25,8 @@ import (
 const (
 	enable  = "enable"
 	disable = "disable"
+
+	defaultApplyConcurrency = 4
 )
 
 var (
@@ -39,7 +47,14 @@ var (
 	errorLogs                     bool
 	infoLogs                      bool
 	waitForStatus                 bool
+	waitTimeout                   time.Duration
 	logCount                      = 500
+	followLogs                    bool
+	logsSince                     string
+	logsUntil                     string
+	logSearch                     string
+	logOutput                     string
+	listOutput                    string
 	variableKey                   string
 	variableValue                 string
 	useEnvFile                    bool
@@ -50,6 +65,19 @@ var (
 	region                        string
 	schedulerSize                 string
 	highAvailability              bool
+	planOnly                      bool
+	prune                         bool
+	applyConcurrency              int
+	templateVars                  []string
+	templateVarFiles              []string
+	validateOnline                bool
+	cloneName                     string
+	cloneClusterID                string
+	cloneSecrets                  bool
+	valueFromFile                 string
+	variablePattern               string
+	forceVariableChange           bool
+	pruneVariables                bool
 	deploymentVariableListExample = `
 		# List a deployment's variables
 		$ astro deployment variable list --deployment-id <deployment-id> --key FOO
@@ -68,13 +96,46 @@ var (
 		# Update a deployment variables from a file
 		$ astro deployment variable update --deployment-id <deployment-id> --load --env .env.my-deployment
 		`
-	httpClient              = httputil.NewHTTPClient()
+	deploymentVariableCreateFromFileExample = `
+		# Create a secret deployment variable from the contents of a file
+		$ astro deployment variable create TOKEN --value-from-file token.txt --deployment-id <deployment-id> --secret
+		# Create a secret deployment variable from stdin
+		$ vault read -field=token secret/airflow | astro deployment variable create TOKEN --value-from-file - --secret
+		`
+	deploymentVariableDeleteExample = `
+		# Delete deployment variables by key
+		$ astro deployment variable delete FOO FOO2 --deployment-id <deployment-id>
+		# Delete every deployment variable whose key starts with AIRFLOW__
+		$ astro deployment variable delete --pattern "AIRFLOW__*" --deployment-id <deployment-id>
+		`
+	deploymentVariableSyncExample = `
+		# Show how a deployment's variables differ from a file
+		$ astro deployment variable diff --deployment-id <deployment-id> --env .env.my-deployment
+		# Make a deployment's variables match a file, deleting the variables that are not in it
+		$ astro deployment variable sync --deployment-id <deployment-id> --env .env.my-deployment --prune
+		`
+	deploymentLogsExample = `
+		# Show the error logs of the last hour
+		$ astro deployment logs <deployment-id> --error --since 1h
+		# Follow the logs that match a pattern
+		$ astro deployment logs <deployment-id> --follow --search "task (failed|retried)"
+		# Print logs between two times as one JSON object per line
+		$ astro deployment logs <deployment-id> --since 2023-01-01T10:00:00Z --until 2023-01-01T11:00:00Z --output json
+		`
+	// httpClient is created by getHTTPClient after the config is loaded, so it uses the http settings
+	httpClient              *httputil.HTTPClient
 	errFlag                 = errors.New("--deployment-file can not be used with other arguments")
 	errInvalidExecutor      = errors.New("not a valid executor")
 	errInvalidCloudProvider = errors.New("not a valid cloud provider. It can only be gcp")
 	errNoRegion             = errors.New("region must be specified with --cloud-provider")
+	errValueFromFileKey     = errors.New("--value-from-file requires a single variable key and no key=value pairs")
+	errEmptyValueFromFile   = errors.New("no variable value in")
+	errInvalidWaitTimeout   = errors.New("is not a valid --wait-timeout, it must be more than 0")
 )
 
+// stdin is where --value-from-file - reads a variable value from
+var stdin io.Reader = os.Stdin
+
 func newDeploymentRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "deployment",
@@ -92,6 +153,12 @@ func newDeploymentRootCmd(out io.Writer) *cobra.Command {
 		newDeploymentVariableRootCmd(out),
 		newDeploymentWorkerQueueRootCmd(out),
 		newDeploymentInspectCmd(out),
+		newDeploymentExportCmd(out),
+		newDeploymentApplyCmd(out),
+		newDeploymentCloneCmd(out),
+		newDeploymentTemplateRootCmd(out),
+		newDeploymentSchemaCmd(out),
+		newDeploymentValidateCmd(out),
 	)
 	return cmd
 }
@@ -107,6 +174,7 @@ func newDeploymentListCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&allDeployments, "all", "a", false, "Show deployments across all workspaces")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -115,7 +183,8 @@ func newDeploymentLogsCmd() *cobra.Command {
 		Use:     "logs [Deployment-ID]",
 		Aliases: []string{"l"},
 		Short:   "Show an Astro Deployment's Scheduler logs",
-		Long:    "Show an Astro Deployment's Scheduler logs. Use flags to determine what log level to show.",
+		Long:    "Show an Astro Deployment's Scheduler logs. Use flags to determine what log level and time window to show. Triggerer and Worker logs can not be shown, Astro only returns Scheduler logs to the CLI.",
+		Example: deploymentLogsExample,
 		RunE:    deploymentLogs,
 	}
 	cmd.Flags().BoolVarP(&warnLogs, "warn", "w", false, "Show logs with a log level of 'warning'")
@@ -123,6 +192,11 @@ func newDeploymentLogsCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&infoLogs, "info", "i", false, "Show logs with a log level of 'info'")
 	cmd.Flags().IntVarP(&logCount, "log-count", "c", logCount, "Number of logs to show")
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to show logs of")
+	cmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Keep polling for new logs until the command is interrupted")
+	cmd.Flags().StringVarP(&logsSince, "since", "t", "", "Only show logs newer than a relative duration like 5m or 2h or an RFC3339 timestamp. Defaults to the past 24 hours")
+	cmd.Flags().StringVarP(&logsUntil, "until", "", "", "Only show logs older than a relative duration like 5m or 2h or an RFC3339 timestamp")
+	cmd.Flags().StringVarP(&logSearch, "search", "s", "", "Only show logs matching this regular expression")
+	cmd.Flags().StringVarP(&logOutput, "output", "o", "", "Output format, json prints one JSON object per log")
 	return cmd
 }
 
@@ -144,9 +218,11 @@ func newDeploymentCreateCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&dagDeploy, "dag-deploy", "", "disable", "Enables DAG-only deploys for the deployment")
 	cmd.Flags().StringVarP(&executor, "executor", "e", "", "The executor to use for the deployment. Possible values can be CeleryExecutor or KubernetesExecutor.")
 	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of file containing the deployment to create. File can be in either JSON or YAML format.")
+	addTemplateVarFlags(cmd)
 	cmd.Flags().IntVarP(&schedulerAU, "scheduler-au", "s", 0, "The Deployment's Scheduler resources in AUs")
 	cmd.Flags().IntVarP(&schedulerReplicas, "scheduler-replicas", "r", 0, "The number of Scheduler replicas for the Deployment")
 	cmd.Flags().BoolVarP(&waitForStatus, "wait", "i", false, "Wait for the Deployment to become healthy before ending the command")
+	cmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "", deployment.DefaultWaitTimeout, "How long --wait waits for the Deployment to become healthy, like 5m or 1h")
 	cmd.Flags().BoolVarP(&cleanOutput, "clean-output", "", false, "clean output to only include inspect yaml or json file in any situation.")
 	if organization.IsOrgHosted() {
 		cmd.Flags().StringVarP(&cloudProvider, "cloud-provider", "p", "", "The Cloud Provider to use for the Deployment. Possible values can be gcp.")
@@ -172,6 +248,7 @@ func newDeploymentUpdateCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&description, "description", "d", "", "Description of the Deployment. If the description contains a space, specify the entire description in quotes \"\"")
 	cmd.Flags().StringVarP(&executor, "executor", "e", "", "The executor to use for the deployment. Possible values can be CeleryExecutor or KubernetesExecutor.")
 	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of file containing the deployment to update. File can be in either JSON or YAML format.")
+	addTemplateVarFlags(cmd)
 	cmd.Flags().IntVarP(&updateSchedulerAU, "scheduler-au", "s", 0, "The Deployment's Scheduler resources in AUs")
 	cmd.Flags().IntVarP(&updateSchedulerReplicas, "scheduler-replicas", "r", 0, "The number of Scheduler replicas for the Deployment")
 	cmd.Flags().BoolVarP(&forceUpdate, "force", "f", false, "Force update: Don't prompt a user before Deployment update")
@@ -181,6 +258,124 @@ func newDeploymentUpdateCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newDeploymentApplyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update Astro Deployments from deployment files",
+		Long:  "Create the Deployments described by deployment files if they do not exist or update them to match the files. The changes are printed before they are applied.",
+		Example: `
+		# Show what would change without applying it, exits with status 2 if a Deployment does not match its file
+		$ astro deployment apply --deployment-file deployment.yaml --plan-only
+		# Apply a directory of deployment files and delete the Deployments of the Workspace that none of them declare
+		$ astro deployment apply --deployment-file deployments/ --prune
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentApply(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of the deployment file or directory of deployment files to apply. Files can be in either JSON or YAML format and YAML files can hold several deployments. Files and directories starting with _ hold fragments for include and extends and are skipped.")
+	cmd.Flags().BoolVarP(&planOnly, "plan-only", "", false, "Only print the changes that would be applied")
+	cmd.Flags().BoolVarP(&prune, "prune", "", false, "Delete the Deployments of the Workspace that are not declared in any deployment file, they are not deleted if a deployment fails to apply")
+	cmd.Flags().IntVarP(&applyConcurrency, "concurrency", "", defaultApplyConcurrency, "Number of Deployments to apply at the same time")
+	addTemplateVarFlags(cmd)
+	_ = cmd.MarkFlagRequired("deployment-file")
+	return cmd
+}
+
+func newDeploymentCloneCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone [DEPLOYMENT-ID]",
+		Short: "Create a copy of an Astro Deployment",
+		Long:  "Create a new Deployment with the configuration, worker queues, environment variables and alert emails of an existing Deployment, in the same or another Workspace and cluster.",
+		Example: `
+		# Create a staging copy of a production Deployment on another cluster
+		$ astro deployment clone <deployment-id> --name staging --workspace-id <workspace-id> --cluster-id <cluster-id>
+		# Also copy secret environment variables, their values are prompted for
+		$ astro deployment clone --deployment-name production --name staging --copy-secrets
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentClone(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&cloneName, "name", "", "", "Name of the new Deployment")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to clone")
+	cmd.Flags().StringVarP(&cloneClusterID, "cluster-id", "c", "", "Cluster to create the new Deployment in. Worker types that do not exist in it are replaced by its default node pool. Defaults to the cluster of the cloned Deployment")
+	cmd.Flags().BoolVarP(&cloneSecrets, "copy-secrets", "", false, "Prompt for the values of secret environment variables to copy them to the new Deployment")
+	_ = cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func newDeploymentTemplateRootCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Work with templated deployment files",
+		Long:  "Deployment files whose name ends with .tmpl, like deployment.yaml.tmpl, are rendered as Go templates before they are read, other files are read as they are even if --var or --var-file is given. Templates can use --var and --var-file values as .Vars, environment variables as .Env and share fragments with include and extends, included fragments are always rendered. Fragments in a directory of deployment files must start with _, like _queues.yaml or _partials/, so they are not read as deployments.",
+	}
+	cmd.AddCommand(
+		newDeploymentTemplateRenderCmd(out),
+	)
+	return cmd
+}
+
+func newDeploymentTemplateRenderCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print deployment files as they are applied",
+		Long:  "Print the deployments of a deployment file or directory of deployment files after rendering templates and resolving extends.",
+		Example: `
+		# Preview a deployment file rendered with variables
+		$ astro deployment template render --deployment-file deployment.yaml.tmpl --var env=prod --var-file prod.yaml
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentTemplateRender(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of the deployment file or directory of deployment files to render")
+	addTemplateVarFlags(cmd)
+	_ = cmd.MarkFlagRequired("deployment-file")
+	return cmd
+}
+
+func newDeploymentSchemaCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of deployment files",
+		Long:  "Print the JSON Schema of deployment files. Editors can use it to complete and check deployment files as they are written.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			return inspect.PrintDeploymentFileSchema(out)
+		},
+	}
+	return cmd
+}
+
+func newDeploymentValidateCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check deployment files without applying them",
+		Long:  "Check deployment files against the deployment file schema and report every problem with the path of the value. Use --online to also check that clusters, workspaces, worker types and runtime versions exist.",
+		Example: `
+		# Check the structure of a directory of deployment files without connecting to Astro
+		$ astro deployment validate --deployment-file deployments/
+		# Also check the values that refer to your Organization
+		$ astro deployment validate --deployment-file deployment.yaml --online
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentValidate(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&inputFile, "deployment-file", "", "", "Location of the deployment file or directory of deployment files to validate")
+	cmd.Flags().BoolVarP(&validateOnline, "online", "", false, "Check clusters, workspaces, worker types, runtime versions and dependencies against your Organization")
+	addTemplateVarFlags(cmd)
+	_ = cmd.MarkFlagRequired("deployment-file")
+	return cmd
+}
+
+func addTemplateVarFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&templateVars, "var", []string{}, "Value to render deployment file templates (.tmpl) with as key=value, available as .Vars.key. Can be repeated and takes precedence over --var-file")
+	cmd.Flags().StringArrayVar(&templateVarFiles, "var-file", []string{}, "YAML or JSON file of values to render deployment file templates (.tmpl) with. Can be repeated")
+}
+
 func newDeploymentDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete DEPLOYMENT-ID",
@@ -205,6 +400,9 @@ func newDeploymentVariableRootCmd(out io.Writer) *cobra.Command {
 		newDeploymentVariableListCmd(out),
 		newDeploymentVariableCreateCmd(out),
 		newDeploymentVariableUpdateCmd(out),
+		newDeploymentVariableDeleteCmd(out),
+		newDeploymentVariableDiffCmd(out),
+		newDeploymentVariableSyncCmd(out),
 	)
 	return cmd
 }
@@ -225,6 +423,7 @@ func newDeploymentVariableListCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&useEnvFile, "save", "s", false, "Save deployment variables to an environment file")
 	cmd.Flags().StringVarP(&envFile, "env", "e", ".env", "Location of the file to save environment variables to")
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to list variables from")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 
 	return cmd
 }
@@ -250,6 +449,8 @@ func newDeploymentVariableCreateCmd(out io.Writer) *cobra.Command {
 	_ = cmd.Flags().MarkHidden("key")
 	_ = cmd.Flags().MarkHidden("value")
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to create variables from")
+	cmd.Flags().StringVarP(&valueFromFile, "value-from-file", "", "", "Read the value of the variable given as the only argument from a file, use - to read it from stdin")
+	cmd.Example += deploymentVariableCreateFromFileExample
 
 	return cmd
 }
@@ -274,7 +475,63 @@ func newDeploymentVariableUpdateCmd(out io.Writer) *cobra.Command {
 	_ = cmd.Flags().MarkHidden("key")
 	_ = cmd.Flags().MarkHidden("value")
 	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to update varibles from")
+	cmd.Flags().StringVarP(&valueFromFile, "value-from-file", "", "", "Read the value of the variable given as the only argument from a file, use - to read it from stdin")
+
+	return cmd
+}
 
+func newDeploymentVariableDeleteCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete [key1 key2]",
+		Short:   "Delete Deployment-level environment variables",
+		Long:    "Delete Deployment-level environment variables by key or by a glob pattern matching their keys",
+		Example: deploymentVariableDeleteExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableDelete(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "Deployment to delete variables from")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to delete variables from")
+	cmd.Flags().StringVarP(&variablePattern, "pattern", "p", "", "Delete every variable whose key matches this glob pattern, such as \"AIRFLOW__*\"")
+	cmd.Flags().BoolVarP(&forceVariableChange, "force", "f", false, "Don't prompt a user before deleting variables")
+	return cmd
+}
+
+func newDeploymentVariableDiffCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff",
+		Short:   "Compare a Deployment's variables to an environment file",
+		Long:    "Show the variables that are added, changed and removed between a Deployment and an environment file. Values of secret variables are never printed or compared",
+		Args:    cobra.NoArgs,
+		Example: deploymentVariableSyncExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableDiff(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "Deployment to compare variables of")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to compare variables of")
+	cmd.Flags().StringVarP(&envFile, "env", "e", ".env", "Location of the environment file to compare to")
+	cmd.Flags().BoolVarP(&makeSecret, "secret", "s", false, "Compare as if the variables of the file are set as secrets")
+	return cmd
+}
+
+func newDeploymentVariableSyncCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sync",
+		Short:   "Make a Deployment's variables match an environment file",
+		Long:    "Create and update a Deployment's variables to match an environment file. Use --prune to also delete the variables that are not in the file",
+		Args:    cobra.NoArgs,
+		Example: deploymentVariableSyncExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentVariableSync(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "Deployment to sync variables of")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to sync variables of")
+	cmd.Flags().StringVarP(&envFile, "env", "e", ".env", "Location of the environment file to sync from")
+	cmd.Flags().BoolVarP(&pruneVariables, "prune", "", false, "Delete the variables of the Deployment that are not in the environment file")
+	cmd.Flags().BoolVarP(&makeSecret, "secret", "s", false, "Set the synced environment variables as secrets")
+	cmd.Flags().BoolVarP(&forceVariableChange, "force", "f", false, "Don't prompt a user before deleting variables")
 	return cmd
 }
 
@@ -283,6 +540,10 @@ func deploymentList(cmd *cobra.Command, out io.Writer) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to find a valid workspace")
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 
 	// Don't validate workspace if viewing all deployments
 	if allDeployments {
@@ -306,7 +567,21 @@ func deploymentLogs(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "failed to find a valid Workspace")
 	}
 
-	return deployment.Logs(deploymentID, ws, deploymentName, warnLogs, errorLogs, infoLogs, logCount, astroClient)
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	options := deployment.LogOptions{
+		WarnLogs:  warnLogs,
+		ErrorLogs: errorLogs,
+		InfoLogs:  infoLogs,
+		LogCount:  logCount,
+		Since:     logsSince,
+		Until:     logsUntil,
+		Search:    logSearch,
+		Follow:    followLogs,
+		Output:    logOutput,
+	}
+	return deployment.Logs(deploymentID, ws, deploymentName, options, astroClient, cmd.OutOrStdout())
 }
 
 func deploymentCreate(cmd *cobra.Command, _ []string, out io.Writer) error {
@@ -334,13 +609,18 @@ func deploymentCreate(cmd *cobra.Command, _ []string, out io.Writer) error {
 
 	// request is to create from a file
 	if inputFile != "" {
-		requestedFlags := cmd.Flags().NFlag()
+		// template vars are the only flags that can be used with a deployment file
+		requestedFlags := cmd.Flags().NFlag() - templateVarFlagsSet(cmd)
 		if requestedFlags > 1 {
 			// other flags were requested
 			return errFlag
 		}
+		vars, err := fromfile.ParseVars(templateVars, templateVarFiles)
+		if err != nil {
+			return err
+		}
 
-		return fromfile.CreateOrUpdate(inputFile, cmd.Name(), astroClient, out)
+		return fromfile.CreateOrUpdate(inputFile, cmd.Name(), vars, astroClient, out)
 	}
 	if dagDeploy != "" && !(dagDeploy == enable || dagDeploy == disable) {
 		return errors.New("Invalid --dag-deploy value)")
@@ -348,7 +628,7 @@ func deploymentCreate(cmd *cobra.Command, _ []string, out io.Writer) error {
 
 	// Get latest runtime version
 	if runtimeVersion == "" {
-		airflowVersionClient := airflowversions.NewClient(httpClient, false)
+		airflowVersionClient := airflowversions.NewClient(getHTTPClient(), false)
 		runtimeVersion, err = airflowversions.GetDefaultImageTag(airflowVersionClient, "")
 		if err != nil {
 			return err
@@ -363,7 +643,22 @@ func deploymentCreate(cmd *cobra.Command, _ []string, out io.Writer) error {
 			return errNoRegion
 		}
 	}
-	return deployment.Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize, schedulerAU, schedulerReplicas, astroClient, astroCoreClient, waitForStatus, highAvailability)
+	timeout, err := healthWaitTimeout()
+	if err != nil {
+		return err
+	}
+	return deployment.Create(label, workspaceID, description, clusterID, runtimeVersion, dagDeploy, executor, cloudProvider, region, schedulerSize, schedulerAU, schedulerReplicas, astroClient, astroCoreClient, timeout, highAvailability)
+}
+
+// healthWaitTimeout returns how long to wait for a deployment to become healthy, 0 if --wait is not set
+func healthWaitTimeout() (time.Duration, error) {
+	if !waitForStatus {
+		return 0, nil
+	}
+	if waitTimeout <= 0 {
+		return 0, fmt.Errorf("%s %w", waitTimeout, errInvalidWaitTimeout)
+	}
+	return waitTimeout, nil
 }
 
 func deploymentUpdate(cmd *cobra.Command, args []string, out io.Writer) error {
@@ -385,12 +680,17 @@ func deploymentUpdate(cmd *cobra.Command, args []string, out io.Writer) error {
 	}
 	// request is to update from a file
 	if inputFile != "" {
-		requestedFlags := cmd.Flags().NFlag()
+		// template vars are the only flags that can be used with a deployment file
+		requestedFlags := cmd.Flags().NFlag() - templateVarFlagsSet(cmd)
 		if requestedFlags > 1 {
 			// other flags were requested
 			return errFlag
 		}
-		return fromfile.CreateOrUpdate(inputFile, cmd.Name(), astroClient, out)
+		vars, err := fromfile.ParseVars(templateVars, templateVarFiles)
+		if err != nil {
+			return err
+		}
+		return fromfile.CreateOrUpdate(inputFile, cmd.Name(), vars, astroClient, out)
 	}
 	if dagDeploy != "" && !(dagDeploy == enable || dagDeploy == disable) {
 		return errors.New("Invalid --dag-deploy value)")
@@ -404,6 +704,78 @@ func deploymentUpdate(cmd *cobra.Command, args []string, out io.Writer) error {
 	return deployment.Update(deploymentID, label, ws, description, deploymentName, dagDeploy, executor, updateSchedulerAU, updateSchedulerReplicas, []astro.WorkerQueue{}, forceUpdate, astroClient)
 }
 
+func deploymentApply(cmd *cobra.Command, out io.Writer) error {
+	options := fromfile.ApplyOptions{PlanOnly: planOnly, Prune: prune, Concurrency: applyConcurrency}
+	// the workspace is only needed to find the deployments to prune
+	if prune {
+		ws, err := coalesceWorkspace()
+		if err != nil {
+			return errors.Wrap(err, "failed to find a valid workspace")
+		}
+		options.WorkspaceID = ws
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	var err error
+	options.Vars, err = fromfile.ParseVars(templateVars, templateVarFiles)
+	if err != nil {
+		return err
+	}
+	return fromfile.Apply(inputFile, options, astroClient, out)
+}
+
+func deploymentClone(cmd *cobra.Command, args []string, out io.Writer) error {
+	// the source deployment is looked up in the current workspace, --workspace-id is where the clone is created
+	ws, err := workspace.GetCurrentWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid workspace")
+	}
+	if len(args) > 0 {
+		deploymentID = args[0]
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	options := fromfile.CloneOptions{Name: cloneName, WorkspaceID: workspaceID, ClusterID: cloneClusterID, CopySecrets: cloneSecrets}
+	return fromfile.Clone(ws, deploymentID, deploymentName, options, astroClient, out)
+}
+
+func deploymentTemplateRender(cmd *cobra.Command, out io.Writer) error {
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	vars, err := fromfile.ParseVars(templateVars, templateVarFiles)
+	if err != nil {
+		return err
+	}
+	return fromfile.Render(inputFile, vars, out)
+}
+
+func deploymentValidate(cmd *cobra.Command, out io.Writer) error {
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	vars, err := fromfile.ParseVars(templateVars, templateVarFiles)
+	if err != nil {
+		return err
+	}
+	return fromfile.Validate(inputFile, vars, validateOnline, astroClient, out)
+}
+
+// templateVarFlagsSet returns how many of the --var and --var-file flags are set on cmd
+func templateVarFlagsSet(cmd *cobra.Command) int {
+	set := 0
+	for _, name := range []string{"var", "var-file"} {
+		if cmd.Flags().Changed(name) {
+			set++
+		}
+	}
+	return set
+}
+
 func deploymentDelete(cmd *cobra.Command, args []string) error {
 	ws, err := coalesceWorkspace()
 	if err != nil {
@@ -426,6 +798,10 @@ func deploymentVariableList(cmd *cobra.Command, _ []string, out io.Writer) error
 	if err != nil {
 		return errors.Wrap(err, "failed to find a valid workspace")
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
@@ -440,6 +816,13 @@ func deploymentVariableCreate(cmd *cobra.Command, args []string, out io.Writer)
 	}
 
 	variableList := args
+	if valueFromFile != "" {
+		variableKey, variableValue, err = variableFromFile(variableKey, variableList)
+		if err != nil {
+			return err
+		}
+		variableList = nil
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
@@ -454,6 +837,13 @@ func deploymentVariableUpdate(cmd *cobra.Command, args []string, out io.Writer)
 	}
 
 	variableList := args
+	if valueFromFile != "" {
+		variableKey, variableValue, err = variableFromFile(variableKey, variableList)
+		if err != nil {
+			return err
+		}
+		variableList = nil
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
@@ -461,6 +851,69 @@ func deploymentVariableUpdate(cmd *cobra.Command, args []string, out io.Writer)
 	return deployment.VariableModify(deploymentID, variableKey, variableValue, ws, envFile, deploymentName, variableList, useEnvFile, makeSecret, true, astroClient, out)
 }
 
+func deploymentVariableDelete(cmd *cobra.Command, args []string, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid workspace")
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableDelete(deploymentID, ws, deploymentName, args, variablePattern, forceVariableChange, astroClient, out)
+}
+
+func deploymentVariableDiff(cmd *cobra.Command, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid workspace")
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableDiff(deploymentID, ws, deploymentName, envFile, makeSecret, astroClient, out)
+}
+
+func deploymentVariableSync(cmd *cobra.Command, out io.Writer) error {
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return errors.Wrap(err, "failed to find a valid workspace")
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	return deployment.VariableSync(deploymentID, ws, deploymentName, envFile, pruneVariables, makeSecret, forceVariableChange, astroClient, out)
+}
+
+// variableFromFile returns the key of a variable given with --key or as the only argument and its value read from
+// --value-from-file, or from stdin if it is -. A single trailing newline is removed from the value.
+func variableFromFile(key string, args []string) (variableKey, value string, err error) {
+	switch {
+	case key != "" && len(args) == 0:
+	case key == "" && len(args) == 1 && !strings.Contains(args[0], "="):
+		key = args[0]
+	default:
+		return "", "", errValueFromFileKey
+	}
+
+	var data []byte
+	if valueFromFile == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(valueFromFile)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	value = strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if value == "" {
+		return "", "", fmt.Errorf("%w %s", errEmptyValueFromFile, valueFromFile)
+	}
+	return key, value, nil
+}
+
 func isValidExecutor(executor string) bool {
 	return executor == deployment.KubeExecutor || executor == deployment.CeleryExecutor || executor == ""
 }
@@ -469,3 +922,11 @@ func isValidExecutor(executor string) bool {
 func isValidCloudProvider(cloudProvider astrocore.SharedClusterCloudProvider) bool {
 	return cloudProvider == astrocore.SharedClusterCloudProviderGcp
 }
+
+// getHTTPClient returns the HTTP client of the runtime versions requests, it is created when it is first used
+func getHTTPClient() *httputil.HTTPClient {
+	if httpClient == nil {
+		httpClient = httputil.NewHTTPClient()
+	}
+	return httpClient
+}
@@ -13,6 +13,8 @@ var (
 	outputFormat, requestedField string
 	template                     bool
 	cleanOutput                  bool
+	exportAll                    bool
+	exportDir                    string
 )
 
 func newDeploymentInspectCmd(out io.Writer) *cobra.Command {
@@ -50,3 +52,44 @@ func deploymentInspect(cmd *cobra.Command, args []string, out io.Writer) error {
 
 	return inspect.Inspect(wsID, deploymentName, deploymentID, outputFormat, astroClient, out, requestedField, template)
 }
+
+func newDeploymentExportCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [DEPLOYMENT-ID]",
+		Short: "Export Deployments as deployment files",
+		Long:  "Write a deployment file template for a Deployment or every Deployment in a Workspace or Organization, named like deployment-name.yaml.tmpl. The files include worker queues, non-secret environment variables and alert emails and can be applied with astro deployment apply, secret environment variables are read from environment variables when they are applied.",
+		Example: `
+		# Export every Deployment in the Organization
+		$ astro deployment export --all --dir deployments/
+		# Export every Deployment in a Workspace
+		$ astro deployment export --all --workspace-id <workspace-id> --dir deployments/
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentExport(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "n", "", "Name of the deployment to export.")
+	cmd.Flags().BoolVarP(&exportAll, "all", "a", false, "Export every deployment in the Workspace given with --workspace-id or in the Organization")
+	cmd.Flags().StringVarP(&exportDir, "dir", "d", ".", "Directory to write the deployment files to")
+	return cmd
+}
+
+func deploymentExport(cmd *cobra.Command, args []string, out io.Writer) error {
+	cmd.SilenceUsage = true
+
+	// every deployment of the organization is exported unless a workspace is given
+	wsID := workspaceID
+	if !exportAll {
+		var err error
+		wsID, err = coalesceWorkspace()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(args) > 0 {
+		deploymentID = args[0]
+	}
+
+	return inspect.Export(wsID, deploymentName, deploymentID, exportDir, exportAll, astroClient, out)
+}
@@ -157,3 +157,55 @@ func TestNewDeploymentInspectCmd(t *testing.T) {
 		assert.NotContains(t, resp, expectedOut)
 	})
 }
+
+func TestNewDeploymentExportCmd(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	deploymentResponse := []astro.Deployment{
+		{
+			ID:        "test-deployment-id",
+			Label:     "test-deployment-label",
+			Workspace: astro.Workspace{ID: "test-ws-id", Label: "test-ws"},
+			Cluster: astro.Cluster{
+				ID:        "cluster-id",
+				Name:      "test-cluster",
+				NodePools: []astro.NodePool{{ID: "test-pool-id", NodeInstanceType: "test-instance-type"}},
+			},
+			Description:    "runs {{ ds }} partitions",
+			RuntimeRelease: astro.RuntimeRelease{Version: "6.0.0"},
+			DeploymentSpec: astro.DeploymentSpec{
+				Executor:                    "CeleryExecutor",
+				Scheduler:                   astro.Scheduler{AU: 5, Replicas: 3},
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{{Key: "token", IsSecret: true}, {Key: "partition", Value: "{{ ds }}"}},
+			},
+			WorkerQueues: []astro.WorkerQueue{{Name: "default", IsDefault: true, NodePoolID: "test-pool-id"}},
+		},
+	}
+	mockClient := new(astro_mocks.Client)
+	astroClient = mockClient
+
+	t.Run("exports deployment files that can be applied", func(t *testing.T) {
+		dir := t.TempDir()
+		mockClient.On("ListDeployments", mock.Anything, "").Return(deploymentResponse, nil).Once()
+		resp, err := execDeploymentCmd("export", "--all", "--dir", dir)
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "1 deployments exported to "+dir)
+
+		t.Setenv("TEST_DEPLOYMENT_LABEL_TOKEN", "secret")
+		resp, err = execDeploymentCmd("validate", "--deployment-file", dir)
+		assert.NoError(t, err)
+		assert.Contains(t, resp, dir+" is valid")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("exported values that look like templates are applied as they are", func(t *testing.T) {
+		dir := t.TempDir()
+		mockClient.On("ListDeployments", mock.Anything, "").Return(deploymentResponse, nil).Twice()
+		_, err := execDeploymentCmd("export", "--all", "--dir", dir)
+		assert.NoError(t, err)
+
+		t.Setenv("TEST_DEPLOYMENT_LABEL_TOKEN", "secret")
+		resp, err := execDeploymentCmd("apply", "--deployment-file", dir, "--plan-only")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "Deployment test-deployment-label is up to date with the deployment file")
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	airflowversions "github.com/astronomer/astro-cli/airflow_versions"
@@ -14,9 +15,12 @@ import (
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
 	"github.com/astronomer/astro-cli/cloud/deployment"
+	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/pkg/fileutil"
+	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/spf13/afero"
@@ -58,6 +62,37 @@ func TestDeploymentList(t *testing.T) {
 	assert.Contains(t, resp, "test-id-1")
 	assert.Contains(t, resp, "test-id-2")
 	mockClient.AssertExpectations(t)
+
+	t.Run("json", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, "").Return([]astro.Deployment{{ID: "test-id-1", Label: "test-name-1"}}, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("list", "-a", "--output", "json")
+		assert.NoError(t, err)
+		var deployments []map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(resp), &deployments))
+		assert.Equal(t, "test-id-1", deployments[0]["id"])
+		assert.Equal(t, "test-name-1", deployments[0]["name"])
+		assert.Equal(t, false, deployments[0]["dag_deploy_enabled"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, "").Return([]astro.Deployment{{ID: "test-id-1"}, {ID: "test-id-2"}}, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("list", "-a", "-o", "template={{.ID}}")
+		assert.NoError(t, err)
+		assert.Equal(t, "test-id-1\ntest-id-2\n", resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		_, err := execDeploymentCmd("list", "-a", "-o", "xml")
+		assert.ErrorIs(t, err, printutil.ErrInvalidOutput)
+	})
 }
 
 func TestDeploymentLogs(t *testing.T) {
@@ -78,9 +113,28 @@ func TestDeploymentLogs(t *testing.T) {
 	astroClient = mockClient
 
 	cmdArgs := []string{"logs", "test-id", "-w", "-e", "-i"}
-	_, err := execDeploymentCmd(cmdArgs...)
+	resp, err := execDeploymentCmd(cmdArgs...)
 	assert.NoError(t, err)
+	assert.Contains(t, resp, "test log line")
 	mockClient.AssertExpectations(t)
+
+	t.Run("with filters and json output", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return([]astro.Deployment{{ID: "test-id"}}, nil).Once()
+		mockClient.On("GetDeploymentHistory", mock.MatchedBy(func(vars map[string]interface{}) bool {
+			return vars["start"] == "2023-01-01T00:00:00Z"
+		})).Return(astro.DeploymentHistory{SchedulerLogs: []astro.SchedulerLog{
+			{Timestamp: "2023-01-01T00:00:01Z", Raw: "task failed", Level: "ERROR"},
+			{Timestamp: "2023-01-01T00:00:02Z", Raw: "task retried", Level: "INFO"},
+		}}, nil).Once()
+		astroClient = mockClient
+
+		cmdArgs := []string{"logs", "test-id", "--since", "2023-01-01T00:00:00Z", "--until", "2023-01-01T01:00:00Z", "--search", "failed", "-o", "json"}
+		resp, err := execDeploymentCmd(cmdArgs...)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"timestamp":"2023-01-01T00:00:01Z","level":"ERROR","message":"task failed"}`+"\n", resp)
+		mockClient.AssertExpectations(t)
+	})
 }
 
 func TestDeploymentCreate(t *testing.T) {
@@ -214,6 +268,11 @@ func TestDeploymentCreate(t *testing.T) {
 		_, err = execDeploymentCmd(cmdArgs...)
 		assert.ErrorContains(t, err, "KubeExecutor is not a valid executor")
 	})
+	t.Run("returns an error if wait-timeout is not more than 0", func(t *testing.T) {
+		cmdArgs := []string{"create", "--name", "test-name", "--workspace-id", ws, "--cluster-id", csID, "--runtime-version", "4.2.5", "--wait", "--wait-timeout", "0s"}
+		_, err = execDeploymentCmd(cmdArgs...)
+		assert.ErrorIs(t, err, errInvalidWaitTimeout)
+	})
 	t.Run("creates a deployment from file", func(t *testing.T) {
 		orgID := "test-org-id"
 		filePath := "./test-deployment.yaml"
@@ -588,6 +647,147 @@ deployment:
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeploymentApply(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	filePath := "./test-deployment.yaml"
+	data := `
+deployment:
+  configuration:
+    name: test-deployment-label
+    description: description 1
+    runtime_version: 6.0.0
+    dag_deploy_enabled: true
+    executor: CeleryExecutor
+    scheduler_au: 5
+    scheduler_count: 3
+    cluster_name: test-cluster
+    workspace_name: test-workspace
+`
+	existingDeployment := astro.Deployment{
+		ID:               "test-deployment-id",
+		Label:            "test-deployment-label",
+		Description:      "description",
+		Workspace:        astro.Workspace{ID: "test-ws-id", Label: "test-workspace"},
+		Cluster:          astro.Cluster{ID: "test-cluster-id", Name: "test-cluster"},
+		DagDeployEnabled: true,
+		DeploymentSpec: astro.DeploymentSpec{
+			Executor:  deployment.CeleryExecutor,
+			Scheduler: astro.Scheduler{AU: 5, Replicas: 3},
+		},
+	}
+	mockClient := new(astro_mocks.Client)
+	origClient := astroClient
+	astroClient = mockClient
+	fileutil.WriteStringToFile(filePath, data)
+	defer func() {
+		astroClient = origClient
+		afero.NewOsFs().Remove(filePath)
+	}()
+
+	t.Run("requires a deployment file", func(t *testing.T) {
+		_, err := execDeploymentCmd("apply")
+		assert.ErrorContains(t, err, "required flag(s) \"deployment-file\" not set")
+	})
+	t.Run("prints the plan and returns an error on drift", func(t *testing.T) {
+		mockClient.On("ListDeployments", mock.Anything, "").Return([]astro.Deployment{existingDeployment}, nil).Once()
+		resp, err := execDeploymentCmd("apply", "--deployment-file", "test-deployment.yaml", "--plan-only")
+		assert.ErrorIs(t, err, fromfile.ErrDriftDetected)
+		assert.Contains(t, resp, "~ deployment.configuration.description: description -> description 1")
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("renders the deployment file template with vars", func(t *testing.T) {
+		fileutil.WriteStringToFile("./test-deployment.yaml.tmpl", strings.Replace(data, "description 1", "{{ .Vars.description }}", 1))
+		defer afero.NewOsFs().Remove("./test-deployment.yaml.tmpl")
+		mockClient.On("ListDeployments", mock.Anything, "").Return([]astro.Deployment{existingDeployment}, nil).Once()
+		resp, err := execDeploymentCmd("apply", "--deployment-file", "test-deployment.yaml.tmpl", "--plan-only", "--var", "description=description")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "is up to date with the deployment file")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeploymentTemplateRender(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	filePath := "./test-deployment.yaml.tmpl"
+	data := `
+deployment:
+  configuration:
+    name: {{ .Vars.name }}
+    executor: CeleryExecutor
+`
+	fileutil.WriteStringToFile(filePath, data)
+	defer afero.NewOsFs().Remove(filePath)
+
+	t.Run("prints the rendered deployment file", func(t *testing.T) {
+		resp, err := execDeploymentCmd("template", "render", "--deployment-file", "test-deployment.yaml.tmpl", "--var", "name=test-deployment-label")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "# Source: test-deployment.yaml.tmpl")
+		assert.Contains(t, resp, "name: test-deployment-label")
+	})
+	t.Run("returns an error if a var of a template is missing", func(t *testing.T) {
+		_, err := execDeploymentCmd("template", "render", "--deployment-file", "test-deployment.yaml.tmpl")
+		assert.ErrorContains(t, err, `map has no entry for key "name"`)
+	})
+	t.Run("returns an error if a var is invalid", func(t *testing.T) {
+		_, err := execDeploymentCmd("template", "render", "--deployment-file", "test-deployment.yaml.tmpl", "--var", "name")
+		assert.ErrorContains(t, err, "invalid variable, use key=value")
+	})
+}
+
+func TestDeploymentClone(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	source := astro.Deployment{
+		ID:        "test-deployment-id",
+		Label:     "test-deployment-label",
+		Workspace: astro.Workspace{ID: "ck05r3bor07h40d02y2hw4n4v", Label: "test-ws"},
+		Cluster:   astro.Cluster{ID: "test-cluster-id", Name: "test-cluster"},
+	}
+	mockClient := new(astro_mocks.Client)
+	astroClient = mockClient
+
+	t.Run("requires a name", func(t *testing.T) {
+		_, err := execDeploymentCmd("clone", "test-deployment-id")
+		assert.ErrorContains(t, err, "required flag(s) \"name\" not set")
+	})
+	t.Run("looks up the source in the current workspace and the cluster to clone to", func(t *testing.T) {
+		mockClient.On("ListDeployments", mock.Anything, "ck05r3bor07h40d02y2hw4n4v").Return([]astro.Deployment{source}, nil).Once()
+		mockClient.On("ListClusters", mock.Anything).Return([]astro.Cluster{source.Cluster}, nil).Once()
+		_, err := execDeploymentCmd("clone", "test-deployment-id", "--name", "test-clone", "--cluster-id", "missing-cluster-id")
+		assert.ErrorContains(t, err, "cluster_id: missing-cluster-id does not exist")
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestDeploymentSchema(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	resp, err := execDeploymentCmd("schema")
+	assert.NoError(t, err)
+	assert.Contains(t, resp, `"title": "Astro deployment file"`)
+}
+
+func TestDeploymentValidate(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	filePath := "./test-deployment.yaml.tmpl"
+	data := `
+deployment:
+  configuration:
+    name: {{ .Vars.name }}
+    executor: CeleryExecutor
+`
+	fileutil.WriteStringToFile(filePath, data)
+	defer afero.NewOsFs().Remove(filePath)
+
+	t.Run("requires a deployment file", func(t *testing.T) {
+		_, err := execDeploymentCmd("validate")
+		assert.ErrorContains(t, err, "required flag(s) \"deployment-file\" not set")
+	})
+	t.Run("prints every problem of the rendered deployment file", func(t *testing.T) {
+		resp, err := execDeploymentCmd("validate", "--deployment-file", "test-deployment.yaml.tmpl", "--var", "name=test-deployment-label")
+		assert.ErrorContains(t, err, "problems found in deployment files: 1")
+		assert.Contains(t, resp, "test-deployment.yaml.tmpl: deployment.configuration.cluster_name: missing required field")
+	})
+}
+
 func TestDeploymentDelete(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.CloudPlatform)
 
@@ -606,6 +806,18 @@ func TestDeploymentDelete(t *testing.T) {
 	_, err := execDeploymentCmd(cmdArgs...)
 	assert.NoError(t, err)
 	mockClient.AssertExpectations(t)
+
+	t.Run("fails to confirm when stdin is closed", func(t *testing.T) {
+		defer testUtil.MockNoInput(t)()
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return([]astro.Deployment{deploymentResp}, nil).Once()
+		astroClient = mockClient
+
+		_, err := execDeploymentCmd("delete", "test-id")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --yes")
+		mockClient.AssertExpectations(t)
+	})
 }
 
 func TestDeploymentVariableList(t *testing.T) {
@@ -732,6 +944,151 @@ func TestDeploymentVariableUpdate(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDeploymentVariableValueFromFile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	mockListResponse := []astro.Deployment{
+		{
+			ID: "test-id-1",
+			DeploymentSpec: astro.DeploymentSpec{
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{},
+			},
+		},
+	}
+	isToken := func(input astro.EnvironmentVariablesInput) bool {
+		vars := input.EnvironmentVariables
+		return len(vars) == 1 && vars[0].Key == "TOKEN" && vars[0].Value == "line1\nline2" && vars[0].IsSecret
+	}
+
+	t.Run("from a file", func(t *testing.T) {
+		valueFile := t.TempDir() + "/token.txt"
+		err := fileutil.WriteStringToFile(valueFile, "line1\nline2\n")
+		assert.NoError(t, err)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(isToken)).Return([]astro.EnvironmentVariablesObject{{Key: "TOKEN", IsSecret: true}}, nil).Once()
+		astroClient = mockClient
+
+		cmdArgs := []string{"variable", "create", "TOKEN", "--value-from-file", valueFile, "--secret", "--deployment-id", "test-id-1"}
+		resp, err := execDeploymentCmd(cmdArgs...)
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "TOKEN")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("from stdin", func(t *testing.T) {
+		defer func(r io.Reader) { stdin = r }(stdin)
+		stdin = strings.NewReader("line1\nline2\n")
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(isToken)).Return([]astro.EnvironmentVariablesObject{{Key: "TOKEN", IsSecret: true}}, nil).Once()
+		astroClient = mockClient
+
+		cmdArgs := []string{"variable", "update", "--key", "TOKEN", "--value-from-file", "-", "--secret", "--deployment-id", "test-id-1"}
+		_, err := execDeploymentCmd(cmdArgs...)
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("requires a single key", func(t *testing.T) {
+		cmdArgs := []string{"variable", "create", "FOO=bar", "--value-from-file", "-", "--deployment-id", "test-id-1"}
+		_, err := execDeploymentCmd(cmdArgs...)
+		assert.ErrorIs(t, err, errValueFromFileKey)
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		defer func(r io.Reader) { stdin = r }(stdin)
+		stdin = strings.NewReader("\n")
+		cmdArgs := []string{"variable", "create", "TOKEN", "--value-from-file", "-", "--deployment-id", "test-id-1"}
+		_, err := execDeploymentCmd(cmdArgs...)
+		assert.ErrorIs(t, err, errEmptyValueFromFile)
+	})
+}
+
+func TestDeploymentVariableDelete(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	mockListResponse := []astro.Deployment{
+		{
+			ID: "test-id-1",
+			DeploymentSpec: astro.DeploymentSpec{
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{{Key: "test-key-1", Value: "test-value-1"}, {Key: "test-key-2", Value: "test-value-2"}},
+			},
+		},
+	}
+	mockClient := new(astro_mocks.Client)
+	mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+	mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+		return len(input.EnvironmentVariables) == 1 && input.EnvironmentVariables[0].Key == "test-key-2"
+	})).Return([]astro.EnvironmentVariablesObject{{Key: "test-key-2", Value: "test-value-2"}}, nil).Once()
+	astroClient = mockClient
+
+	cmdArgs := []string{"variable", "delete", "test-key-1", "--deployment-id", "test-id-1", "--force"}
+	resp, err := execDeploymentCmd(cmdArgs...)
+	assert.NoError(t, err)
+	assert.Contains(t, resp, "deleted variable test-key-1")
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeploymentVariableDiffAndSync(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	mockListResponse := []astro.Deployment{
+		{
+			ID: "test-id-1",
+			DeploymentSpec: astro.DeploymentSpec{
+				EnvironmentVariablesObjects: []astro.EnvironmentVariablesObject{{Key: "test-key-1", Value: "test-value-1"}, {Key: "test-key-2", Value: "test-value-2"}},
+			},
+		},
+	}
+	envFile := t.TempDir() + "/.env"
+	err := fileutil.WriteStringToFile(envFile, "test-key-1=test-value-update\nexport test-key-3=\"test value 3\"\n")
+	assert.NoError(t, err)
+
+	t.Run("diff", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("variable", "diff", "--deployment-id", "test-id-1", "--env", envFile)
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "test-key-3: test value 3")
+		assert.Contains(t, resp, "test-key-1: test-value-1 -> test-value-update")
+		assert.Contains(t, resp, "test-key-2: test-value-2")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("sync with prune", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			vars := input.EnvironmentVariables
+			return len(vars) == 2 && vars[0].Value == "test-value-update" && vars[1].Key == "test-key-3"
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+		astroClient = mockClient
+
+		_, err := execDeploymentCmd("variable", "sync", "--deployment-id", "test-id-1", "--env", envFile, "--prune", "--force")
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("sync with secret does not print the values", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(mockListResponse, nil).Once()
+		mockClient.On("ModifyDeploymentVariable", mock.MatchedBy(func(input astro.EnvironmentVariablesInput) bool {
+			vars := input.EnvironmentVariables
+			return len(vars) == 3 && vars[0].IsSecret && !vars[1].IsSecret && vars[2].IsSecret && vars[2].Value == "test value 3"
+		})).Return([]astro.EnvironmentVariablesObject{}, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("variable", "sync", "--deployment-id", "test-id-1", "--env", envFile, "--secret")
+		assert.NoError(t, err)
+		assert.NotContains(t, resp, "test-value-update")
+		assert.NotContains(t, resp, "test value 3")
+		mockClient.AssertExpectations(t)
+	})
+}
+
 func TestIsValidExecutor(t *testing.T) {
 	t.Run("returns true for Kubernetes Executor", func(t *testing.T) {
 		actual := isValidExecutor(deployment.KubeExecutor)
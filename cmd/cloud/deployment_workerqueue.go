@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/astronomer/astro-cli/cloud/deployment/workerqueue"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 )
 
 var (
@@ -15,8 +16,26 @@ var (
 	workerType     string
 	name           string
 	force          bool
+	queuesFile     string
+	queuesOutput   string
 )
 
+const workerQueueApplyExample = `
+  # Make the worker queues of a deployment match a queues file
+  $ astro deployment worker-queue apply --deployment-id <deployment-id> --file queues.yaml
+
+  # Save the current worker queues of a deployment as a queues file
+  $ astro deployment worker-queue list --deployment-id <deployment-id> --output json > queues.json
+
+  # queues.yaml lists every worker queue of the deployment, queues that are not in it are deleted
+  worker_queues:
+    - name: default
+      worker_type: m5.xlarge
+      min_worker_count: 1
+      max_worker_count: 10
+      worker_concurrency: 16
+`
+
 func newDeploymentWorkerQueueRootCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "worker-queue",
@@ -25,6 +44,8 @@ func newDeploymentWorkerQueueRootCmd(out io.Writer) *cobra.Command {
 		Long:    "Manage worker queues for an Astro Deployment.",
 	}
 	cmd.AddCommand(
+		newDeploymentWorkerQueueListCmd(out),
+		newDeploymentWorkerQueueApplyCmd(out),
 		newDeploymentWorkerQueueCreateCmd(out),
 		newDeploymentWorkerQueueUpdateCmd(out),
 		newDeploymentWorkerQueueDeleteCmd(out),
@@ -32,6 +53,42 @@ func newDeploymentWorkerQueueRootCmd(out io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newDeploymentWorkerQueueListCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List a Deployment's worker queues",
+		Long:    "List the worker queues of an Astro Deployment with their worker type, worker counts and concurrency",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentWorkerQueueList(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "The deployment whose worker queues should be listed.")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "", "", "Name of the deployment whose worker queues should be listed.")
+	cmd.Flags().StringVarP(&queuesOutput, "output", "o", "table", printutil.OutputUsage+". The JSON and YAML outputs are a queues file that can be applied.")
+	return cmd
+}
+
+func newDeploymentWorkerQueueApplyCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "apply",
+		Short:   "Apply a queues file to a Deployment's worker queues",
+		Long:    "Create, update and delete the worker queues of an Astro Deployment to match the queues in a yaml or json file. The changes are shown before they are applied.",
+		Example: workerQueueApplyExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deploymentWorkerQueueApply(cmd, args, out)
+		},
+	}
+	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "The deployment whose worker queues should be applied.")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "", "", "Name of the deployment whose worker queues should be applied.")
+	cmd.Flags().StringVarP(&queuesFile, "file", "", "", "Location of the queues file to apply.")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force apply: Don't prompt a user for confirmation")
+	_ = cmd.MarkFlagRequired("file")
+	return cmd
+}
+
 func newDeploymentWorkerQueueCreateCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "create",
@@ -112,3 +169,23 @@ func deploymentWorkerQueueDelete(cmd *cobra.Command, _ []string, out io.Writer)
 	}
 	return workerqueue.Delete(ws, deploymentID, deploymentName, name, force, astroClient, out)
 }
+
+func deploymentWorkerQueueList(cmd *cobra.Command, _ []string, out io.Writer) error {
+	cmd.SilenceUsage = true
+
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return err
+	}
+	return workerqueue.List(ws, deploymentID, deploymentName, queuesOutput, astroClient, out)
+}
+
+func deploymentWorkerQueueApply(cmd *cobra.Command, _ []string, out io.Writer) error {
+	cmd.SilenceUsage = true
+
+	ws, err := coalesceWorkspace()
+	if err != nil {
+		return err
+	}
+	return workerqueue.Apply(ws, deploymentID, deploymentName, queuesFile, force, astroClient, out)
+}
@@ -598,3 +598,76 @@ func TestNewDeploymentWorkerQueueUpdateCmd(t *testing.T) {
 		assert.NotContains(t, resp, expectedOut)
 	})
 }
+
+func TestNewDeploymentWorkerQueueListCmd(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	deploymentResp := []astro.Deployment{
+		{
+			ID:    "test-deployment-id",
+			Label: "test-deployment-label",
+			Cluster: astro.Cluster{
+				NodePools: []astro.NodePool{{ID: "test-pool-id", NodeInstanceType: "test-instance-type"}},
+			},
+			WorkerQueues: []astro.WorkerQueue{
+				{ID: "test-wq-id", Name: "default", IsDefault: true, MaxWorkerCount: 130, MinWorkerCount: 12, WorkerConcurrency: 110, NodePoolID: "test-pool-id"},
+			},
+		},
+	}
+
+	t.Run("lists worker queues", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deploymentResp, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("worker-queue", "list", "-d", "test-deployment-id")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "test-instance-type")
+		assert.Contains(t, resp, "test-wq-id")
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("lists worker queues as json", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deploymentResp, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("worker-queue", "list", "-d", "test-deployment-id", "-o", "json")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, `"worker_type": "test-instance-type"`)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestNewDeploymentWorkerQueueApplyCmd(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	t.Run("requires a file", func(t *testing.T) {
+		_, err := execDeploymentCmd("worker-queue", "apply", "-d", "test-deployment-id")
+		assert.ErrorContains(t, err, `required flag(s) "file" not set`)
+	})
+
+	t.Run("applies a queues file", func(t *testing.T) {
+		deploymentResp := []astro.Deployment{
+			{
+				ID:             "test-deployment-id",
+				Label:          "test-deployment-label",
+				Cluster:        astro.Cluster{NodePools: []astro.NodePool{{ID: "test-pool-id", NodeInstanceType: "test-instance-type"}}},
+				DeploymentSpec: astro.DeploymentSpec{Executor: "KubernetesExecutor"},
+				WorkerQueues:   []astro.WorkerQueue{{ID: "test-wq-id", Name: "default", IsDefault: true, NodePoolID: "test-pool-id-old"}},
+			},
+		}
+		queuesFile := t.TempDir() + "/queues.yaml"
+		err := os.WriteFile(queuesFile, []byte("worker_queues:\n  - name: default\n    worker_type: test-instance-type\n"), 0o644)
+		assert.NoError(t, err)
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListDeployments", mock.Anything, mock.Anything).Return(deploymentResp, nil).Twice()
+		mockClient.On("GetDeploymentConfig").Return(astro.DeploymentConfig{}, nil).Once()
+		mockClient.On("UpdateDeployment", mock.Anything).Return(astro.Deployment{}, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execDeploymentCmd("worker-queue", "apply", "-d", "test-deployment-id", "--file", queuesFile, "-f")
+		assert.NoError(t, err)
+		assert.Contains(t, resp, "~ default: worker_type: N/A -> test-instance-type")
+		mockClient.AssertExpectations(t)
+	})
+}
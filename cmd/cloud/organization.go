@@ -16,6 +16,7 @@ import (
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 )
 
 var (
@@ -59,6 +60,7 @@ func newOrganizationListCmd(out io.Writer) *cobra.Command {
 			return organizationList(cmd, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -155,6 +157,7 @@ func newOrganizationUserListCmd(out io.Writer) *cobra.Command {
 			return listUsers(cmd, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -175,6 +178,10 @@ func newOrganizationUserUpdateCmd(out io.Writer) *cobra.Command {
 }
 
 func organizationList(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return orgList(out, astroCoreClient)
@@ -224,7 +231,11 @@ func userInvite(cmd *cobra.Command, args []string, out io.Writer) error {
 		email = strings.ToLower(args[0])
 	} else {
 		// no email was provided so ask the user for it
-		email = input.Text("enter email address to invite a user: ")
+		var err error
+		email, err = input.Prompt("enter email address to invite a user: ", "pass the email address as an argument")
+		if err != nil {
+			return err
+		}
 	}
 
 	cmd.SilenceUsage = true
@@ -232,6 +243,10 @@ func userInvite(cmd *cobra.Command, args []string, out io.Writer) error {
 }
 
 func listUsers(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	cmd.SilenceUsage = true
 	return user.ListOrgUsers(out, astroCoreClient)
 }
@@ -247,7 +262,11 @@ func userUpdate(cmd *cobra.Command, args []string, out io.Writer) error {
 
 	if updateRole == "" {
 		// no role was provided so ask the user for it
-		updateRole = input.Text("enter a user organization role(ORGANIZATION_MEMBER, ORGANIZATION_BILLING_ADMIN, ORGANIZATION_OWNER) to update user: ")
+		var err error
+		updateRole, err = input.Prompt("enter a user organization role(ORGANIZATION_MEMBER, ORGANIZATION_BILLING_ADMIN, ORGANIZATION_OWNER) to update user: ", "pass --role")
+		if err != nil {
+			return err
+		}
 	}
 
 	cmd.SilenceUsage = true
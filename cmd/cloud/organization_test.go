@@ -15,6 +15,7 @@ import (
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/input"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -282,6 +283,14 @@ func TestUserInvite(t *testing.T) {
 		_, err = execOrganizationCmd(cmdArgs...)
 		assert.ErrorIs(t, err, user.ErrInvalidEmail)
 	})
+	t.Run("command fails when no email is passed in and stdin is closed", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		defer testUtil.MockNoInput(t)()
+
+		_, err := execOrganizationCmd("user", "invite")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass the email address as an argument")
+	})
 }
 
 func TestUserList(t *testing.T) {
@@ -388,4 +397,12 @@ func TestUserUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Contains(t, resp, expectedOut)
 	})
+	t.Run("command fails when no role is passed in and stdin is closed", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		defer testUtil.MockNoInput(t)()
+
+		_, err := execOrganizationCmd("user", "update", "user@1.com")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --role")
+	})
 }
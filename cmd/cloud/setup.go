@@ -16,6 +16,7 @@ import (
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	"github.com/astronomer/astro-cli/cloud/auth"
 	"github.com/astronomer/astro-cli/cloud/organization"
+	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 	"github.com/astronomer/astro-cli/pkg/util"
@@ -26,18 +27,20 @@ import (
 )
 
 var (
-	authLogin        = auth.Login
-	defaultDomain    = "astronomer.io"
-	client           = httputil.NewHTTPClient()
+	authLogin     = auth.Login
+	defaultDomain = "astronomer.io"
+	// client is created by getClient after the config is loaded, so it uses the http settings
+	client           *httputil.HTTPClient
 	isDeploymentFile = false
 	parseAPIToken    = util.ParseAPIToken
 	errNotAPIToken   = errors.New("the API token given does not appear to be an Astro API Token")
+
+	errOrganizationNotFound = errors.New("is not the ID, short name or name of one of your organizations")
 )
 
 const (
-	accessTokenExpThreshold = 5 * time.Minute
-	topLvlCmd               = "astro"
-	deploymentCmd           = "deployment"
+	topLvlCmd     = "astro"
+	deploymentCmd = "deployment"
 )
 
 type TokenResponse struct {
@@ -97,8 +100,18 @@ func Setup(cmd *cobra.Command, args []string, client astro.Client, coreClient as
 		return nil
 	}
 
-	// if deployment inspect, create, or update commands are used
-	deploymentCmds := []string{"inspect", "create", "update"}
+	// rendering deployment file templates does not need auth setup
+	if cmd.CalledAs() == "render" && cmd.Parent().Use == "template" {
+		return nil
+	}
+
+	// the deployment file schema and offline validation do not need auth setup
+	if cmd.Parent().Use == deploymentCmd && (cmd.CalledAs() == "schema" || (cmd.CalledAs() == "validate" && !validateOnline)) {
+		return nil
+	}
+
+	// if deployment inspect, create, update, apply or validate commands are used
+	deploymentCmds := []string{"inspect", "create", "update", "apply", "validate"}
 	if util.Contains(deploymentCmds, cmd.CalledAs()) && cmd.Parent().Use == deploymentCmd {
 		isDeploymentFile = true
 	}
@@ -109,7 +122,7 @@ func Setup(cmd *cobra.Command, args []string, client astro.Client, coreClient as
 		return err
 	}
 	if apiToken {
-		return nil
+		return resolveOrganizationOverride(coreClient)
 	}
 
 	// run auth setup for any command that requires auth
@@ -118,12 +131,17 @@ func Setup(cmd *cobra.Command, args []string, client astro.Client, coreClient as
 		return err
 	}
 	if apiKey {
-		return nil
+		return resolveOrganizationOverride(coreClient)
 	}
 	err = checkToken(client, coreClient, os.Stdout)
 	if err != nil {
 		return err
 	}
+	// the organization override is resolved before the migration, which would save its short name in the context
+	err = resolveOrganizationOverride(coreClient)
+	if err != nil {
+		return err
+	}
 	err = migrateCloudConfig(coreClient)
 	if err != nil {
 		return err
@@ -136,7 +154,6 @@ func checkToken(client astro.Client, coreClient astrocore.CoreClient, out io.Wri
 	if err != nil {
 		return err
 	}
-	expireTime, _ := c.GetExpiresIn()
 	// check if user is logged in
 	if c.Token == "Bearer " || c.Token == "" || c.Domain == "" {
 		// guide the user through the login process if not logged in
@@ -146,86 +163,16 @@ func checkToken(client astro.Client, coreClient astrocore.CoreClient, out io.Wri
 		}
 
 		return nil
-	} else if isExpired(expireTime, accessTokenExpThreshold) {
-		authConfig, err := auth.FetchDomainAuthConfig(c.Domain)
-		if err != nil {
-			return err
-		}
-		res, err := refresh(c.RefreshToken, authConfig)
+	} else if astro.TokenExpiresSoon(&c) {
+		err := astro.RefreshToken(&c)
 		if err != nil {
 			// guide the user through the login process if refresh doesn't work
-			err := authLogin(c.Domain, "", client, coreClient, out, false)
-			if err != nil {
-				return err
-			}
-		}
-		// persist the updated context with the renewed access token
-		err = c.SetContextKey("token", "Bearer "+res.AccessToken)
-		if err != nil {
-			return err
-		}
-		err = c.SetExpiresIn(res.ExpiresIn)
-		if err != nil {
-			return err
-		}
-		err = c.SetContextKey("workspace", c.Workspace)
-		if err != nil {
-			return err
-		}
-		err = c.SetContextKey("workspace", c.LastUsedWorkspace)
-		if err != nil {
-			return err
+			return authLogin(c.Domain, "", client, coreClient, out, false)
 		}
 	}
 	return nil
 }
 
-// isExpired is true if now() + a threshold is after the given date
-func isExpired(t time.Time, threshold time.Duration) bool {
-	return time.Now().Add(threshold).After(t)
-}
-
-// Refresh gets a new access token from the provided refresh token,
-// The request is used the default client_id and endpoint for device authentication.
-func refresh(refreshToken string, authConfig astro.AuthConfig) (TokenResponse, error) {
-	addr := authConfig.DomainURL + "oauth/token"
-	data := url.Values{
-		"client_id":     {authConfig.ClientID},
-		"grant_type":    {"refresh_token"},
-		"refresh_token": {refreshToken},
-	}
-
-	client := &http.Client{}
-
-	r, err := http.NewRequestWithContext(http_context.Background(), http.MethodPost, addr, strings.NewReader(data.Encode())) // URL-encoded payload
-	if err != nil {
-		log.Fatal(err)
-		return TokenResponse{}, fmt.Errorf("cannot get a new access token from the refresh token: %w", err)
-	}
-	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-
-	res, err := client.Do(r)
-	if err != nil {
-		log.Fatal(err)
-		return TokenResponse{}, fmt.Errorf("cannot get a new access token from the refresh token: %w", err)
-	}
-	defer res.Body.Close()
-
-	var tokenRes TokenResponse
-
-	err = json.NewDecoder(res.Body).Decode(&tokenRes)
-
-	if err != nil {
-		return TokenResponse{}, fmt.Errorf("cannot decode response: %w", err)
-	}
-
-	if tokenRes.Error != nil {
-		return TokenResponse{}, errors.New(tokenRes.ErrorDescription)
-	}
-
-	return tokenRes, nil
-}
-
 func checkAPIKeys(astroClient astro.Client, coreClient astrocore.CoreClient, isDeploymentFile bool, args []string) (bool, error) {
 	// check os variables
 	astronomerKeyID := os.Getenv("ASTRONOMER_KEY_ID")
@@ -284,7 +231,7 @@ func checkAPIKeys(astroClient astro.Client, coreClient astrocore.CoreClient, isD
 	}
 
 	// execute request
-	res, err := client.Do(doOptions)
+	res, err := getClient().Do(doOptions)
 	if err != nil {
 		log.Fatal(err)
 		return false, fmt.Errorf("cannot getaccess token with API keys: %w", err)
@@ -421,3 +368,36 @@ func checkAPIToken(isDeploymentFile bool, coreClient astrocore.CoreClient, args
 	}
 	return true, nil
 }
+
+// resolveOrganizationOverride looks up the organization picked with --organization or ASTRO_ORGANIZATION by its ID,
+// short name or name, so that commands use its ID and short name
+func resolveOrganizationOverride(coreClient astrocore.CoreClient) error {
+	name := config.OrganizationOverride()
+	if name == "" {
+		return nil
+	}
+	orgs, err := organization.ListOrganizations(coreClient)
+	if err != nil {
+		return err
+	}
+	for i := range orgs {
+		if orgs[i].Id != name && orgs[i].ShortName != name && orgs[i].Name != name {
+			continue
+		}
+		product := ""
+		if orgs[i].Product != nil {
+			product = string(*orgs[i].Product)
+		}
+		config.ResolveOrganizationOverride(orgs[i].Id, orgs[i].ShortName, product)
+		return nil
+	}
+	return fmt.Errorf("%s %w", name, errOrganizationNotFound)
+}
+
+// getClient returns the HTTP client of the setup, it is created when it is first used
+func getClient() *httputil.HTTPClient {
+	if client == nil {
+		client = httputil.NewHTTPClient()
+	}
+	return client
+}
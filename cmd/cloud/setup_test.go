@@ -11,6 +11,7 @@ import (
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
+	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/context"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/astronomer/astro-cli/pkg/util"
@@ -413,3 +414,44 @@ func TestCheckAPIToken(t *testing.T) {
 		assert.ErrorIs(t, err, errNotAPIToken)
 	})
 }
+
+func TestResolveOrganizationOverride(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	mockOrgsResponse := astrocore.ListOrganizationsResponse{
+		HTTPResponse: &http.Response{
+			StatusCode: 200,
+		},
+		JSON200: &[]astrocore.Organization{
+			{AuthServiceId: "auth-service-id", Id: "test-org-id", Name: "test org", ShortName: "testorg", Product: &mockOrganizationProduct},
+			{AuthServiceId: "auth-service-id-2", Id: "other-org-id", Name: "other org", ShortName: "otherorg", Product: &mockOrganizationProduct},
+		},
+	}
+	mockCoreClient := new(astrocore_mocks.ClientWithResponsesInterface)
+	defer config.SetOrganizationOverride("")
+
+	t.Run("does nothing without an override", func(t *testing.T) {
+		err := resolveOrganizationOverride(mockCoreClient)
+		assert.NoError(t, err)
+	})
+
+	t.Run("resolves the organization by its name", func(t *testing.T) {
+		config.SetOrganizationOverride("other org")
+		mockCoreClient.On("ListOrganizationsWithResponse", mock.Anything, &astrocore.ListOrganizationsParams{}).Return(&mockOrgsResponse, nil).Once()
+		err := resolveOrganizationOverride(mockCoreClient)
+		assert.NoError(t, err)
+
+		ctx, err := context.GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "other-org-id", ctx.Organization)
+		assert.Equal(t, "otherorg", ctx.OrganizationShortName)
+		assert.Equal(t, "HYBRID", ctx.OrganizationProduct)
+	})
+
+	t.Run("returns an error for an unknown organization", func(t *testing.T) {
+		config.SetOrganizationOverride("unknown-org")
+		mockCoreClient.On("ListOrganizationsWithResponse", mock.Anything, &astrocore.ListOrganizationsParams{}).Return(&mockOrgsResponse, nil).Once()
+		err := resolveOrganizationOverride(mockCoreClient)
+		assert.ErrorIs(t, err, errOrganizationNotFound)
+	})
+	mockCoreClient.AssertExpectations(t)
+}
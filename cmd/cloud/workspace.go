@@ -7,6 +7,7 @@ import (
 	"github.com/astronomer/astro-cli/cloud/user"
 	"github.com/astronomer/astro-cli/cloud/workspace"
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -43,6 +44,7 @@ func newWorkspaceListCmd(out io.Writer) *cobra.Command {
 			return workspaceList(cmd, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -102,6 +104,7 @@ func newWorkspaceUserListCmd(out io.Writer) *cobra.Command {
 			return listWorkspaceUser(cmd, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -135,6 +138,10 @@ func newWorkspaceUserRemoveCmd(out io.Writer) *cobra.Command {
 }
 
 func workspaceList(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return workspace.List(astroClient, out)
@@ -167,6 +174,10 @@ func addWorkspaceUser(cmd *cobra.Command, args []string, out io.Writer) error {
 }
 
 func listWorkspaceUser(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	cmd.SilenceUsage = true
 	return user.ListWorkspaceUsers(out, astroCoreClient, "")
 }
@@ -182,7 +193,11 @@ func updateWorkspaceUser(cmd *cobra.Command, args []string, out io.Writer) error
 
 	if updateWorkspaceRole == "" {
 		// no role was provided so ask the user for it
-		updateWorkspaceRole = input.Text("Enter a user workspace role(WORKSPACE_MEMBER, WORKSPACE_OPERATOR and WORKSPACE_OWNER) to update user: ")
+		var err error
+		updateWorkspaceRole, err = input.Prompt("Enter a user workspace role(WORKSPACE_MEMBER, WORKSPACE_OPERATOR and WORKSPACE_OWNER) to update user: ", "pass --role")
+		if err != nil {
+			return err
+		}
 	}
 
 	cmd.SilenceUsage = true
@@ -12,6 +12,7 @@ import (
 	astrocore_mocks "github.com/astronomer/astro-cli/astro-client-core/mocks"
 	astro_mocks "github.com/astronomer/astro-cli/astro-client/mocks"
 	"github.com/astronomer/astro-cli/cloud/user"
+	"github.com/astronomer/astro-cli/pkg/input"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,6 +52,17 @@ func TestWorkspaceList(t *testing.T) {
 	assert.Contains(t, resp, "test-id-1")
 	assert.Contains(t, resp, "test-label-1")
 	mockClient.AssertExpectations(t)
+
+	t.Run("yaml", func(t *testing.T) {
+		mockClient := new(astro_mocks.Client)
+		mockClient.On("ListWorkspaces", "test-org-id").Return([]astro.Workspace{{ID: "test-id-1", Label: "test-label-1"}}, nil).Once()
+		astroClient = mockClient
+
+		resp, err := execWorkspaceCmd("list", "--output", "yaml")
+		assert.NoError(t, err)
+		assert.Equal(t, "- id: test-id-1\n  name: test-label-1\n", resp)
+		mockClient.AssertExpectations(t)
+	})
 }
 
 func TestWorkspaceSwitch(t *testing.T) {
@@ -258,6 +270,14 @@ func TestWorkspacUserUpdate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Contains(t, resp, expectedOut)
 	})
+	t.Run("command fails when no role is passed in and stdin is closed", func(t *testing.T) {
+		testUtil.InitTestConfig(testUtil.CloudPlatform)
+		defer testUtil.MockNoInput(t)()
+
+		_, err := execWorkspaceCmd("user", "update", "user@1.com")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --role")
+	})
 }
 
 func TestWorkspaceUserAdd(t *testing.T) {
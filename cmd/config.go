@@ -5,17 +5,21 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 
 	"github.com/spf13/cobra"
 )
 
 const (
 	configSetSuccessMsg           = "Setting %s to %s successfully\n"
+	configUnsetSuccessMsg         = "Unset %s successfully\n"
 	configUseOutsideProjectDirMsg = "You are attempting to %s a project config outside of a project directory\n To %s a global config try\n%s\n"
+	redactedConfigValue           = "REDACTED"
 )
 
 var (
 	globalFlag       bool
+	projectFlag      bool
 	configGetExample = `
 		# Get your current project's name
 		$ astro config get project.name
@@ -24,6 +28,26 @@ var (
 		# Set your current project's postgres user
 		$ astro config set postgres.user postgres
 		`
+	configMigrateCredentialsExample = `
+		# Move tokens to a file encrypted with a passphrase, read from ASTRO_CREDENTIALS_PASSPHRASE or prompted
+		$ astro config set -g credentials.store file
+		$ astro config migrate-credentials
+
+		# Move tokens to the credential helper astro-credential-pass
+		$ astro config set -g credentials.store pass
+		$ astro config migrate-credentials
+		`
+	configListExample = `
+		# List every setting with the value that is used and where it comes from
+		$ astro config list
+
+		# List the settings of your global config
+		$ astro config list --global
+		`
+	configUnsetExample = `
+		# Go back to the default container binary in your global config
+		$ astro config unset -g container.binary
+		`
 )
 
 func newConfigRootCmd(out io.Writer) *cobra.Command {
@@ -34,9 +58,13 @@ func newConfigRootCmd(out io.Writer) *cobra.Command {
 		PersistentPreRunE: ensureGlobalFlag,
 	}
 	cmd.PersistentFlags().BoolVarP(&globalFlag, "global", "g", false, "view or modify global config")
+	cmd.PersistentFlags().BoolVarP(&projectFlag, "project", "p", false, "view or modify project config")
 	cmd.AddCommand(
 		newConfigGetCmd(out),
 		newConfigSetCmd(out),
+		newConfigUnsetCmd(out),
+		newConfigListCmd(out),
+		newConfigMigrateCredentialsCmd(out),
 	)
 	return cmd
 }
@@ -64,10 +92,67 @@ func newConfigSetCmd(_ io.Writer) *cobra.Command {
 	return cmd
 }
 
+func newConfigUnsetCmd(_ io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unset [setting-name]",
+		Short:   "Unset project's configuration settings",
+		Long:    "Remove a particular setting from your config.yaml file, so that its global or default value is used",
+		Args:    cobra.ExactArgs(1),
+		Example: configUnsetExample,
+		RunE:    configUnset,
+	}
+	return cmd
+}
+
+func newConfigListCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List configuration settings",
+		Long:    "List every setting with its value and where it comes from: default, home, project or env. Every setting can be overridden with an ASTRO_<SETTING> environment variable, like ASTRO_PAGE_SIZE for page_size. The values of credentials like cloud.api.token are redacted, use astro config get to read them",
+		Args:    cobra.NoArgs,
+		Example: configListExample,
+		// the settings of every config are listed without a project directory
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if globalFlag && projectFlag {
+				return errConfigScopes
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := printutil.NewWriter(out, listOutput)
+			if err != nil {
+				return err
+			}
+			return configList(cmd, out)
+		},
+	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
+	return cmd
+}
+
+func newConfigMigrateCredentialsCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-credentials",
+		Short:   "Move tokens out of the global config.yaml",
+		Long:    "Move the tokens of every context from the global config.yaml to the credential store set with credentials.store",
+		Args:    cobra.NoArgs,
+		Example: configMigrateCredentialsExample,
+		// tokens are only in the global config
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configMigrateCredentials(cmd, out)
+		},
+	}
+	return cmd
+}
+
 func ensureGlobalFlag(cmd *cobra.Command, args []string) error {
+	if globalFlag && projectFlag {
+		return errConfigScopes
+	}
 	isProjectDir, _ := config.IsProjectDir(config.WorkingPath)
 
-	if !isProjectDir && !globalFlag {
+	if !isProjectDir && !globalFlag && len(args) > 0 {
 		c := "astro config " + cmd.Use + " " + args[0] + " -g"
 		return fmt.Errorf(configUseOutsideProjectDirMsg, cmd.Use, cmd.Use, c) //nolint
 	}
@@ -105,10 +190,14 @@ func configSet(cmd *cobra.Command, args []string) error {
 		return errInvalidConfigPath
 	}
 
+	err := cfg.Validate(args[1])
+	if err != nil {
+		return err
+	}
+
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
-	var err error
 	if globalFlag {
 		err = cfg.SetHomeString(args[1])
 	} else {
@@ -121,3 +210,80 @@ func configSet(cmd *cobra.Command, args []string) error {
 	fmt.Printf(configSetSuccessMsg+"\n", cfg.Path, args[1])
 	return nil
 }
+
+func configUnset(cmd *cobra.Command, args []string) error {
+	// get config struct
+	cfg, ok := config.CFGStrMap[args[0]]
+	if !ok {
+		return errInvalidConfigPath
+	}
+
+	// Silence Usage as we have now validated command input
+	cmd.SilenceUsage = true
+
+	var err error
+	if globalFlag {
+		err = cfg.UnsetHome()
+	} else {
+		err = cfg.UnsetProject()
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(configUnsetSuccessMsg, cfg.Path)
+	return nil
+}
+
+func configList(cmd *cobra.Command, out io.Writer) error {
+	cmd.SilenceUsage = true
+
+	tab := printutil.Table{
+		Padding:        []int{30, 50, 10},
+		DynamicPadding: true,
+		Header:         []string{"SETTING", "VALUE", "SOURCE"},
+		Fields:         []string{"setting", "value", "source"},
+	}
+	for _, cfg := range config.Cfgs() {
+		var value, source string
+		switch {
+		case globalFlag:
+			value, source = cfg.Default, config.SourceDefault
+			if cfg.InHome() {
+				value, source = cfg.GetHomeString(), config.SourceHome
+			}
+		case projectFlag:
+			if !cfg.InProject() {
+				continue
+			}
+			value, source = cfg.GetProjectString(), config.SourceProject
+		default:
+			value, source = cfg.GetEffective()
+		}
+		if source == config.SourceEnv {
+			source += " (" + cfg.EnvName() + ")"
+		}
+		// the list is meant to be shared, credentials are redacted like in trace files
+		if cfg.Sensitive() && value != "" {
+			value = redactedConfigValue
+		}
+		tab.AddRow([]string{cfg.Path, value, source}, false)
+	}
+	return tab.Print(out)
+}
+
+func configMigrateCredentials(cmd *cobra.Command, out io.Writer) error {
+	cmd.SilenceUsage = true
+
+	migrated, err := config.MigrateCredentials()
+	for _, domain := range migrated {
+		fmt.Fprintf(out, "Moved the tokens of %s to %s\n", domain, config.CFG.CredentialsStore.GetGlobalString())
+	}
+	if err != nil {
+		return err
+	}
+	if len(migrated) == 0 {
+		fmt.Fprintln(out, "There are no tokens in config.yaml to move")
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/astronomer/astro-cli/config"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,3 +48,60 @@ func TestConfigSetCommandSuccess(t *testing.T) {
 	_, err := executeCommand("config", "set", "-g", "project.name", "testing")
 	assert.NoError(t, err)
 }
+
+func TestConfigMigrateCredentialsCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	_, err := executeCommand("config", "migrate-credentials")
+	assert.ErrorContains(t, err, "credentials.store is plaintext")
+}
+
+func TestConfigSetCommandValidation(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	_, err := executeCommand("config", "set", "-g", "page_size", "many")
+	assert.EqualError(t, err, "many is not a valid value for page_size, it must be of type int")
+
+	_, err = executeCommand("config", "set", "-g", "-p", "page_size", "50")
+	assert.ErrorIs(t, err, errConfigScopes)
+}
+
+func TestConfigListCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	_, err := executeCommand("config", "set", "-g", "container.binary", "podman")
+	assert.NoError(t, err)
+	t.Setenv("ASTRO_PAGE_SIZE", "50")
+
+	buf := new(bytes.Buffer)
+	globalFlag = false
+	err = configList(newConfigListCmd(buf), buf)
+	assert.NoError(t, err)
+	assert.Regexp(t, `container.binary +podman +home`, buf.String())
+	assert.Regexp(t, `page_size +50 +env \(ASTRO_PAGE_SIZE\)`, buf.String())
+	assert.Regexp(t, `postgres.user +postgres +default`, buf.String())
+	assert.NotContains(t, buf.String(), "contexts")
+
+	// credentials are redacted
+	t.Setenv("ASTRO_CLOUD_API_TOKEN", "test-token")
+	buf = new(bytes.Buffer)
+	err = configList(newConfigListCmd(buf), buf)
+	assert.NoError(t, err)
+	assert.Regexp(t, `cloud.api.token +REDACTED +env`, buf.String())
+	assert.Regexp(t, `postgres.password +REDACTED +default`, buf.String())
+	assert.NotContains(t, buf.String(), "test-token")
+
+	_, err = executeCommand("config", "list", "--global", "--project")
+	assert.ErrorIs(t, err, errConfigScopes)
+}
+
+func TestConfigUnsetCommand(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.LocalPlatform)
+	_, err := executeCommand("config", "set", "-g", "container.binary", "podman")
+	assert.NoError(t, err)
+	assert.Equal(t, "podman", config.CFG.DockerCommand.GetString())
+
+	_, err = executeCommand("config", "unset", "-g", "container.binary")
+	assert.NoError(t, err)
+	assert.Equal(t, "docker", config.CFG.DockerCommand.GetString())
+
+	_, err = executeCommand("config", "unset", "-g", "test")
+	assert.ErrorIs(t, err, errInvalidConfigPath)
+}
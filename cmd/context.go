@@ -6,9 +6,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/astronomer/astro-cli/context"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 )
 
-var noPrompt bool
+var (
+	noPrompt   bool
+	listOutput string
+)
 
 func newContextCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
@@ -32,9 +36,14 @@ func newContextListCmd(out io.Writer) *cobra.Command {
 		Short:   "List all contexts",
 		Long:    "List all Astro and Astronomer Software contexts or domains that you've authenticated to on this machine",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			out, err := printutil.NewWriter(out, listOutput)
+			if err != nil {
+				return err
+			}
 			return context.ListContext(cmd, args, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
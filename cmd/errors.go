@@ -12,4 +12,5 @@ var (
 
 	errInvalidSetArgs    = errors.New("must specify exactly two arguments (key value) when setting a config")
 	errInvalidConfigPath = errors.New("config does not exist, check your config key")
+	errConfigScopes      = errors.New("--global and --project can not be used together")
 )
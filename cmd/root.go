@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 
 	astro "github.com/astronomer/astro-cli/astro-client"
 	astrocore "github.com/astronomer/astro-cli/astro-client-core"
@@ -14,11 +16,13 @@ import (
 	"github.com/astronomer/astro-cli/houston"
 	"github.com/astronomer/astro-cli/pkg/ansi"
 	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/version"
 
 	"github.com/google/go-github/v48/github"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -35,6 +39,13 @@ const (
 // NewRootCmd adds all of the primary commands for the cli
 func NewRootCmd() *cobra.Command {
 	var err error
+	// the commands of the context picked with --context are added
+	if name := contextFromArgs(os.Args); name != "" {
+		if err = config.SetContextOverride(name); err != nil {
+			softwareCmd.InitDebugLogs = append(softwareCmd.InitDebugLogs, fmt.Sprintf("Unable to use context %s: %s", name, err.Error()))
+		}
+	}
+
 	httpClient := houston.NewHTTPClient()
 	houstonClient = houston.NewClient(httpClient)
 	houstonVersion, err = houstonClient.GetPlatformVersion(nil)
@@ -66,9 +77,9 @@ func NewRootCmd() *cobra.Command {
 Welcome to the Astro CLI, the modern command line interface for data orchestration. You can use it for Astro, Astronomer Software, or Local Development.`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Check for latest version
-			if config.CFG.UpgradeMessage.GetBool() {
-				// create github client
-				githubClient := github.NewClient(nil)
+			if config.CFG.UpgradeMessage.GetBool() && !config.IsOffline() {
+				// create github client with the configured HTTP client so the check uses the http settings
+				githubClient := github.NewClient(httputil.NewHTTPClient().HTTPClient)
 				// compare current version to latest
 				err = version.CompareVersions(githubClient, "astronomer", "astro-cli")
 				if err != nil {
@@ -120,10 +131,91 @@ Welcome to the Astro CLI, the modern command line interface for data orchestrati
 
 	rootCmd.SetHelpTemplate(getResourcesHelpTemplate(houstonVersion, ctx))
 	rootCmd.PersistentFlags().StringVarP(&verboseLevel, "verbosity", "", logrus.WarnLevel.String(), "Log level (debug, info, warn, error, fatal, panic")
+	rootCmd.PersistentFlags().Var(&overrideFlag{set: config.SetProfileOverride}, "profile", "Profile of the current domain to use for this command without switching to it. It can also be set with "+config.ProfileEnv)
+	rootCmd.PersistentFlags().Var(&overrideFlag{set: config.SetContextOverride}, "context", "Context to use for this command without switching to it, a domain or a domain@profile")
+	rootCmd.PersistentFlags().Var(&overrideFlag{set: func(org string) error {
+		config.SetOrganizationOverride(org)
+		return nil
+	}}, "organization", "Organization ID, short name or name to use for this command without switching to it. It can also be set with "+config.OrganizationEnv)
+	rootCmd.PersistentFlags().Var(&overrideFlag{set: func(ws string) error {
+		config.SetWorkspaceOverride(ws)
+		return nil
+	}}, "workspace", "Workspace ID to use for this command without switching to it. It can also be set with "+config.WorkspaceEnv)
+	rootCmd.PersistentFlags().Var(&overrideFlag{set: httputil.SetTraceFile}, "trace-file", "Write the HTTP requests of this command to a HAR file for support cases, tokens, secrets and signed URLs are redacted. It can also be set with "+httputil.TraceEnv)
+	rootCmd.PersistentFlags().Var(&switchFlag{set: config.SetOfflineOverride}, "offline", "Do not check for updates or look up runtime versions online, cached versions and the image tag of the Dockerfile are used instead. It can also be set with the offline setting")
+	rootCmd.PersistentFlags().Var(&switchFlag{set: input.SetNoInput}, "no-input", "Never prompt for input, prompts use their default or fail with the flag to pass instead. It is the default when stdin is not a terminal")
+	rootCmd.PersistentFlags().VarP(&switchFlag{set: input.SetAssumeYes}, "yes", "y", "Answer yes to every confirmation")
+	rootCmd.PersistentFlags().Lookup("offline").NoOptDefVal = "true"
+	rootCmd.PersistentFlags().Lookup("no-input").NoOptDefVal = "true"
+	rootCmd.PersistentFlags().Lookup("yes").NoOptDefVal = "true"
 
 	return rootCmd
 }
 
+// overrideFlag applies its value as soon as it is parsed, so it is also used by the setup of commands
+type overrideFlag struct {
+	value string
+	set   func(string) error
+}
+
+func (f *overrideFlag) String() string {
+	return f.value
+}
+
+func (f *overrideFlag) Set(value string) error {
+	if err := f.set(value); err != nil {
+		return err
+	}
+	f.value = value
+	return nil
+}
+
+func (f *overrideFlag) Type() string {
+	return "string"
+}
+
+// switchFlag is a bool flag that applies its value as soon as it is parsed
+type switchFlag struct {
+	value bool
+	set   func(bool)
+}
+
+func (f *switchFlag) String() string {
+	return strconv.FormatBool(f.value)
+}
+
+func (f *switchFlag) Set(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	f.set(v)
+	f.value = v
+	return nil
+}
+
+func (f *switchFlag) Type() string {
+	return "bool"
+}
+
+// contextFromArgs returns the value of --context in the arguments of the process. It is read before the flags are
+// parsed as the commands that are added depend on the platform of the context.
+func contextFromArgs(osArgs []string) string {
+	if len(osArgs) < 2 { //nolint:gomnd
+		return ""
+	}
+	args := osArgs[1:]
+	flags := pflag.NewFlagSet("context", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.Usage = func() {}
+	name := flags.String("context", "", "")
+	// pflag stops parsing at --help when it is not defined
+	flags.BoolP("help", "h", false, "")
+	_ = flags.Parse(args)
+	return *name
+}
+
 func getResourcesHelpTemplate(houstonVersion, ctx string) string {
 	return fmt.Sprintf(`{{with (or .Long .Short)}}{{. | trimTrailingWhitespaces}}
 
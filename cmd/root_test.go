@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"path/filepath"
 	"testing"
 
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/input"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/astronomer/astro-cli/version"
 	"github.com/spf13/cobra"
@@ -80,3 +84,76 @@ func TestRootCommandWithFlow(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, output, "Run flow commands")
 }
+
+func TestRootCommandProfile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	defer func() { _ = config.SetProfileOverride("") }()
+
+	_, err := executeCommand("version", "--profile", "Not.Valid")
+	assert.ErrorContains(t, err, "is not a valid profile name")
+
+	_, err = executeCommand("version", "--profile", "ci")
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", config.ProfileOverride())
+	assert.Equal(t, "astronomer.io", config.CFG.Context.GetHomeString())
+}
+
+func TestContextFromArgs(t *testing.T) {
+	assert.Equal(t, "astronomer.io@ci", contextFromArgs([]string{"astro", "deployment", "list", "--context", "astronomer.io@ci", "--all"}))
+	assert.Equal(t, "localhost", contextFromArgs([]string{"astro", "--verbosity", "debug", "-h", "--context=localhost"}))
+	assert.Equal(t, "", contextFromArgs([]string{"astro", "deployment", "list"}))
+}
+
+func TestRootCommandOverrides(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	defer func() {
+		_ = config.SetContextOverride("")
+		config.SetWorkspaceOverride("")
+		config.SetOrganizationOverride("")
+	}()
+
+	_, err := executeCommand("version", "--context", "unknown.io")
+	assert.ErrorContains(t, err, "unknown.io is not a context")
+
+	_, err = executeCommand("version", "--context", "astronomer.io", "--organization", "other-org-id", "--workspace", "other-workspace-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "astronomer.io", config.ContextOverride())
+	ctx, err := config.GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "other-org-id", ctx.Organization)
+	assert.Equal(t, "other-workspace-id", ctx.Workspace)
+}
+
+func TestRootCommandNoInput(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	defer func() {
+		input.SetNoInput(false)
+		input.SetAssumeYes(false)
+	}()
+
+	_, err := executeCommand("version", "--no-input", "--yes")
+	assert.NoError(t, err)
+	assert.True(t, input.NoInput())
+	confirmed, err := input.Confirm("confirm")
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+
+	_, err = executeCommand("version", "--no-input=false", "--yes=false")
+	assert.NoError(t, err)
+	assert.False(t, input.NoInput())
+
+	_, err = executeCommand("workspace", "user", "update", "user@1.com", "--no-input")
+	assert.ErrorIs(t, err, input.ErrNoInput)
+	assert.ErrorContains(t, err, "pass --role")
+}
+
+func TestRootCommandTraceFile(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+	defer httputil.SetTraceFile("") //nolint:errcheck
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	_, err := executeCommand("version", "--trace-file", path)
+	assert.NoError(t, err)
+	assert.Equal(t, path, httputil.TraceFile())
+	assert.FileExists(t, path)
+}
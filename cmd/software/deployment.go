@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/pkg/input"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/deployment"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +21,7 @@ const (
 
 var (
 	allDeployments              bool
+	listOutput                  string
 	cancel                      bool
 	hardDelete                  bool
 	executor                    string
@@ -187,6 +189,7 @@ func newDeploymentListCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&allDeployments, "all", "a", false, "Show deployments across all workspaces")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -401,7 +404,10 @@ func deploymentDelete(cmd *cobra.Command, args []string, out io.Writer) error {
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	if hardDelete {
-		i, _ := input.Confirm(cliDeploymentHardDeletePrompt)
+		i, err := input.Confirm(cliDeploymentHardDeletePrompt)
+		if err != nil {
+			return err
+		}
 
 		if !i {
 			fmt.Println("Exit: This command was not executed and your Deployment was not hard deleted.\n If you want to delete your Deployment but not permanently, try\n $ astro deployment delete without the --hard flag.")
@@ -416,6 +422,10 @@ func deploymentList(cmd *cobra.Command, out io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("failed to find a valid workspace: %w", err)
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 
 	// Don't validate workspace if viewing all deployments
 	if allDeployments {
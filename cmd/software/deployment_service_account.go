@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	sa "github.com/astronomer/astro-cli/software/service_account"
 	"github.com/spf13/cobra"
 )
@@ -75,6 +76,7 @@ func newDeploymentSaListCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&deploymentID, "deployment-id", "d", "", "ID of the deployment in which you wish to manage Service Accounts")
 	_ = cmd.MarkFlagRequired("deployment-id")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -105,6 +107,10 @@ func deploymentSaCreate(cmd *cobra.Command, out io.Writer) error {
 }
 
 func deploymentSaList(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/deployment"
 )
 
@@ -103,10 +104,15 @@ func newDeploymentTeamListCmd(out io.Writer) *cobra.Command {
 			return deploymentTeamsList(cmd, out, args)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
 func deploymentTeamsList(cmd *cobra.Command, out io.Writer, _ []string) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return deployment.ListTeamRoles(deploymentID, houstonClient, out)
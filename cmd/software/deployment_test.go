@@ -8,6 +8,7 @@ import (
 
 	"github.com/astronomer/astro-cli/houston"
 	mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/pkg/input"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -461,6 +462,10 @@ func TestDeploymentList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, output, mockDeployment.ID)
 	api.AssertExpectations(t)
+
+	output, err = execDeploymentCmd("list", "--all", "--output", "template={{.ID}} {{.ReleaseName}}")
+	assert.NoError(t, err)
+	assert.Equal(t, mockDeployment.ID+" "+mockDeployment.ReleaseName+"\n", output)
 }
 
 func TestDeploymentDeleteHardResponseNo(t *testing.T) {
@@ -532,6 +537,41 @@ func TestDeploymentDeleteHardResponseYes(t *testing.T) {
 	assert.Contains(t, output, expectedOut)
 }
 
+func TestDeploymentDeleteHardNoInput(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	appConfig = &houston.AppConfig{
+		HardDeleteDeployment: true,
+		Flags: houston.FeatureFlags{
+			HardDeleteDeployment: true,
+		},
+	}
+	defer testUtil.MockNoInput(t)()
+
+	t.Run("fails to confirm when stdin is closed", func(t *testing.T) {
+		api := new(mocks.ClientInterface)
+		api.On("GetAppConfig", nil).Return(appConfig, nil)
+		houstonClient = api
+
+		_, err := execDeploymentCmd("delete", "--hard", mockDeployment.ID)
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --yes")
+		api.AssertNotCalled(t, "DeleteDeployment", mock.Anything)
+	})
+
+	t.Run("deletes when confirmations are answered with yes", func(t *testing.T) {
+		input.SetAssumeYes(true)
+		defer input.SetAssumeYes(false)
+		api := new(mocks.ClientInterface)
+		api.On("GetAppConfig", nil).Return(appConfig, nil)
+		api.On("DeleteDeployment", houston.DeleteDeploymentRequest{DeploymentID: mockDeployment.ID, HardDelete: true}).Return(mockDeployment, nil)
+		houstonClient = api
+
+		output, err := execDeploymentCmd("delete", "--hard", mockDeployment.ID)
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Successfully deleted deployment")
+	})
+}
+
 func TestDeploymentRuntimeUpgradeCommand(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/deployment"
 	"github.com/spf13/cobra"
 )
@@ -64,6 +65,7 @@ func newDeploymentUserListCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringVarP(&deploymentUserFullname, "name", "n", "", "Full name of the user to search for")
 	_ = cmd.MarkFlagRequired("deployment-id")
 
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -121,6 +123,10 @@ func newDeploymentUserUpdateCmd(out io.Writer) *cobra.Command { //nolint:dupl
 }
 
 func deploymentUserList(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return deployment.UserList(deploymentID, deploymentUserEmail, deploymentUserID, deploymentUserFullname, houstonClient, out)
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/teams"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -57,6 +58,7 @@ func newTeamListCmd(out io.Writer) *cobra.Command {
 	}
 	cmd.Flags().BoolVarP(&paginated, "paginated", "p", false, "Paginated team list")
 	cmd.Flags().IntVarP(&pageSize, "page-size", "s", 0, "Page size of the team list if paginated is set to true")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -79,7 +81,12 @@ func newTeamUpdateCmd(out io.Writer) *cobra.Command {
 }
 
 func listTeam(_ *cobra.Command, out io.Writer, paginated bool, pageSize int) error {
-	if config.CFG.Interactive.GetBool() || paginated {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
+	// scripts get every team in one list in the other output formats
+	if listOutput == printutil.TableOutput && (config.CFG.Interactive.GetBool() || paginated) {
 		configPageSize := config.CFG.PageSize.GetInt()
 		if pageSize <= 0 && teams.ListTeamLimit > 0 {
 			pageSize = configPageSize
@@ -5,11 +5,22 @@ import (
 	"os"
 	"testing"
 
+	"github.com/astronomer/astro-cli/pkg/input"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func execUserCmd(args ...string) (string, error) {
+	testUtil.SetupOSArgsForGinkgo()
+	buf := new(bytes.Buffer)
+	cmd := newUserCmd(buf)
+	cmd.SetOut(buf)
+	cmd.SetArgs(args)
+	_, err := cmd.ExecuteC()
+	return buf.String(), err
+}
+
 func TestUserRootCommand(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	testUtil.SetupOSArgsForGinkgo()
@@ -21,3 +32,20 @@ func TestUserRootCommand(t *testing.T) {
 	assert.Contains(t, buf.String(), "Users represents a human who has authenticated with the Astronomer platform")
 	assert.Contains(t, buf.String(), "create")
 }
+
+func TestUserCreate(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	defer testUtil.MockNoInput(t)()
+
+	t.Run("fails without --email when stdin is closed", func(t *testing.T) {
+		_, err := execUserCmd("create")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --email")
+	})
+
+	t.Run("fails without --password when stdin is closed", func(t *testing.T) {
+		_, err := execUserCmd("create", "--email", "test@astronomer.io")
+		assert.ErrorIs(t, err, input.ErrNoInput)
+		assert.ErrorContains(t, err, "pass --password")
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/workspace"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -60,6 +61,7 @@ func newWorkspaceListCmd(out io.Writer) *cobra.Command {
 			return workspaceList(cmd, out)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -148,6 +150,10 @@ func workspaceCreate(cmd *cobra.Command, out io.Writer) error {
 }
 
 func workspaceList(cmd *cobra.Command, out io.Writer) error {
+	out, err := printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
 	return workspace.List(houstonClient, out)
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	sa "github.com/astronomer/astro-cli/software/service_account"
 	"github.com/spf13/cobra"
 )
@@ -72,6 +73,7 @@ func newWorkspaceSaListCmd(out io.Writer) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&workspaceID, "workspace-id", "w", "", "ID of the workspace, you can leave it empty if you want to use your current context's workspace ID")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -110,6 +112,10 @@ func workspaceSaList(cmd *cobra.Command, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
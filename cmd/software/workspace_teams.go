@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/workspace"
 
 	"github.com/spf13/cobra"
@@ -99,6 +100,7 @@ func newWorkspaceTeamsListCmd(out io.Writer) *cobra.Command {
 			return workspaceTeamsList(cmd, out, args)
 		},
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -149,6 +151,10 @@ func workspaceTeamsList(cmd *cobra.Command, out io.Writer, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to find a valid workspace: %w", err)
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 
 	// Silence Usage as we have now validated command input
 	cmd.SilenceUsage = true
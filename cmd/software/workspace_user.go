@@ -6,6 +6,7 @@ import (
 
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	"github.com/astronomer/astro-cli/software/workspace"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -95,6 +96,7 @@ func newWorkspaceUserListCmd(out io.Writer) *cobra.Command {
 		cmd.Flags().BoolVarP(&paginated, "paginated", "p", false, "Paginated workspace user list")
 		cmd.Flags().IntVarP(&pageSize, "page-size", "s", 0, "Page size of the workspace user list if paginated is set to true")
 	}
+	cmd.Flags().StringVarP(&listOutput, "output", "o", printutil.TableOutput, printutil.OutputUsage)
 	return cmd
 }
 
@@ -150,10 +152,15 @@ func workspaceUserList(_ *cobra.Command, out io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("failed to find a valid workspace: %w", err)
 	}
+	out, err = printutil.NewWriter(out, listOutput)
+	if err != nil {
+		return err
+	}
 	configPageSize := config.CFG.PageSize.GetInt()
 
 	// not calling paginated workspace roles if houston version is before 0.30.0, since that doesn't support pagination
-	if (config.CFG.Interactive.GetBool() || paginated) && houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.30.0"}) {
+	// scripts get every user in one list in the other output formats
+	if listOutput == printutil.TableOutput && (config.CFG.Interactive.GetBool() || paginated) && houston.VerifyVersionMatch(houstonVersion, houston.VersionRestrictions{GTE: "0.30.0"}) {
 		if pageSize <= 0 && configPageSize > 0 {
 			pageSize = configPageSize
 		}
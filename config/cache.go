@@ -0,0 +1,30 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+var errConfigNotInitialized = errors.New("the config is not initialized")
+
+// ReadCacheFile reads a file the CLI caches data in, like runtime versions, from the home config directory
+func ReadCacheFile(name string) ([]byte, error) {
+	if configFs == nil {
+		return nil, os.ErrNotExist
+	}
+	return afero.ReadFile(configFs, filepath.Join(HomeConfigPath, name))
+}
+
+// WriteCacheFile writes a file the CLI caches data in to the home config directory
+func WriteCacheFile(name string, data []byte) error {
+	if configFs == nil {
+		return errConfigNotInitialized
+	}
+	if err := configFs.MkdirAll(HomeConfigPath, dirPerm); err != nil {
+		return err
+	}
+	return afero.WriteFile(configFs, filepath.Join(HomeConfigPath, name), data, filePerm)
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheFile(t *testing.T) {
+	initProfileTestConfig()
+
+	_, err := ReadCacheFile("test_cache.json")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	assert.NoError(t, WriteCacheFile("test_cache.json", []byte("{}")))
+	data, err := ReadCacheFile("test_cache.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(data))
+}
@@ -50,11 +50,13 @@ var (
 	// CFG Houses configuration meta
 	CFG = cfgs{
 		CloudAPIProtocol:      newCfg("cloud.api.protocol", "https"),
-		CloudAPIPort:          newCfg("cloud.api.port", "443"),
+		CloudAPIPort:          newTypedCfg("cloud.api.port", "443", intCfg),
 		CloudWSProtocol:       newCfg("cloud.api.ws_protocol", "wss"),
 		CloudAPIToken:         newCfg("cloud.api.token", ""),
 		Context:               newCfg("context", ""),
-		Contexts:              newCfg("contexts", ""),
+		Contexts:              newTypedCfg("contexts", "", mapCfg),
+		CredentialsStore:      newCfg("credentials.store", PlaintextCredentialStore),
+		CredentialsKeyFile:    newCfg("credentials.key_file", ""),
 		DockerCommand:         newCfg("container.binary", "docker"),
 		LocalAstro:            newCfg("local.astrohub", "http://localhost:8871/v1"),
 		LocalCore:             newCfg("local.core", "http://localhost:8888/v1alpha1"),
@@ -65,30 +67,48 @@ var (
 		PostgresUser:          newCfg("postgres.user", "postgres"),
 		PostgresPassword:      newCfg("postgres.password", "postgres"),
 		PostgresHost:          newCfg("postgres.host", "postgres"),
-		PostgresPort:          newCfg("postgres.port", "5432"),
+		PostgresPort:          newTypedCfg("postgres.port", "5432", intCfg),
 		ProjectDeployment:     newCfg("project.deployment", ""),
 		ProjectName:           newCfg("project.name", ""),
 		ProjectWorkspace:      newCfg("project.workspace", ""),
-		WebserverPort:         newCfg("webserver.port", "8080"),
-		AirflowExposePort:     newCfg("airflow.expose_port", "false"),
-		ShowWarnings:          newCfg("show_warnings", "true"),
+		WebserverPort:         newTypedCfg("webserver.port", "8080", intCfg),
+		AirflowExposePort:     newTypedCfg("airflow.expose_port", "false", boolCfg),
+		ShowWarnings:          newTypedCfg("show_warnings", "true", boolCfg),
 		Verbosity:             newCfg("verbosity", "warning"),
-		HoustonDialTimeout:    newCfg("houston.dial_timeout", "10"),
-		HoustonSkipVerifyTLS:  newCfg("houston.skip_verify_tls", "false"),
-		DuplicateImageVolumes: newCfg("duplicate_volumes", "true"),
-		SkipParse:             newCfg("skip_parse", "false"),
-		Interactive:           newCfg("interactive", "false"),
-		PageSize:              newCfg("page_size", "20"),
-		SQLCLI:                newCfg("beta.sql_cli", "false"),
-		AuditLogs:             newCfg("beta.audit_logs", "false"),
-		UpgradeMessage:        newCfg("upgrade_message", "true"),
-		DisableAstroRun:       newCfg("disable_astro_run", "false"),
+		HoustonDialTimeout:    newTypedCfg("houston.dial_timeout", "10", intCfg),
+		HoustonSkipVerifyTLS:  newTypedCfg("houston.skip_verify_tls", "false", boolCfg),
+		HTTPCABundle:          newCfg("http.ca_bundle", ""),
+		HTTPClientCert:        newCfg("http.client_cert", ""),
+		HTTPClientKey:         newCfg("http.client_key", ""),
+		HTTPProxy:             newCfg("http.proxy", ""),
+		HTTPTimeout:           newTypedCfg("http.timeout", "0s", durationCfg),
+		DuplicateImageVolumes: newTypedCfg("duplicate_volumes", "true", boolCfg),
+		SkipParse:             newTypedCfg("skip_parse", "false", boolCfg),
+		Interactive:           newTypedCfg("interactive", "false", boolCfg),
+		Offline:               newTypedCfg("offline", "false", boolCfg),
+		PageSize:              newTypedCfg("page_size", "20", intCfg),
+		SQLCLI:                newTypedCfg("beta.sql_cli", "false", boolCfg),
+		AuditLogs:             newTypedCfg("beta.audit_logs", "false", boolCfg),
+		UpgradeMessage:        newTypedCfg("upgrade_message", "true", boolCfg),
+		DisableAstroRun:       newTypedCfg("disable_astro_run", "false", boolCfg),
+		DagUploadBlockSize:    newTypedCfg("dag_upload.block_size_mb", "4", intCfg),
+		DagUploadConcurrency:  newTypedCfg("dag_upload.concurrency", "4", intCfg),
+		DagUploadMaxRetries:   newTypedCfg("dag_upload.max_retries", "5", intCfg),
+		DagUploadTimeout:      newTypedCfg("dag_upload.timeout", "600", intCfg),
+		RuntimeVersionsSource: newCfg("runtime_versions.source", ""),
+		RuntimeVersionsTTL:    newTypedCfg("runtime_versions.cache_ttl", "24h", durationCfg),
+		SBOMFormat:            newCfg("sbom.format", ""),
+		SBOMVulnerabilityDB:   newCfg("sbom.vulnerability_db", ""),
+		SBOMFailOnSeverity:    newCfg("sbom.fail_on_severity", ""),
+		DeployDependencyDiff:  newTypedCfg("deploy.dependency_diff", "false", boolCfg),
 	}
 
 	// viperHome is the viper object in the users home directory
 	viperHome *viper.Viper
 	// viperProject is the viper object in a project directory
 	viperProject *viper.Viper
+	// configFs is the filesystem of the config files
+	configFs afero.Fs
 	// createConfigPath dir path, file path
 	dirPerm  os.FileMode = 0o775
 	filePerm os.FileMode = 0o600
@@ -96,6 +116,7 @@ var (
 
 // InitConfig initializes the config files
 func InitConfig(fs afero.Fs) {
+	configFs = fs
 	initHome(fs)
 	initProject(fs)
 }
@@ -3,8 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
-	"strings"
 	"time"
+
+	"github.com/astronomer/astro-cli/pkg/credentials"
 )
 
 var (
@@ -34,20 +35,28 @@ type Context struct {
 	Token                 string `mapstructure:"token"`
 	RefreshToken          string `mapstructure:"refreshtoken"`
 	UserEmail             string `mapstructure:"user_email"`
+	Profile               string `mapstructure:"profile"`
 }
 
 // GetCurrentContext looks up current context and gets corresponding Context struct
 func GetCurrentContext() (Context, error) {
 	c := Context{}
 
-	domain := CFG.Context.GetHomeString()
+	domain, _ := ParseContextName(currentContextName())
 	if domain == "" {
 		return Context{}, ErrGetHomeString
 	}
 
+	// the current profile or the one picked with --profile is used
 	c.Domain = domain
 
-	return c.GetContext()
+	c, err := c.GetContext()
+	if err != nil {
+		return c, err
+	}
+	// the organization and workspace picked with --organization and --workspace are used
+	c.applyOverrides()
+	return c, nil
 }
 
 // ResetCurrentContext reset the current context and is used when someone logs out
@@ -57,12 +66,17 @@ func ResetCurrentContext() error {
 
 // GetContextKey allows a context domain to be used without interfering
 // with viper's dot (.) notation for fetching configs by replacing with underscores (_)
+// The key of a profile other than the default one ends with @ and the profile
 func (c *Context) GetContextKey() (string, error) {
 	if c.Domain == "" {
 		return "", ErrCtxConfigErr
 	}
 
-	return strings.Replace(c.Domain, ".", "_", -1), nil
+	key := domainKey(c.Domain)
+	if profile := c.resolvedProfile(); profile != DefaultProfile {
+		key += profileSeparator + profile
+	}
+	return key, nil
 }
 
 // ContextExists checks if a context struct exists in config
@@ -88,10 +102,16 @@ func (c *Context) GetContext() (Context, error) {
 	if !c.ContextExists() {
 		return *c, errNotConnected
 	}
+	profile := c.resolvedProfile()
 	err = viperHome.UnmarshalKey("contexts"+"."+key, &c)
 	if err != nil {
 		return *c, err
 	}
+	c.Profile = profile
+	err = c.loadCredentials()
+	if err != nil {
+		return *c, err
+	}
 	return *c, nil
 }
 
@@ -114,17 +134,29 @@ func (c *Context) SetContext() error {
 		return err
 	}
 
+	token, refreshToken := c.Token, c.RefreshToken
+	if store := CredentialStore(); store != nil {
+		err = c.storeCredentials(store, credentials.Credentials{Token: c.Token, RefreshToken: c.RefreshToken})
+		if err != nil {
+			return err
+		}
+		token, refreshToken = "", ""
+	}
+
 	context := map[string]string{
-		"token":                   c.Token,
+		"token":                   token,
 		"domain":                  c.Domain,
 		"organization":            c.Organization,
 		"organization_short_name": c.OrganizationShortName,
 		"organization_product":    c.OrganizationProduct,
 		"workspace":               c.Workspace,
 		"last_used_workspace":     c.Workspace,
-		"refreshtoken":            c.RefreshToken,
+		"refreshtoken":            refreshToken,
 		"user_email":              c.UserEmail,
 	}
+	if profile := c.resolvedProfile(); profile != DefaultProfile {
+		context["profile"] = profile
+	}
 
 	viperHome.Set("contexts"+"."+key, context)
 	err = saveConfig(viperHome, HomeConfigFile)
@@ -142,14 +174,29 @@ func (c *Context) SetContextKey(key, value string) error {
 		return err
 	}
 
-	cfgPath := fmt.Sprintf("contexts.%s.%s", cKey, key)
-	viperHome.Set(cfgPath, value)
-	err = saveConfig(viperHome, HomeConfigFile)
-	if err != nil {
-		return err
+	if store := CredentialStore(); store != nil && (key == tokenKey || key == refreshTokenKey) {
+		err = c.setCredentialKey(store, key, value)
+		if err != nil {
+			return err
+		}
+		// a token saved in config.yaml before the store was configured is removed
+		value = ""
 	}
 
-	return nil
+	return setContextValue(cKey, key, value)
+}
+
+// setContextValue saves a single value of the context with key cKey. The whole context is set because viper only
+// returns the values of a context that were set in the process once one of them is set.
+func setContextValue(cKey, key string, value interface{}) error {
+	cfgPath := fmt.Sprintf("%s.%s", contextsKey, cKey)
+	context := map[string]interface{}{}
+	for k, v := range viperHome.GetStringMap(cfgPath) {
+		context[k] = v
+	}
+	context[key] = value
+	viperHome.Set(cfgPath, context)
+	return saveConfig(viperHome, HomeConfigFile)
 }
 
 // set organization id and short name in context config
@@ -178,7 +225,7 @@ func (c *Context) SwitchContext() error {
 		return err
 	}
 
-	viperHome.Set("context", ctx.Domain)
+	viperHome.Set("context", ctx.Name())
 	err = saveConfig(viperHome, HomeConfigFile)
 	if err != nil {
 		return err
@@ -194,6 +241,12 @@ func (c *Context) DeleteContext() error {
 	}
 	// Since viper does not have a way to unset or delete a key,
 	// hence getting all contexts and delete the required context
+	if store := CredentialStore(); store != nil {
+		err = store.Erase(c.Name())
+		if err != nil {
+			return err
+		}
+	}
 	contexts := viperHome.Get(contextsKey).(map[string]interface{})
 	delete(contexts, cKey)
 	viperHome.Set(contextsKey, contexts)
@@ -212,14 +265,7 @@ func (c *Context) SetExpiresIn(value int64) error {
 
 	expiretime := time.Now().Add(time.Duration(value) * time.Second)
 
-	cfgPath := fmt.Sprintf("contexts.%s.%s", cKey, "ExpiresIn")
-	viperHome.Set(cfgPath, expiretime)
-	err = saveConfig(viperHome, HomeConfigFile)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return setContextValue(cKey, "ExpiresIn", expiretime)
 }
 
 func (c *Context) GetExpiresIn() (time.Time, error) {
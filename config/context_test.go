@@ -165,7 +165,7 @@ func TestGetContexts(t *testing.T) {
 	initTestConfig()
 	ctxs, err := GetContexts()
 	assert.NoError(t, err)
-	assert.Equal(t, Contexts{Contexts: map[string]Context{"test_com": {"test.com", "test-org-id", "test-org-short-name", "", "ck05r3bor07h40d02y2hw4n4v", "ck05r3bor07h40d02y2hw4n4v", "token", "", ""}, "example_com": {"example.com", "test-org-id", "test-org-short-name", "", "ck05r3bor07h40d02y2hw4n4v", "ck05r3bor07h40d02y2hw4n4v", "token", "", ""}}}, ctxs)
+	assert.Equal(t, Contexts{Contexts: map[string]Context{"test_com": {"test.com", "test-org-id", "test-org-short-name", "", "ck05r3bor07h40d02y2hw4n4v", "ck05r3bor07h40d02y2hw4n4v", "token", "", "", ""}, "example_com": {"example.com", "test-org-id", "test-org-short-name", "", "ck05r3bor07h40d02y2hw4n4v", "ck05r3bor07h40d02y2hw4n4v", "token", "", "", ""}}}, ctxs)
 }
 
 func TestSetContextKey(t *testing.T) {
@@ -177,6 +177,21 @@ func TestSetContextKey(t *testing.T) {
 	assert.Equal(t, "test", outCtx.Token)
 }
 
+func TestSetContextKeyKeepsContext(t *testing.T) {
+	initTestConfig()
+	ctx := Context{Domain: "test.com"}
+	err := ctx.SetContextKey("token", "Bearer new-token")
+	assert.NoError(t, err)
+	err = ctx.SetExpiresIn(60)
+	assert.NoError(t, err)
+
+	outCtx, err := GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "test.com", outCtx.Domain)
+	assert.Equal(t, "test-org-id", outCtx.Organization)
+	assert.Equal(t, "Bearer new-token", outCtx.Token)
+}
+
 func TestSetOrganizationContext(t *testing.T) {
 	initTestConfig()
 	t.Run("set organization context", func(t *testing.T) {
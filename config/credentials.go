@@ -0,0 +1,173 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/astronomer/astro-cli/pkg/credentials"
+	"github.com/astronomer/astro-cli/pkg/input"
+)
+
+const (
+	// PlaintextCredentialStore keeps tokens in config.yaml
+	PlaintextCredentialStore = "plaintext"
+	// FileCredentialStore keeps tokens in an encrypted file next to config.yaml, any other store is a credential helper
+	FileCredentialStore = "file"
+
+	credentialsFileName = "credentials.enc"
+	// CredentialsPassphraseEnv is the passphrase of the encrypted credentials file
+	CredentialsPassphraseEnv = "ASTRO_CREDENTIALS_PASSPHRASE" //nolint:gosec
+
+	tokenKey        = "token"
+	refreshTokenKey = "refreshtoken"
+)
+
+var (
+	errPlaintextStore = errors.New("credentials.store is plaintext, set it to file or the name of a credential helper to move tokens out of config.yaml")
+
+	// cachedStore is reused while the store config does not change, so the credentials file is decrypted once
+	cachedStore    credentials.Store
+	cachedStoreKey string
+
+	// Monkey patched to write unit tests
+	promptPassphrase = func() (string, error) {
+		if input.NoInput() {
+			return "", input.Required("set " + CredentialsPassphraseEnv + " or credentials.key_file")
+		}
+		return input.Password("Passphrase of the Astro credentials file: ")
+	}
+)
+
+// CredentialStore returns the store of tokens configured with credentials.store or nil if tokens are kept in config.yaml
+func CredentialStore() credentials.Store {
+	name := CFG.CredentialsStore.GetGlobalString()
+	if name == "" || name == PlaintextCredentialStore {
+		return nil
+	}
+	keyFile := CFG.CredentialsKeyFile.GetGlobalString()
+	storeKey := name + ":" + HomeConfigPath + ":" + keyFile
+	if cachedStore != nil && cachedStoreKey == storeKey {
+		return cachedStore
+	}
+
+	if name == FileCredentialStore {
+		cachedStore = credentials.NewFileStore(filepath.Join(HomeConfigPath, credentialsFileName), func() (string, error) {
+			return credentialsPassphrase(keyFile)
+		})
+	} else {
+		cachedStore = credentials.NewHelperStore(name)
+	}
+	cachedStoreKey = storeKey
+	return cachedStore
+}
+
+// credentialsPassphrase returns the passphrase of the credentials file from the environment, the key file or a prompt
+func credentialsPassphrase(keyFile string) (string, error) {
+	if passphrase := os.Getenv(CredentialsPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if keyFile != "" {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading credentials.key_file: %w", err)
+		}
+		return strings.TrimSpace(string(key)), nil
+	}
+	return promptPassphrase()
+}
+
+// loadCredentials sets the tokens of c from the credential store. Tokens that were saved in config.yaml before the
+// store was configured are kept until they are migrated.
+func (c *Context) loadCredentials() error {
+	store := CredentialStore()
+	if store == nil {
+		return nil
+	}
+	creds, err := store.Get(c.Name())
+	if errors.Is(err, credentials.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.Token = creds.Token
+	c.RefreshToken = creds.RefreshToken
+	return nil
+}
+
+// storeCredentials saves creds in store for the name of c, empty credentials are erased
+func (c *Context) storeCredentials(store credentials.Store, creds credentials.Credentials) error {
+	if creds.IsEmpty() {
+		return store.Erase(c.Name())
+	}
+	return store.Store(c.Name(), creds)
+}
+
+// setCredentialKey saves a single token of c in store
+func (c *Context) setCredentialKey(store credentials.Store, key, value string) error {
+	creds, err := store.Get(c.Name())
+	if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+		return err
+	}
+	if key == tokenKey {
+		creds.Token = value
+	} else {
+		creds.RefreshToken = value
+	}
+	return c.storeCredentials(store, creds)
+}
+
+// MigrateCredentials moves the tokens of every context from config.yaml to the configured credential store.
+// It returns the names of the contexts whose tokens were moved.
+func MigrateCredentials() ([]string, error) {
+	store := CredentialStore()
+	if store == nil {
+		return nil, errPlaintextStore
+	}
+	contexts, err := GetContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(contexts.Contexts))
+	for key := range contexts.Contexts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var migrated []string
+	for _, key := range keys {
+		ctx := contexts.Contexts[key]
+		creds := credentials.Credentials{Token: ctx.Token, RefreshToken: ctx.RefreshToken}
+		if creds.IsEmpty() {
+			continue
+		}
+		domain, profile := ParseContextName(key)
+		if ctx.Domain == "" {
+			ctx.Domain = strings.Replace(domain, "_", ".", -1)
+		}
+		ctx.Profile = profile
+		if profile == "" {
+			ctx.Profile = DefaultProfile
+		}
+		name := ctx.Name()
+		err = store.Store(name, creds)
+		if err != nil {
+			return migrated, fmt.Errorf("error moving the tokens of %s: %w", name, err)
+		}
+		err = setContextValue(key, tokenKey, "")
+		if err != nil {
+			return migrated, err
+		}
+		err = setContextValue(key, refreshTokenKey, "")
+		if err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, name)
+	}
+	return migrated, nil
+}
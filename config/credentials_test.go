@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astronomer/astro-cli/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+// initFileStoreTestConfig uses an encrypted credentials file in a temporary directory
+func initFileStoreTestConfig(t *testing.T) {
+	initTestConfig()
+	homeConfigPath := HomeConfigPath
+	t.Cleanup(func() { HomeConfigPath = homeConfigPath })
+	HomeConfigPath = t.TempDir()
+	t.Setenv(CredentialsPassphraseEnv, "secret")
+	err := CFG.CredentialsStore.SetHomeString(FileCredentialStore)
+	assert.NoError(t, err)
+}
+
+func TestCredentialStore(t *testing.T) {
+	t.Run("keeps tokens in config.yaml by default", func(t *testing.T) {
+		initTestConfig()
+		assert.Nil(t, CredentialStore())
+
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "token", ctx.Token)
+	})
+
+	t.Run("returns a credential helper", func(t *testing.T) {
+		initTestConfig()
+		err := CFG.CredentialsStore.SetHomeString("pass")
+		assert.NoError(t, err)
+		assert.IsType(t, &credentials.HelperStore{}, CredentialStore())
+	})
+
+	t.Run("reads the passphrase from the key file", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "key")
+		err := os.WriteFile(keyFile, []byte("secret\n"), 0o600)
+		assert.NoError(t, err)
+		passphrase, err := credentialsPassphrase(keyFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", passphrase)
+
+		t.Setenv(CredentialsPassphraseEnv, "from-env")
+		passphrase, err = credentialsPassphrase(keyFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "from-env", passphrase)
+	})
+
+	t.Run("prompts for the passphrase", func(t *testing.T) {
+		defer func(prompt func() (string, error)) { promptPassphrase = prompt }(promptPassphrase)
+		promptPassphrase = func() (string, error) { return "prompted", nil }
+		passphrase, err := credentialsPassphrase("")
+		assert.NoError(t, err)
+		assert.Equal(t, "prompted", passphrase)
+	})
+}
+
+func TestContextCredentialsInStore(t *testing.T) {
+	initFileStoreTestConfig(t)
+
+	ctx, err := GetCurrentContext()
+	assert.NoError(t, err)
+	ctx.Token = "Bearer new-token"
+	ctx.RefreshToken = "new-refresh-token"
+	err = ctx.SetContext()
+	assert.NoError(t, err)
+
+	// the tokens are not in config.yaml
+	assert.Empty(t, viperHome.GetString("contexts.test_com.token"))
+	assert.Empty(t, viperHome.GetString("contexts.test_com.refreshtoken"))
+
+	ctx, err = GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer new-token", ctx.Token)
+	assert.Equal(t, "new-refresh-token", ctx.RefreshToken)
+
+	err = ctx.SetContextKey("token", "Bearer refreshed-token")
+	assert.NoError(t, err)
+	assert.Empty(t, viperHome.GetString("contexts.test_com.token"))
+	creds, err := CredentialStore().Get("test.com")
+	assert.NoError(t, err)
+	assert.Equal(t, credentials.Credentials{Token: "Bearer refreshed-token", RefreshToken: "new-refresh-token"}, creds)
+
+	err = ctx.DeleteContext()
+	assert.NoError(t, err)
+	_, err = CredentialStore().Get("test.com")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestMigrateCredentials(t *testing.T) {
+	t.Run("moves tokens out of config.yaml", func(t *testing.T) {
+		initFileStoreTestConfig(t)
+
+		migrated, err := MigrateCredentials()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"example.com", "test.com"}, migrated)
+		assert.Empty(t, viperHome.GetString("contexts.example_com.token"))
+		assert.Empty(t, viperHome.GetString("contexts.test_com.token"))
+
+		for _, domain := range migrated {
+			creds, err := CredentialStore().Get(domain)
+			assert.NoError(t, err)
+			assert.Equal(t, "token", creds.Token)
+		}
+
+		// there is nothing left to migrate
+		migrated, err = MigrateCredentials()
+		assert.NoError(t, err)
+		assert.Empty(t, migrated)
+	})
+
+	t.Run("keeps the other values of the contexts", func(t *testing.T) {
+		initFileStoreTestConfig(t)
+
+		_, err := MigrateCredentials()
+		assert.NoError(t, err)
+
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "test.com", ctx.Domain)
+		assert.Equal(t, "test-org-id", ctx.Organization)
+		assert.Equal(t, "ck05r3bor07h40d02y2hw4n4v", ctx.Workspace)
+
+		// read config.yaml again so only the values that were saved are left
+		initHome(configFs)
+		for _, key := range []string{"example_com", "test_com"} {
+			ctx := viperHome.GetStringMapString(contextsKey + "." + key)
+			assert.Equal(t, "test-org-id", ctx["organization"])
+			assert.Equal(t, "test-org-short-name", ctx["organization_short_name"])
+			assert.Equal(t, "ck05r3bor07h40d02y2hw4n4v", ctx["workspace"])
+			assert.Empty(t, ctx[tokenKey])
+		}
+	})
+
+	t.Run("returns an error for the plaintext store", func(t *testing.T) {
+		initTestConfig()
+		_, err := MigrateCredentials()
+		assert.ErrorIs(t, err, errPlaintextStore)
+	})
+}
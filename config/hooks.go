@@ -0,0 +1,32 @@
+package config
+
+import "fmt"
+
+const deployHooksKey = "hooks"
+
+// DeployHooks holds the commands run around a deploy, as set under `hooks` in the project config
+type DeployHooks struct {
+	PreBuild   []DeployHook `mapstructure:"pre_build"`
+	PostPush   []DeployHook `mapstructure:"post_push"`
+	PostDeploy []DeployHook `mapstructure:"post_deploy"`
+}
+
+// DeployHook is a single shell command run at a phase of a deploy
+type DeployHook struct {
+	Name    string `mapstructure:"name"`
+	Command string `mapstructure:"command"`
+	// Timeout is a duration such as 30s or 5m, a default applies when empty
+	Timeout string `mapstructure:"timeout"`
+}
+
+// GetDeployHooks returns the deploy hooks of the current project, hooks are never read from the home config
+func GetDeployHooks() (DeployHooks, error) {
+	hooks := DeployHooks{}
+	if !configExists(viperProject) || !viperProject.IsSet(deployHooksKey) {
+		return hooks, nil
+	}
+	if err := viperProject.UnmarshalKey(deployHooksKey, &hooks); err != nil {
+		return hooks, fmt.Errorf("error reading deploy hooks from the project config: %w", err)
+	}
+	return hooks, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func initProjectConfig(t *testing.T, configRaw []byte) {
+	fs := afero.NewMemMapFs()
+	workingConfigFile := filepath.Join(WorkingPath, ConfigDir, ConfigFileNameWithExt)
+	assert.NoError(t, afero.WriteFile(fs, workingConfigFile, configRaw, 0o777))
+	initProject(fs)
+}
+
+func TestGetDeployHooks(t *testing.T) {
+	t.Run("hooks set", func(t *testing.T) {
+		initProjectConfig(t, []byte(`
+hooks:
+  pre_build:
+    - name: generate dags
+      command: python scripts/generate_dags.py
+      timeout: 2m
+  post_deploy:
+    - command: ./notify.sh
+`))
+		hooks, err := GetDeployHooks()
+		assert.NoError(t, err)
+		assert.Equal(t, []DeployHook{{Name: "generate dags", Command: "python scripts/generate_dags.py", Timeout: "2m"}}, hooks.PreBuild)
+		assert.Empty(t, hooks.PostPush)
+		assert.Equal(t, []DeployHook{{Command: "./notify.sh"}}, hooks.PostDeploy)
+	})
+
+	t.Run("no hooks", func(t *testing.T) {
+		initProjectConfig(t, []byte("project:\n  name: test\n"))
+		hooks, err := GetDeployHooks()
+		assert.NoError(t, err)
+		assert.Equal(t, DeployHooks{}, hooks)
+	})
+
+	t.Run("invalid hooks", func(t *testing.T) {
+		initProjectConfig(t, []byte("hooks:\n  pre_build: not-a-list\n"))
+		_, err := GetDeployHooks()
+		assert.Error(t, err)
+	})
+}
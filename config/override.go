@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// OrganizationEnv picks the organization for a single command, like --organization
+	OrganizationEnv = "ASTRO_ORGANIZATION"
+	// WorkspaceEnv picks the workspace for a single command, like --workspace
+	WorkspaceEnv = "ASTRO_WORKSPACE"
+)
+
+var (
+	errContextNotFound = errors.New("is not a context, run astro context list to see your contexts")
+
+	// contextOverride, organizationOverride and workspaceOverride are picked with --context, --organization and
+	// --workspace, they are only used by the current process and are not saved in the config
+	contextOverride      string
+	organizationOverride string
+	workspaceOverride    string
+	// offlineOverride is picked with --offline
+	offlineOverride bool
+
+	// resolvedOrganization is the organization that the organization override was resolved to
+	resolvedOrganization struct {
+		name, id, shortName, product string
+	}
+)
+
+// SetContextOverride picks the context of the rest of the command without switching to it.
+// name is a domain or a domain followed by @ and a profile.
+func SetContextOverride(name string) error {
+	if name != "" {
+		domain, profile := ParseContextName(name)
+		if profile == "" {
+			profile = DefaultProfile
+		}
+		if err := ValidateProfile(profile); err != nil {
+			return err
+		}
+		c := Context{Domain: domain, Profile: profile}
+		if !c.ContextExists() {
+			return fmt.Errorf("%s %w", name, errContextNotFound)
+		}
+	}
+	contextOverride = name
+	return nil
+}
+
+// ContextOverride returns the context picked with --context, it is empty if none is picked
+func ContextOverride() string {
+	return contextOverride
+}
+
+// SetOrganizationOverride picks the organization of the current context for the rest of the command
+func SetOrganizationOverride(organization string) {
+	organizationOverride = organization
+}
+
+// OrganizationOverride returns the organization picked with --organization or ASTRO_ORGANIZATION, it is empty if
+// none is picked. It is an organization ID, short name or name until it is resolved by ResolveOrganizationOverride.
+func OrganizationOverride() string {
+	if organizationOverride != "" {
+		return organizationOverride
+	}
+	return os.Getenv(OrganizationEnv)
+}
+
+// ResolveOrganizationOverride sets the ID, short name and product of the organization picked with --organization
+func ResolveOrganizationOverride(id, shortName, product string) {
+	resolvedOrganization.name = OrganizationOverride()
+	resolvedOrganization.id = id
+	resolvedOrganization.shortName = shortName
+	resolvedOrganization.product = product
+}
+
+// SetWorkspaceOverride picks the workspace of the current context for the rest of the command
+func SetWorkspaceOverride(workspace string) {
+	workspaceOverride = workspace
+}
+
+// WorkspaceOverride returns the workspace picked with --workspace or ASTRO_WORKSPACE, it is empty if none is picked
+func WorkspaceOverride() string {
+	if workspaceOverride != "" {
+		return workspaceOverride
+	}
+	return os.Getenv(WorkspaceEnv)
+}
+
+// SetOfflineOverride turns on offline mode for the rest of the command
+func SetOfflineOverride(offline bool) {
+	offlineOverride = offline
+}
+
+// IsOffline is true if the command was run with --offline or the offline setting is true. Offline, the CLI does not
+// check for updates and only uses cached runtime versions.
+func IsOffline() bool {
+	if offlineOverride {
+		return true
+	}
+	offline, _ := strconv.ParseBool(CFG.Offline.GetGlobalString())
+	return offline
+}
+
+// currentContextName returns the name of the context picked with --context, else the name of the current context
+func currentContextName() string {
+	if contextOverride != "" {
+		return contextOverride
+	}
+	return CFG.Context.GetGlobalString()
+}
+
+// applyOverrides replaces the organization and workspace of c with the ones picked for the current command.
+// The workspace of c is dropped when another organization is picked without a workspace, as it belongs to the
+// organization of c.
+func (c *Context) applyOverrides() {
+	if org := OrganizationOverride(); org != "" {
+		id, shortName, product := org, "", ""
+		if resolvedOrganization.name == org {
+			id, shortName, product = resolvedOrganization.id, resolvedOrganization.shortName, resolvedOrganization.product
+		}
+		if id != c.Organization && org != c.OrganizationShortName {
+			c.Organization = id
+			c.OrganizationShortName = shortName
+			c.OrganizationProduct = product
+			c.Workspace = ""
+			c.LastUsedWorkspace = ""
+		}
+	}
+	if workspace := WorkspaceOverride(); workspace != "" {
+		c.Workspace = workspace
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetContextOverride(t *testing.T) {
+	initProfileTestConfig()
+	defer func() { _ = SetContextOverride("") }()
+
+	err := SetContextOverride("example.com")
+	assert.NoError(t, err)
+	ctx, err := GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", ctx.Domain)
+	assert.Equal(t, "example-token", ctx.Token)
+	assert.Equal(t, "test.com", CFG.Context.GetHomeString())
+
+	err = SetContextOverride("test.com@ci")
+	assert.NoError(t, err)
+	ctx, err = GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-token", ctx.Token)
+	assert.Equal(t, "test.com@ci", ctx.Name())
+
+	err = SetContextOverride("unknown.com")
+	assert.ErrorIs(t, err, errContextNotFound)
+	err = SetContextOverride("test.com@Not.Valid")
+	assert.ErrorIs(t, err, errInvalidProfile)
+	assert.Equal(t, "test.com@ci", ContextOverride())
+}
+
+func TestOrganizationAndWorkspaceOverrides(t *testing.T) {
+	t.Run("replaces the workspace", func(t *testing.T) {
+		initProfileTestConfig()
+		t.Setenv(WorkspaceEnv, "env-workspace-id")
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "env-workspace-id", ctx.Workspace)
+		assert.Equal(t, "test-org-id", ctx.Organization)
+
+		SetWorkspaceOverride("flag-workspace-id")
+		defer SetWorkspaceOverride("")
+		ctx, err = GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "flag-workspace-id", ctx.Workspace)
+
+		// the override is not saved
+		ctx, err = (&Context{Domain: "test.com"}).GetContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "test-workspace-id", ctx.Workspace)
+	})
+
+	t.Run("replaces the organization and drops its workspace", func(t *testing.T) {
+		initProfileTestConfig()
+		SetOrganizationOverride("other-org")
+		defer SetOrganizationOverride("")
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "other-org", ctx.Organization)
+		assert.Equal(t, "", ctx.OrganizationShortName)
+		assert.Equal(t, "", ctx.Workspace)
+
+		ResolveOrganizationOverride("other-org-id", "otherorg", "HOSTED")
+		t.Setenv(WorkspaceEnv, "other-workspace-id")
+		ctx, err = GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "other-org-id", ctx.Organization)
+		assert.Equal(t, "otherorg", ctx.OrganizationShortName)
+		assert.Equal(t, "HOSTED", ctx.OrganizationProduct)
+		assert.Equal(t, "other-workspace-id", ctx.Workspace)
+	})
+
+	t.Run("keeps the workspace of the current organization", func(t *testing.T) {
+		initProfileTestConfig()
+		t.Setenv(OrganizationEnv, "test-org-id")
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "test-org-id", ctx.Organization)
+		assert.Equal(t, "test-workspace-id", ctx.Workspace)
+	})
+}
+
+func TestIsOffline(t *testing.T) {
+	initProfileTestConfig()
+	defer SetOfflineOverride(false)
+
+	assert.False(t, IsOffline())
+	t.Setenv(CFG.Offline.EnvName(), "true")
+	assert.True(t, IsOffline())
+	t.Setenv(CFG.Offline.EnvName(), "false")
+	SetOfflineOverride(true)
+	assert.True(t, IsOffline())
+}
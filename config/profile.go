@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultProfile is the profile of a domain that was logged in to without a profile
+	DefaultProfile = "default"
+	// ProfileEnv picks the profile of a domain for a single command, like --profile
+	ProfileEnv = "ASTRO_PROFILE"
+
+	// profileSeparator separates the domain and profile in context names like astronomer.io@ci
+	profileSeparator = "@"
+)
+
+var (
+	errInvalidProfile = errors.New("is not a valid profile name, it must start with a lowercase letter or number and only contain lowercase letters, numbers, - and _")
+
+	profileRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+	// profileOverride is the profile picked with --profile, it is not saved in the config
+	profileOverride string
+)
+
+// ValidateProfile returns an error if profile can not be used as the name of a profile
+func ValidateProfile(profile string) error {
+	if !profileRegex.MatchString(profile) {
+		return fmt.Errorf("%s %w", profile, errInvalidProfile)
+	}
+	return nil
+}
+
+// SetProfileOverride picks the profile of contexts for the rest of the command without changing the current context
+func SetProfileOverride(profile string) error {
+	if profile != "" {
+		if err := ValidateProfile(profile); err != nil {
+			return err
+		}
+	}
+	profileOverride = profile
+	return nil
+}
+
+// ProfileOverride returns the profile picked with --profile or ASTRO_PROFILE, it is empty if none is picked
+func ProfileOverride() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	return os.Getenv(ProfileEnv)
+}
+
+// ParseContextName splits a context name like astronomer.io@ci into its domain and profile.
+// The profile of a name without one is empty.
+func ParseContextName(name string) (domain, profile string) {
+	if i := strings.LastIndex(name, profileSeparator); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// Name returns the name of the context, its domain followed by @ and its profile unless it is the default profile
+func (c *Context) Name() string {
+	profile := c.resolvedProfile()
+	if profile == DefaultProfile {
+		return c.Domain
+	}
+	return c.Domain + profileSeparator + profile
+}
+
+// resolvedProfile returns the profile of c. A context without a profile uses the profile picked with --profile or
+// ASTRO_PROFILE, else the current profile if its domain is the current domain, else the default profile.
+// The current context is the one picked with --context if there is one.
+func (c *Context) resolvedProfile() string {
+	if c.Profile != "" {
+		return c.Profile
+	}
+	if profile := ProfileOverride(); profile != "" {
+		return profile
+	}
+	currentDomain, currentProfile := ParseContextName(currentContextName())
+	if currentProfile != "" && domainKey(currentDomain) == domainKey(c.Domain) {
+		return currentProfile
+	}
+	return DefaultProfile
+}
+
+// domainKey allows a domain to be used without interfering with viper's dot (.) notation
+func domainKey(domain string) string {
+	return strings.Replace(domain, ".", "_", -1)
+}
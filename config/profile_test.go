@@ -0,0 +1,132 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// initProfileTestConfig has a default and a ci profile for test.com
+func initProfileTestConfig() {
+	fs := afero.NewMemMapFs()
+	configRaw := []byte(`
+context: test.com
+contexts:
+  test_com:
+    domain: test.com
+    organization: test-org-id
+    token: token
+    workspace: test-workspace-id
+  test_com@ci:
+    domain: test.com
+    profile: ci
+    organization: ci-org-id
+    token: ci-token
+    workspace: ci-workspace-id
+  example_com:
+    domain: example.com
+    token: example-token
+`)
+	_ = afero.WriteFile(fs, HomeConfigFile, configRaw, 0o777)
+	InitConfig(fs)
+}
+
+func TestParseContextName(t *testing.T) {
+	domain, profile := ParseContextName("astronomer.io@ci")
+	assert.Equal(t, "astronomer.io", domain)
+	assert.Equal(t, "ci", profile)
+
+	domain, profile = ParseContextName("astronomer.io")
+	assert.Equal(t, "astronomer.io", domain)
+	assert.Equal(t, "", profile)
+}
+
+func TestValidateProfile(t *testing.T) {
+	for _, profile := range []string{"ci", "my_org-2", "default"} {
+		assert.NoError(t, ValidateProfile(profile))
+	}
+	for _, profile := range []string{"", "CI", "-ci", "ci.prod", "ci@prod"} {
+		assert.ErrorIs(t, ValidateProfile(profile), errInvalidProfile)
+	}
+	assert.ErrorIs(t, SetProfileOverride("ci.prod"), errInvalidProfile)
+}
+
+func TestProfiles(t *testing.T) {
+	t.Run("switches to a profile", func(t *testing.T) {
+		initProfileTestConfig()
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "token", ctx.Token)
+		assert.Equal(t, DefaultProfile, ctx.Profile)
+		assert.Equal(t, "test.com", ctx.Name())
+
+		ci := Context{Domain: "test.com", Profile: "ci"}
+		err = ci.SwitchContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "test.com@ci", CFG.Context.GetHomeString())
+
+		ctx, err = GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-token", ctx.Token)
+		assert.Equal(t, "ci-org-id", ctx.Organization)
+		assert.Equal(t, "ci", ctx.Profile)
+		assert.Equal(t, "test.com@ci", ctx.Name())
+
+		// a context of the current domain without a profile is the current profile
+		sameDomain := Context{Domain: "test.com"}
+		key, err := sameDomain.GetContextKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "test_com@ci", key)
+		other := Context{Domain: "example.com"}
+		key, err = other.GetContextKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "example_com", key)
+	})
+
+	t.Run("picks a profile without switching to it", func(t *testing.T) {
+		initProfileTestConfig()
+		defer func() { _ = SetProfileOverride("") }()
+		err := SetProfileOverride("ci")
+		assert.NoError(t, err)
+
+		ctx, err := GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-token", ctx.Token)
+		assert.Equal(t, "test.com", CFG.Context.GetHomeString())
+
+		err = SetProfileOverride("")
+		assert.NoError(t, err)
+		t.Setenv(ProfileEnv, "ci")
+		ctx, err = GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "ci-workspace-id", ctx.Workspace)
+
+		// the default profile can be picked when another one is current
+		t.Setenv(ProfileEnv, DefaultProfile)
+		ci := Context{Domain: "test.com", Profile: "ci"}
+		err = ci.SwitchContext()
+		assert.NoError(t, err)
+		ctx, err = GetCurrentContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "token", ctx.Token)
+	})
+
+	t.Run("saves a new profile", func(t *testing.T) {
+		initProfileTestConfig()
+		ctx := Context{Domain: "test.com", Profile: "personal", Token: "personal-token", Organization: "personal-org-id"}
+		err := ctx.SetContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "personal", viperHome.GetStringMapString("contexts.test_com@personal")["profile"])
+
+		ctx, err = (&Context{Domain: "test.com", Profile: "personal"}).GetContext()
+		assert.NoError(t, err)
+		assert.Equal(t, "personal-token", ctx.Token)
+		assert.Equal(t, "personal-org-id", ctx.Organization)
+
+		err = ctx.DeleteContext()
+		assert.NoError(t, err)
+		assert.False(t, ctx.ContextExists())
+		assert.True(t, (&Context{Domain: "test.com", Profile: DefaultProfile}).ContextExists())
+	})
+}
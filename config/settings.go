@@ -0,0 +1,203 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// SourceDefault, SourceHome, SourceProject and SourceEnv are where the value of a setting comes from
+	SourceDefault = "default"
+	SourceHome    = "home"
+	SourceProject = "project"
+	SourceEnv     = "env"
+
+	envPrefix = "ASTRO_"
+)
+
+var (
+	errInvalidValue = errors.New("is not a valid value for")
+	errMapSetting   = errors.New("can not be set with astro config, it is managed by other commands")
+
+	// sensitivePathParts are parts of the paths of settings whose values are credentials
+	sensitivePathParts = []string{"token", "password", "secret"}
+)
+
+// Cfgs returns the settings that can be managed with astro config, sorted by path
+func Cfgs() []cfg { //nolint:revive
+	cfgs := make([]cfg, 0, len(CFGStrMap))
+	for _, c := range CFGStrMap {
+		if c.Type == mapCfg {
+			continue
+		}
+		cfgs = append(cfgs, c)
+	}
+	sort.Slice(cfgs, func(i, j int) bool { return cfgs[i].Path < cfgs[j].Path })
+	return cfgs
+}
+
+// EnvName returns the environment variable that overrides the setting, like ASTRO_PAGE_SIZE for page_size
+func (c cfg) EnvName() string {
+	name := strings.NewReplacer(".", "_", "-", "_").Replace(c.Path)
+	return envPrefix + strings.ToUpper(name)
+}
+
+// Sensitive is true if the value of the setting is a credential, like cloud.api.token or postgres.password
+func (c cfg) Sensitive() bool {
+	for _, part := range sensitivePathParts {
+		if strings.Contains(c.Path, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupEnv returns the value of the environment variable of the setting and whether it is set
+func (c cfg) lookupEnv() (string, bool) {
+	if c.Type == mapCfg {
+		return "", false
+	}
+	return os.LookupEnv(c.EnvName())
+}
+
+// Validate returns an error if value can not be used as the value of the setting
+func (c cfg) Validate(value string) error {
+	var err error
+	switch c.Type {
+	case stringCfg:
+		// any string is valid
+	case boolCfg:
+		_, err = strconv.ParseBool(value)
+	case intCfg:
+		_, err = strconv.Atoi(value)
+	case durationCfg:
+		_, err = time.ParseDuration(value)
+	case mapCfg:
+		return fmt.Errorf("%s %w", c.Path, errMapSetting)
+	}
+	if err != nil {
+		return fmt.Errorf("%s %w %s, it must be of type %s", value, errInvalidValue, c.Path, c.Type)
+	}
+	return nil
+}
+
+// GetGlobalString returns the value of a setting that is only read from the home config, unless it is overridden
+// by its environment variable
+func (c cfg) GetGlobalString() string {
+	if value, ok := c.lookupEnv(); ok {
+		return value
+	}
+	return c.GetHomeString()
+}
+
+// GetEffective returns the value of the setting that is used and where it comes from, the environment, the project
+// config, the home config or the default value
+func (c cfg) GetEffective() (value, source string) {
+	if env, ok := c.lookupEnv(); ok {
+		return env, SourceEnv
+	}
+	if c.InProject() {
+		return c.GetProjectString(), SourceProject
+	}
+	if c.InHome() {
+		return c.GetHomeString(), SourceHome
+	}
+	return c.Default, SourceDefault
+}
+
+// InHome is true if the setting is in the home config file with a value other than its default. Viper writes
+// default values to the home config file when it is saved, they are not considered set.
+func (c cfg) InHome() bool {
+	return inConfigFile(viperHome, c.Path) && c.GetHomeString() != c.Default
+}
+
+// InProject is true if the setting is in the project config file
+func (c cfg) InProject() bool {
+	return configExists(viperProject) && inConfigFile(viperProject, c.Path)
+}
+
+// UnsetHome removes the setting from the home config file, its default value is used instead
+func (c cfg) UnsetHome() error {
+	if !configExists(viperHome) {
+		return nil
+	}
+	err := unsetConfigFileKey(viperHome.ConfigFileUsed(), c.Path)
+	if err != nil {
+		return err
+	}
+	// viper can not unset a key, the home config is read again without it
+	initHome(configFs)
+	return nil
+}
+
+// UnsetProject removes the setting from the project config file, the home config or default value is used instead
+func (c cfg) UnsetProject() error {
+	if !configExists(viperProject) {
+		return nil
+	}
+	file := viperProject.ConfigFileUsed()
+	err := unsetConfigFileKey(file, c.Path)
+	if err != nil {
+		return err
+	}
+	// viper can not unset a key, the project config is read again without it
+	viperProject = newFileViper(file)
+	return viperProject.ReadInConfig()
+}
+
+// inConfigFile is true if path is set in the config file of v. The file is read as values that are set are also kept
+// in memory by viper.
+func inConfigFile(v *viper.Viper, path string) bool {
+	if !configExists(v) {
+		return false
+	}
+	fileViper := newFileViper(v.ConfigFileUsed())
+	if err := fileViper.ReadInConfig(); err != nil {
+		return false
+	}
+	return fileViper.InConfig(path)
+}
+
+// unsetConfigFileKey removes path and the sections it leaves empty from a config file
+func unsetConfigFileKey(file, path string) error {
+	fileViper := newFileViper(file)
+	if err := fileViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("error reading config: %w", err)
+	}
+	settings := fileViper.AllSettings()
+	deleteSetting(settings, strings.Split(strings.ToLower(path), "."))
+
+	out := newFileViper(file)
+	if err := out.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	return saveConfig(out, file)
+}
+
+// deleteSetting removes the setting at path from settings, it returns true if settings is left empty
+func deleteSetting(settings map[string]interface{}, path []string) bool {
+	if len(path) == 1 {
+		delete(settings, path[0])
+		return len(settings) == 0
+	}
+	section, ok := settings[path[0]].(map[string]interface{})
+	if ok && deleteSetting(section, path[1:]) {
+		delete(settings, path[0])
+	}
+	return len(settings) == 0
+}
+
+func newFileViper(file string) *viper.Viper {
+	v := viper.New()
+	v.SetFs(configFs)
+	v.SetConfigFile(file)
+	v.SetConfigType(ConfigFileType)
+	return v
+}
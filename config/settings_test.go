@@ -0,0 +1,145 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// initSettingsTestConfig has settings in the home and project configs
+func initSettingsTestConfig(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	homeRaw := []byte(`
+page_size: 50
+postgres:
+  user: home-user
+  host: home-host
+`)
+	projectRaw := []byte(`
+postgres:
+  user: project-user
+`)
+	assert.NoError(t, afero.WriteFile(fs, HomeConfigFile, homeRaw, 0o777))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(WorkingPath, ConfigDir, ConfigFileNameWithExt), projectRaw, 0o777))
+	InitConfig(fs)
+	return fs
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, CFG.PageSize.Validate("100"))
+	assert.ErrorIs(t, CFG.PageSize.Validate("many"), errInvalidValue)
+	assert.NoError(t, CFG.ShowWarnings.Validate("false"))
+	assert.EqualError(t, CFG.ShowWarnings.Validate("nope"), "nope is not a valid value for show_warnings, it must be of type bool")
+	assert.NoError(t, CFG.DockerCommand.Validate("podman"))
+	assert.ErrorIs(t, CFG.Contexts.Validate("anything"), errMapSetting)
+
+	duration := cfg{Path: "test.timeout", Type: durationCfg}
+	assert.NoError(t, duration.Validate("1m30s"))
+	assert.ErrorIs(t, duration.Validate("90"), errInvalidValue)
+}
+
+func TestEnvOverrides(t *testing.T) {
+	initSettingsTestConfig(t)
+	assert.Equal(t, "ASTRO_PAGE_SIZE", CFG.PageSize.EnvName())
+	assert.Equal(t, "ASTRO_DAG_UPLOAD_BLOCK_SIZE_MB", CFG.DagUploadBlockSize.EnvName())
+
+	t.Setenv("ASTRO_PAGE_SIZE", "75")
+	t.Setenv("ASTRO_POSTGRES_USER", "env-user")
+	t.Setenv("ASTRO_SHOW_WARNINGS", "false")
+	t.Setenv("ASTRO_CREDENTIALS_STORE", FileCredentialStore)
+	assert.Equal(t, 75, CFG.PageSize.GetInt())
+	assert.Equal(t, "env-user", CFG.PostgresUser.GetString())
+	assert.False(t, CFG.ShowWarnings.GetBool())
+	assert.Equal(t, FileCredentialStore, CFG.CredentialsStore.GetGlobalString())
+
+	// the config files are not changed
+	assert.Equal(t, "home-user", CFG.PostgresUser.GetHomeString())
+	assert.Equal(t, "project-user", CFG.PostgresUser.GetProjectString())
+
+	duration := cfg{Path: "test.timeout", Type: durationCfg}
+	t.Setenv("ASTRO_TEST_TIMEOUT", "2m")
+	assert.Equal(t, 2*time.Minute, duration.GetDuration())
+}
+
+func TestGetEffective(t *testing.T) {
+	initSettingsTestConfig(t)
+	tests := []struct {
+		cfg    cfg
+		value  string
+		source string
+	}{
+		{CFG.PostgresUser, "project-user", SourceProject},
+		{CFG.PostgresHost, "home-host", SourceHome},
+		{CFG.PageSize, "50", SourceHome},
+		{CFG.PostgresPort, "5432", SourceDefault},
+	}
+	for _, tt := range tests {
+		value, source := tt.cfg.GetEffective()
+		assert.Equal(t, tt.value, value, tt.cfg.Path)
+		assert.Equal(t, tt.source, source, tt.cfg.Path)
+	}
+
+	t.Setenv("ASTRO_POSTGRES_PORT", "5433")
+	value, source := CFG.PostgresPort.GetEffective()
+	assert.Equal(t, "5433", value)
+	assert.Equal(t, SourceEnv, source)
+
+	// settings that are set are read from the config file
+	assert.NoError(t, CFG.WebserverPort.SetHomeString("8081"))
+	value, source = CFG.WebserverPort.GetEffective()
+	assert.Equal(t, "8081", value)
+	assert.Equal(t, SourceHome, source)
+}
+
+func TestCfgs(t *testing.T) {
+	cfgs := Cfgs()
+	assert.NotEmpty(t, cfgs)
+	for i := range cfgs {
+		assert.NotEqual(t, mapCfg, cfgs[i].Type)
+		if i > 0 {
+			assert.Less(t, cfgs[i-1].Path, cfgs[i].Path)
+		}
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	assert.True(t, CFG.CloudAPIToken.Sensitive())
+	assert.True(t, CFG.PostgresPassword.Sensitive())
+	assert.False(t, CFG.PostgresUser.Sensitive())
+	assert.False(t, CFG.HTTPClientKey.Sensitive())
+}
+
+func TestUnset(t *testing.T) {
+	t.Run("unsets a project setting", func(t *testing.T) {
+		fs := initSettingsTestConfig(t)
+		err := CFG.PostgresUser.UnsetProject()
+		assert.NoError(t, err)
+		assert.False(t, CFG.PostgresUser.InProject())
+		assert.Equal(t, "home-user", CFG.PostgresUser.GetString())
+
+		raw, err := afero.ReadFile(fs, filepath.Join(WorkingPath, ConfigDir, ConfigFileNameWithExt))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(raw), "postgres")
+	})
+
+	t.Run("unsets a home setting that was set", func(t *testing.T) {
+		initSettingsTestConfig(t)
+		assert.NoError(t, CFG.PostgresHost.SetHomeString("other-host"))
+		err := CFG.PostgresHost.UnsetHome()
+		assert.NoError(t, err)
+		assert.False(t, CFG.PostgresHost.InHome())
+		assert.Equal(t, "postgres", CFG.PostgresHost.GetString())
+		assert.Equal(t, "home-user", CFG.PostgresUser.GetHomeString())
+		assert.Equal(t, 50, CFG.PageSize.GetInt())
+	})
+
+	t.Run("unsets a setting that is not set", func(t *testing.T) {
+		initSettingsTestConfig(t)
+		err := CFG.DockerCommand.UnsetHome()
+		assert.NoError(t, err)
+		assert.Equal(t, "docker", CFG.DockerCommand.GetString())
+	})
+}
@@ -1,9 +1,28 @@
 package config
 
+import (
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// cfgType is the type of the value of a setting, values are validated against it when they are set
+type cfgType string
+
+const (
+	stringCfg   cfgType = "string"
+	boolCfg     cfgType = "bool"
+	intCfg      cfgType = "int"
+	durationCfg cfgType = "duration"
+	// mapCfg settings like contexts are managed by their own commands
+	mapCfg cfgType = "map"
+)
+
 // cfg defines settings a single configuration setting can have
 type cfg struct {
 	Path    string
 	Default string
+	Type    cfgType
 }
 
 // cfgs houses all configurations for an astro project
@@ -14,6 +33,8 @@ type cfgs struct {
 	CloudAPIToken         cfg
 	Context               cfg
 	Contexts              cfg
+	CredentialsStore      cfg
+	CredentialsKeyFile    cfg
 	DockerCommand         cfg
 	LocalEnabled          cfg
 	LocalAstro            cfg
@@ -36,18 +57,39 @@ type cfgs struct {
 	Verbosity             cfg
 	HoustonDialTimeout    cfg
 	HoustonSkipVerifyTLS  cfg
+	HTTPCABundle          cfg
+	HTTPClientCert        cfg
+	HTTPClientKey         cfg
+	HTTPProxy             cfg
+	HTTPTimeout           cfg
 	SkipParse             cfg
 	Interactive           cfg
+	Offline               cfg
 	PageSize              cfg
 	SQLCLI                cfg
 	AuditLogs             cfg
 	UpgradeMessage        cfg
 	DisableAstroRun       cfg
+	DagUploadBlockSize    cfg
+	DagUploadConcurrency  cfg
+	DagUploadMaxRetries   cfg
+	DagUploadTimeout      cfg
+	RuntimeVersionsSource cfg
+	RuntimeVersionsTTL    cfg
+	SBOMFormat            cfg
+	SBOMVulnerabilityDB   cfg
+	SBOMFailOnSeverity    cfg
+	DeployDependencyDiff  cfg
 }
 
 // Creates a new cfg struct
 func newCfg(path, dflt string) cfg {
-	ncfg := cfg{path, dflt}
+	return newTypedCfg(path, dflt, stringCfg)
+}
+
+// Creates a new cfg struct with a value of the given type
+func newTypedCfg(path, dflt string, typ cfgType) cfg {
+	ncfg := cfg{path, dflt, typ}
 	CFGStrMap[path] = ncfg
 	return ncfg
 }
@@ -78,30 +120,44 @@ func (c cfg) SetProjectString(value string) error {
 	return nil
 }
 
-// GetString will return the requested config, check working dir and fallback to home
+// GetString will return the requested config, check env, working dir and fallback to home
 func (c cfg) GetString() string {
+	if value, ok := c.lookupEnv(); ok {
+		return value
+	}
 	if configExists(viperProject) && viperProject.IsSet(c.Path) {
 		return c.GetProjectString()
 	}
 	return c.GetHomeString()
 }
 
-// GetBool will return the requested config, check working dir and fallback to home
+// GetBool will return the requested config, check env, working dir and fallback to home
 func (c cfg) GetBool() bool {
+	if value, ok := c.lookupEnv(); ok {
+		return cast.ToBool(value)
+	}
 	if configExists(viperProject) && viperProject.IsSet(c.Path) {
 		return viperProject.GetBool(c.Path)
 	}
 	return viperHome.GetBool(c.Path)
 }
 
-// GetInt will return the integer value of requested config, check working dir and fallback to home
+// GetInt will return the integer value of requested config, check env, working dir and fallback to home
 func (c cfg) GetInt() int {
+	if value, ok := c.lookupEnv(); ok {
+		return cast.ToInt(value)
+	}
 	if configExists(viperProject) && viperProject.IsSet(c.Path) {
 		return viperProject.GetInt(c.Path)
 	}
 	return viperHome.GetInt(c.Path)
 }
 
+// GetDuration will return the duration value of requested config, check env, working dir and fallback to home
+func (c cfg) GetDuration() time.Duration {
+	return cast.ToDuration(c.GetString())
+}
+
 // GetProjectString will return a project config
 func (c cfg) GetProjectString() string {
 	return viperProject.GetString(c.Path)
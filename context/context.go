@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/astronomer/astro-cli/config"
@@ -29,6 +30,7 @@ var (
 var tab = printutil.Table{
 	Padding:      []int{44},
 	Header:       []string{"NAME"},
+	Fields:       []string{"name"},
 	ColorRowCode: [2]string{"\033[1;32m", "\033[0m"},
 }
 
@@ -37,6 +39,7 @@ func newTableOut() *printutil.Table {
 	return &printutil.Table{
 		Padding: []int{36, 36},
 		Header:  []string{"CONTEXT DOMAIN", "WORKSPACE"},
+		Fields:  []string{"domain", "workspace"},
 	}
 }
 
@@ -82,54 +85,82 @@ func Switch(domain string) error {
 	return c.SwitchContext()
 }
 
-func Delete(domain string, noPrompt bool) error {
+// Delete deletes the context name, a domain, a domain followed by @ and a profile or a profile of the current domain
+func Delete(name string, noPrompt bool) error {
 	currentCtx, _ := GetCurrentContext()
-	if currentCtx.Domain != "" && currentCtx.Domain == domain && !noPrompt {
-		i, _ := input.Confirm(fmt.Sprintf(contextDeleteWarnMsg, domain))
+	c := contextOf(name)
+	isCurrent := currentCtx.Domain != "" && currentCtx.Name() == c.Name()
+	if isCurrent && !noPrompt {
+		i, err := input.Confirm(fmt.Sprintf(contextDeleteWarnMsg, c.Name()))
+		if err != nil {
+			return err
+		}
 		if !i {
 			fmt.Println(cancelCtxDeleteMsg)
 			return nil
 		}
 	}
 
-	c := config.Context{Domain: domain}
 	err := c.DeleteContext()
 	if err != nil {
-		fmt.Printf(fmt.Sprintf(failCtxDeleteMsg, domain)) //nolint:staticcheck
+		fmt.Printf(fmt.Sprintf(failCtxDeleteMsg, name)) //nolint:staticcheck
 		return err
 	}
 
-	if currentCtx.Domain == domain {
+	if isCurrent {
 		if err := config.ResetCurrentContext(); err != nil {
 			return err
 		}
 	}
 
-	fmt.Println(fmt.Sprintf(successCtxDeleteMsg, domain))
+	fmt.Println(fmt.Sprintf(successCtxDeleteMsg, c.Name()))
 	return nil
 }
 
+// contextOf returns the context of name. name is a domain, a domain followed by @ and a profile like
+// astronomer.io@ci or the name of a profile of the current domain, like ci. A domain is its default profile.
+func contextOf(name string) config.Context {
+	domain, profile := config.ParseContextName(name)
+	if profile != "" {
+		return config.Context{Domain: domain, Profile: profile}
+	}
+	if currentCtx, err := GetCurrentContext(); err == nil && name != "" {
+		c := config.Context{Domain: currentCtx.Domain, Profile: name}
+		if name == config.DefaultProfile || c.ContextExists() {
+			return c
+		}
+	}
+	return config.Context{Domain: domain, Profile: config.DefaultProfile}
+}
+
 func SwitchContext(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
 
-	domain := ""
+	name := ""
 	if len(args) == 1 {
-		domain = args[0]
+		name = args[0]
 	}
 
-	err := Switch(domain)
+	c := contextOf(name)
+	// Create context if it does not exist
+	if !c.ContextExists() {
+		err := c.SetContext()
+		if err != nil {
+			return err
+		}
+	}
+	err := c.SwitchContext()
 	if err != nil {
 		return err
 	}
 
-	c := config.Context{Domain: domain}
 	ctx, err := c.GetContext()
 	if err != nil {
 		return err
 	}
 
 	tab := newTableOut()
-	tab.AddRow([]string{ctx.Domain, ctx.Workspace}, false)
+	tab.AddRow([]string{ctx.Name(), ctx.Workspace}, false)
 	tab.SuccessMsg = "\n Switched context"
 	tab.Print(os.Stdout)
 
@@ -139,7 +170,6 @@ func SwitchContext(cmd *cobra.Command, args []string) error {
 func ListContext(cmd *cobra.Command, args []string, out io.Writer) error {
 	cmd.SilenceUsage = true
 
-	var domain string
 	contexts, err := config.GetContexts()
 	if err != nil {
 		return err
@@ -149,23 +179,28 @@ func ListContext(cmd *cobra.Command, args []string, out io.Writer) error {
 	if err != nil {
 		return err
 	}
+	names := make([]string, 0, len(contexts.Contexts))
 	//nolint:gocritic
 	for ctxKey, ctx := range contexts.Contexts {
+		// profiles of a domain are listed as domain@profile
+		key, profile := config.ParseContextName(ctxKey)
+		domain := strings.Replace(key, "_", ".", -1)
 		if ctx.Domain != "" {
 			domain = ctx.Domain
-		} else {
-			domain = strings.Replace(ctxKey, "_", ".", -1)
 		}
-
-		if domain == currentCtx.Domain {
-			tab.AddRow([]string{domain}, true)
-		} else {
-			tab.AddRow([]string{domain}, false)
+		if profile == "" {
+			profile = config.DefaultProfile
 		}
+		c := config.Context{Domain: domain, Profile: profile}
+		names = append(names, c.Name())
 	}
+	sort.Strings(names)
 
-	tab.Print(out)
-	return nil
+	for _, name := range names {
+		tab.AddRow([]string{name}, name == currentCtx.Name())
+	}
+
+	return tab.Print(out)
 }
 
 func DeleteContext(cmd *cobra.Command, args []string, noPrompt bool) error {
@@ -2,10 +2,12 @@ package context
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/printutil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +127,56 @@ func TestListContext(t *testing.T) {
 	err := ListContext(&cobra.Command{}, []string{}, buf)
 	assert.NoError(t, err)
 	assert.Contains(t, buf.String(), "localhost")
+
+	t.Run("json", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := printutil.NewWriter(buf, printutil.JSONOutput)
+		assert.NoError(t, err)
+		err = ListContext(&cobra.Command{}, []string{}, out)
+		assert.NoError(t, err)
+		var contexts []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &contexts))
+		assert.Equal(t, "astronomer.io", contexts[0]["name"])
+	})
+}
+
+func TestProfiles(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.CloudPlatform)
+
+	// a profile is created when it is switched to
+	err := SwitchContext(&cobra.Command{}, []string{"astronomer.io@ci"})
+	assert.NoError(t, err)
+	ctx, err := GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "astronomer.io", ctx.Domain)
+	assert.Equal(t, "ci", ctx.Profile)
+	assert.Equal(t, "", ctx.Token)
+
+	buf := new(bytes.Buffer)
+	err = ListContext(&cobra.Command{}, []string{}, buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "astronomer.io@ci")
+
+	// profiles of the current domain are switched to by their name
+	err = SwitchContext(&cobra.Command{}, []string{config.DefaultProfile})
+	assert.NoError(t, err)
+	ctx, err = GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, config.DefaultProfile, ctx.Profile)
+	assert.Equal(t, "token", ctx.Token)
+
+	err = SwitchContext(&cobra.Command{}, []string{"ci"})
+	assert.NoError(t, err)
+	ctx, err = GetCurrentContext()
+	assert.NoError(t, err)
+	assert.Equal(t, "astronomer.io", ctx.Domain)
+	assert.Equal(t, "ci", ctx.Profile)
+
+	err = Delete("astronomer.io@ci", true)
+	assert.NoError(t, err)
+	ci, defaultProfile := contextOf("astronomer.io@ci"), contextOf("astronomer.io")
+	assert.False(t, ci.ContextExists())
+	assert.True(t, defaultProfile.ContextExists())
 }
 
 func TestIsCloudDomain(t *testing.T) {
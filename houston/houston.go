@@ -1,7 +1,6 @@
 package houston
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -119,18 +118,16 @@ type Client struct {
 }
 
 func NewHTTPClient() *httputil.HTTPClient {
-	httpClient := httputil.NewHTTPClient()
 	// configure http transport
 	dialTimeout := config.CFG.HoustonDialTimeout.GetInt()
-	// #nosec
-	httpClient.HTTPClient.Transport = &http.Transport{
-		Dial: (&net.Dialer{
+	return httputil.NewHTTPClientWithTransport(func(transport *http.Transport) {
+		transport.DialContext = (&net.Dialer{
 			Timeout: time.Duration(dialTimeout) * time.Second,
-		}).Dial,
-		TLSHandshakeTimeout: time.Duration(dialTimeout) * time.Second,
-		TLSClientConfig:     &tls.Config{InsecureSkipVerify: config.CFG.HoustonSkipVerifyTLS.GetBool()},
-	}
-	return httpClient
+		}).DialContext
+		transport.TLSHandshakeTimeout = time.Duration(dialTimeout) * time.Second
+		// #nosec
+		transport.TLSClientConfig.InsecureSkipVerify = config.CFG.HoustonSkipVerifyTLS.GetBool()
+	})
 }
 
 // newInternalClient returns a new Client with the logger and HTTP Client setup.
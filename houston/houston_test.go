@@ -3,10 +3,15 @@ package houston
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/pem"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/httputil"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
@@ -56,4 +61,18 @@ func TestNewHTTPClient(t *testing.T) {
 	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
 	client := NewHTTPClient()
 	assert.NotNil(t, client)
+
+	t.Run("uses the http settings", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		caBundle := filepath.Join(t.TempDir(), "ca.pem")
+		err := os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)
+		assert.NoError(t, err)
+		t.Setenv(config.CFG.HTTPCABundle.EnvName(), caBundle)
+
+		resp, err := NewHTTPClient().Do(&httputil.DoOptions{Method: http.MethodGet, Path: server.URL})
+		assert.NoError(t, err)
+		resp.Body.Close()
+	})
 }
@@ -1,20 +1,37 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
+	"github.com/astronomer/astro-cli/cloud/deployment/fromfile"
 	"github.com/astronomer/astro-cli/cmd"
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/ansi"
+	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/spf13/afero"
 )
 
+const (
+	exitError = 1
+	// exitDrift lets scripts tell a deployment that does not match its deployment file apart from a failed command
+	exitDrift = 2
+)
+
 func main() {
 	// TODO: Remove this when version logic is implemented
 	fs := afero.NewOsFs()
 	config.InitConfig(fs)
+	// scripts and CI runs can not answer prompts
+	input.SetNoInput(!input.StdinIsTerminal())
+	if err := httputil.SetTraceFile(os.Getenv(httputil.TraceEnv)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: unable to write the trace file of %s: %s\n", httputil.TraceEnv, err.Error())
+		os.Exit(exitError)
+	}
 	if err := cmd.NewRootCmd().Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 
 	// platform specific terminal initialization:
@@ -22,3 +39,10 @@ func main() {
 	// for most of the architectures there's no requirements:
 	ansi.InitConsole()
 }
+
+func exitCode(err error) int {
+	if errors.Is(err, fromfile.ErrDriftDetected) {
+		return exitDrift
+	}
+	return exitError
+}
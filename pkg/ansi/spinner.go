@@ -1,6 +1,7 @@
 package ansi
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -13,8 +14,15 @@ const (
 	spinnerTextFailed   = "failed"
 
 	spinnerColor = "cyan"
+
+	percent = 100
 )
 
+var spinnerSet = []string{"●   ", "●   ", " ●  ", "  ● ", "    ●", "  ● ", " ●  "}
+
+// ProgressFunc reports how many bytes of a total have been processed, total is <= 0 when unknown
+type ProgressFunc func(current, total int64)
+
 func Waiting(fn func() error) error {
 	return loading("", "", "", fn)
 }
@@ -27,23 +35,68 @@ func Spinner(text string, fn func() error) error {
 	return loading(initialMsg, doneMsg, failMsg, fn)
 }
 
+// Progress behaves like Spinner but hands fn a ProgressFunc which updates a percentage shown next to the spinner
+func Progress(text string, fn func(progress ProgressFunc) error) error {
+	initialMsg := text + spinnerTextEllipsis + " "
+	doneMsg := text + spinnerTextEllipsis + " " + spinnerTextDone + "\n"
+	failMsg := text + spinnerTextEllipsis + " " + spinnerTextFailed + "\n"
+
+	s := newSpinner(initialMsg, doneMsg)
+	s.Start()
+
+	err := fn(func(current, total int64) {
+		s.Lock()
+		s.Suffix = progressSuffix(current, total)
+		s.Unlock()
+	})
+	if err != nil {
+		s.FinalMSG = failMsg
+	}
+	s.Stop()
+
+	return err
+}
+
+func progressSuffix(current, total int64) string {
+	if total <= 0 {
+		return " " + humanBytes(current)
+	}
+	return fmt.Sprintf(" %d%% (%s/%s)", current*percent/total, humanBytes(current), humanBytes(total))
+}
+
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func newSpinner(initialMsg, doneMsg string) *spinner.Spinner {
+	s := spinner.New(spinnerSet, 100*time.Millisecond, spinner.WithWriter(Messages)) //nolint:gomnd
+	s.Prefix = initialMsg
+	s.FinalMSG = doneMsg
+	s.HideCursor = true
+	s.Writer = Messages
+
+	if err := s.Color(spinnerColor, "bold"); err != nil {
+		panic(errors.Wrap(err, "failed setting spinner color"))
+	}
+	return s
+}
+
 func loading(initialMsg, doneMsg, failMsg string, fn func() error) error {
 	done := make(chan struct{})
 	errc := make(chan error)
 	go func() {
 		defer close(done)
 
-		spinnerSet := []string{"●   ", "●   ", " ●  ", "  ● ", "    ●", "  ● ", " ●  "}
-		s := spinner.New(spinnerSet, 100*time.Millisecond, spinner.WithWriter(Messages)) //nolint:gomnd
-		s.Prefix = initialMsg
-		s.FinalMSG = doneMsg
-		s.HideCursor = true
-		s.Writer = Messages
-
-		if err := s.Color(spinnerColor, "bold"); err != nil {
-			panic(errors.Wrap(err, "failed setting spinner color"))
-		}
-
+		s := newSpinner(initialMsg, doneMsg)
 		s.Start()
 		err := <-errc
 		if err != nil {
@@ -36,3 +36,35 @@ func TestSpinner(t *testing.T) {
 		})
 	}
 }
+
+func TestProgress(t *testing.T) {
+	err := Progress("testing", func(progress ProgressFunc) error {
+		progress(1, 2)
+		progress(2, 2)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Progress() error = %v", err)
+	}
+
+	err = Progress("testing", func(progress ProgressFunc) error { return errMock })
+	if !errors.Is(err, errMock) {
+		t.Errorf("Progress() error = %v, want %v", err, errMock)
+	}
+}
+
+func TestProgressSuffix(t *testing.T) {
+	tests := []struct {
+		current, total int64
+		want           string
+	}{
+		{current: 512, total: -1, want: " 512 B"},
+		{current: 1024, total: 4096, want: " 25% (1.0 KB/4.0 KB)"},
+		{current: 3 * 1024 * 1024, total: 6 * 1024 * 1024, want: " 50% (3.0 MB/6.0 MB)"},
+	}
+	for _, tt := range tests {
+		if got := progressSuffix(tt.current, tt.total); got != tt.want {
+			t.Errorf("progressSuffix(%d, %d) = %q, want %q", tt.current, tt.total, got, tt.want)
+		}
+	}
+}
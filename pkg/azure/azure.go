@@ -1,32 +1,365 @@
 package azure
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+
+	"github.com/astronomer/astro-cli/pkg/ansi"
+)
+
+const (
+	DefaultBlockSize     = 4 * 1024 * 1024
+	DefaultConcurrency   = 4
+	DefaultMaxRetries    = 5
+	DefaultRetryDelay    = 500 * time.Millisecond
+	DefaultMaxRetryDelay = 30 * time.Second
+	DefaultTimeout       = 10 * time.Minute
+
+	progressMsg = "Uploading DAGs"
 )
 
-var azureUploader = Upload
+var (
+	azureUploader = Upload
+
+	errUploadCanceled = errors.New("DAG upload canceled")
+	errUploadTimedOut = errors.New("DAG upload timed out, increase the upload timeout or check your network connection")
+)
 
 type Azure interface {
 	Upload(sasLink string, dagFileReader io.Reader) (string, error)
 }
 
+// UploadOptions controls how a DAG bundle is split into blocks and sent to blob storage
+type UploadOptions struct {
+	// BlockSize is the size in bytes of every staged block except the last one
+	BlockSize int64
+	// Concurrency is the number of blocks staged in parallel
+	Concurrency int
+	// MaxRetries is the number of times a failed block or commit is retried before giving up
+	MaxRetries int
+	// RetryDelay is the initial backoff, doubled after every failed attempt up to MaxRetryDelay
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// Timeout bounds the whole upload, zero means no timeout
+	Timeout time.Duration
+	// Progress shows a progress indicator on ansi.Messages when true
+	Progress bool
+	// ClientOptions is passed to the blob client, mostly useful to swap the transport in tests
+	ClientOptions *azblob.ClientOptions
+}
+
+// DefaultUploadOptions returns the options used by Upload
+func DefaultUploadOptions() UploadOptions {
+	return UploadOptions{
+		BlockSize:     DefaultBlockSize,
+		Concurrency:   DefaultConcurrency,
+		MaxRetries:    DefaultMaxRetries,
+		RetryDelay:    DefaultRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+		Timeout:       DefaultTimeout,
+		Progress:      true,
+	}
+}
+
 func azureUpload(sasLink string, dagFileReader io.Reader) (string, error) {
 	return azureUploader(sasLink, dagFileReader)
 }
 
+// Upload uploads the DAG bundle to the given SAS link with the default options
 func Upload(sasLink string, dagFileReader io.Reader) (string, error) {
-	blobClient, err := azblob.NewBlockBlobClientWithNoCredential(sasLink, nil)
+	return UploadWithOptions(sasLink, dagFileReader, DefaultUploadOptions())
+}
+
+// UploadWithOptions uploads the DAG bundle as a block blob and returns the version ID of the committed blob.
+// Blocks are named after their position and content, so blocks that were already staged against the same
+// SAS link by an earlier, interrupted attempt are not sent again. The upload is canceled on Ctrl-C.
+func UploadWithOptions(sasLink string, dagFileReader io.Reader, opts UploadOptions) (string, error) {
+	opts = withDefaults(opts)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	blobClient, err := azblob.NewBlockBlobClientWithNoCredential(sasLink, opts.ClientOptions)
 	if err != nil {
 		return "", err
 	}
-	uploadRes, err := blobClient.UploadStream(context.TODO(), dagFileReader, azblob.UploadStreamOptions{})
+
+	u := &blockUploader{client: blobClient, opts: opts, total: readerSize(dagFileReader)}
+
+	var versionID string
+	upload := func(progress ansi.ProgressFunc) error {
+		u.progress = progress
+		versionID, err = u.upload(ctx, dagFileReader)
+		return err
+	}
+	if opts.Progress {
+		err = ansi.Progress(progressMsg, upload)
+	} else {
+		err = upload(nil)
+	}
 	if err != nil {
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return "", errUploadTimedOut
+		case errors.Is(ctx.Err(), context.Canceled):
+			return "", errUploadCanceled
+		}
 		return "", err
 	}
-	versionID := *uploadRes.VersionID
 
 	return versionID, nil
 }
+
+func withDefaults(opts UploadOptions) UploadOptions {
+	if opts.BlockSize <= 0 {
+		opts.BlockSize = DefaultBlockSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.MaxRetryDelay < opts.RetryDelay {
+		opts.MaxRetryDelay = opts.RetryDelay
+	}
+	clientOptions := azblob.ClientOptions{}
+	if opts.ClientOptions != nil {
+		clientOptions = *opts.ClientOptions
+	}
+	// retries are handled per block by the uploader so the SDK should only try once
+	clientOptions.Retry.MaxRetries = -1
+	opts.ClientOptions = &clientOptions
+	return opts
+}
+
+func readerSize(r io.Reader) int64 {
+	if f, ok := r.(interface{ Stat() (os.FileInfo, error) }); ok {
+		if info, err := f.Stat(); err == nil {
+			return info.Size()
+		}
+	}
+	if l, ok := r.(interface{ Len() int }); ok {
+		return int64(l.Len())
+	}
+	return -1
+}
+
+type block struct {
+	id   string
+	data []byte
+}
+
+type blockUploader struct {
+	client   *azblob.BlockBlobClient
+	opts     UploadOptions
+	total    int64
+	sent     int64
+	progress ansi.ProgressFunc
+}
+
+func (u *blockUploader) upload(ctx context.Context, r io.Reader) (string, error) {
+	staged := u.stagedBlocks(ctx)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	blocks := make(chan block)
+	for i := 0; i < u.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range blocks {
+				if err := u.stageBlock(ctx, b, staged); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	var ids []string
+	readErr := func() error {
+		defer close(blocks)
+		for index := 0; ; index++ {
+			data := make([]byte, u.opts.BlockSize)
+			n, err := io.ReadFull(r, data)
+			if n > 0 {
+				b := block{id: blockID(index, data[:n]), data: data[:n]}
+				ids = append(ids, b.id)
+				select {
+				case blocks <- b:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}()
+	wg.Wait()
+
+	if readErr != nil {
+		return "", readErr
+	}
+	if firstErr != nil {
+		return "", firstErr
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	var resp azblob.BlockBlobCommitBlockListResponse
+	err := u.retry(ctx, func() error {
+		var err error
+		resp, err = u.client.CommitBlockList(ctx, ids, nil)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.VersionID == nil {
+		return "", nil
+	}
+	return *resp.VersionID, nil
+}
+
+// stagedBlocks returns the uncommitted blocks that already exist for the blob, keyed by ID
+func (u *blockUploader) stagedBlocks(ctx context.Context) map[string]int64 {
+	staged := map[string]int64{}
+	resp, err := u.client.GetBlockList(ctx, azblob.BlockListTypeUncommitted, nil)
+	if err != nil {
+		// most likely the blob does not exist yet, in which case there is nothing to resume
+		return staged
+	}
+	if resp.BlockList.UncommittedBlocks == nil {
+		return staged
+	}
+	for _, b := range resp.BlockList.UncommittedBlocks {
+		if b.Name != nil && b.Size != nil {
+			staged[*b.Name] = *b.Size
+		}
+	}
+	return staged
+}
+
+func (u *blockUploader) stageBlock(ctx context.Context, b block, staged map[string]int64) error {
+	if size, ok := staged[b.id]; ok && size == int64(len(b.data)) {
+		u.reportProgress(int64(len(b.data)))
+		return nil
+	}
+	err := u.retry(ctx, func() error {
+		_, err := u.client.StageBlock(ctx, b.id, readSeekNopCloser{bytes.NewReader(b.data)}, nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	u.reportProgress(int64(len(b.data)))
+	return nil
+}
+
+func (u *blockUploader) reportProgress(n int64) {
+	sent := atomic.AddInt64(&u.sent, n)
+	if u.progress != nil {
+		u.progress(sent, u.total)
+	}
+}
+
+// retry calls fn until it succeeds, returns a non-transient error or runs out of attempts, backing off exponentially
+func (u *blockUploader) retry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= u.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(backoff(attempt, u.opts.RetryDelay, u.opts.MaxRetryDelay))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		err = fn()
+		if err == nil || !isRetryable(ctx, err) {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", u.opts.MaxRetries+1, err)
+}
+
+func backoff(attempt int, base, limit time.Duration) time.Duration {
+	d := time.Duration(float64(base) * math.Pow(2, float64(attempt-1))) //nolint:gomnd
+	if d > limit || d <= 0 {
+		return limit
+	}
+	return d
+}
+
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var storageErr *azblob.StorageError
+	if errors.As(err, &storageErr) {
+		switch code := storageErr.StatusCode(); {
+		case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests:
+			return true
+		case code >= http.StatusInternalServerError:
+			return true
+		default:
+			return false
+		}
+	}
+	// connection resets, DNS hiccups and the like
+	return true
+}
+
+// readSeekNopCloser lets an in-memory block be passed where the SDK expects an io.ReadSeekCloser
+type readSeekNopCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+// blockID derives a stable, fixed length block ID from the position and the content of a block
+func blockID(index int, data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%06d-%x", index, sum[:8])))
+}
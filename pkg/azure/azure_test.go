@@ -1,10 +1,16 @@
 package azure
 
 import (
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,3 +36,164 @@ func TestUpload(t *testing.T) {
 		assert.ErrorIs(t, err, errMock)
 	})
 }
+
+// fakeBlobServer is a minimal, in-memory stand-in for the block blob endpoints of Azurite
+type fakeBlobServer struct {
+	mu          sync.Mutex
+	staged      map[string][]byte
+	committed   []byte
+	stageCalls  int
+	failStaging int
+	failStatus  int
+}
+
+func newFakeBlobServer() *fakeBlobServer {
+	return &fakeBlobServer{staged: map[string][]byte{}}
+}
+
+func (f *fakeBlobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		f.stageCalls++
+		if f.failStaging > 0 {
+			f.failStaging--
+			w.WriteHeader(f.failStatus)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		f.staged[query.Get("blockid")] = body
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet && query.Get("comp") == "blocklist":
+		var sb strings.Builder
+		sb.WriteString(`<?xml version="1.0" encoding="utf-8"?><BlockList><UncommittedBlocks>`)
+		for id, data := range f.staged {
+			fmt.Fprintf(&sb, "<Block><Name>%s</Name><Size>%d</Size></Block>", id, len(data))
+		}
+		sb.WriteString(`</UncommittedBlocks></BlockList>`)
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(sb.String()))
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var list struct {
+			Latest []string `xml:"Latest"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := xml.Unmarshal(body, &list); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.committed = nil
+		for _, id := range list.Latest {
+			f.committed = append(f.committed, f.staged[id]...)
+		}
+		w.Header().Set("x-ms-version-id", "version-id")
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testUploadOptions() UploadOptions {
+	return UploadOptions{
+		BlockSize:     4,
+		Concurrency:   3,
+		MaxRetries:    3,
+		RetryDelay:    time.Millisecond,
+		MaxRetryDelay: 5 * time.Millisecond,
+		Timeout:       10 * time.Second,
+	}
+}
+
+func TestUploadWithOptions(t *testing.T) {
+	content := "a DAG bundle that spans several blocks"
+
+	t.Run("uploads all blocks in order", func(t *testing.T) {
+		fake := newFakeBlobServer()
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		versionID, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), testUploadOptions())
+		assert.NoError(t, err)
+		assert.Equal(t, "version-id", versionID)
+		assert.Equal(t, content, string(fake.committed))
+	})
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		fake := newFakeBlobServer()
+		fake.failStaging = 2
+		fake.failStatus = http.StatusServiceUnavailable
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		_, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), testUploadOptions())
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(fake.committed))
+	})
+
+	t.Run("gives up on non transient errors", func(t *testing.T) {
+		fake := newFakeBlobServer()
+		fake.failStaging = 100
+		fake.failStatus = http.StatusForbidden
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		opts := testUploadOptions()
+		opts.Concurrency = 1
+		_, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), opts)
+		assert.Error(t, err)
+		assert.Equal(t, 1, fake.stageCalls)
+		assert.Nil(t, fake.committed)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		fake := newFakeBlobServer()
+		fake.failStaging = 100
+		fake.failStatus = http.StatusInternalServerError
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		opts := testUploadOptions()
+		opts.Concurrency = 1
+		_, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), opts)
+		assert.ErrorContains(t, err, "giving up after 4 attempts")
+		assert.Equal(t, 4, fake.stageCalls)
+	})
+
+	t.Run("resumes from already staged blocks", func(t *testing.T) {
+		fake := newFakeBlobServer()
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		opts := testUploadOptions()
+		fake.staged[blockID(0, []byte(content[:4]))] = []byte(content[:4])
+		fake.staged[blockID(1, []byte(content[4:8]))] = []byte(content[4:8])
+
+		_, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), opts)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(fake.committed))
+		assert.Equal(t, (len(content)+3)/4-2, fake.stageCalls)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		opts := testUploadOptions()
+		opts.Timeout = 50 * time.Millisecond
+		_, err := UploadWithOptions(server.URL+"/dags/dags.tar", strings.NewReader(content), opts)
+		assert.ErrorIs(t, err, errUploadTimedOut)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, backoff(1, 100*time.Millisecond, time.Second))
+	assert.Equal(t, 400*time.Millisecond, backoff(3, 100*time.Millisecond, time.Second))
+	assert.Equal(t, time.Second, backoff(10, 100*time.Millisecond, time.Second))
+}
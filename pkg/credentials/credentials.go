@@ -0,0 +1,28 @@
+// Package credentials stores the tokens of the Astro and Astronomer Software contexts outside of the config file.
+package credentials
+
+import "errors"
+
+// ErrNotFound is returned by Store.Get when there are no credentials for a domain
+var ErrNotFound = errors.New("credentials not found")
+
+// Credentials are the tokens of a context
+type Credentials struct {
+	Token        string `json:"Token"`
+	RefreshToken string `json:"RefreshToken"`
+}
+
+// IsEmpty returns true if there are no tokens in c
+func (c Credentials) IsEmpty() bool {
+	return c.Token == "" && c.RefreshToken == ""
+}
+
+// Store saves the credentials of contexts, they are identified by the domain of the context
+type Store interface {
+	// Get returns the credentials of domain or ErrNotFound
+	Get(domain string) (Credentials, error)
+	// Store saves the credentials of domain, replacing the existing ones
+	Store(domain string, creds Credentials) error
+	// Erase deletes the credentials of domain, it does not fail if there are none
+	Erase(domain string) error
+}
@@ -0,0 +1,192 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// scrypt parameters recommended for interactive logins
+	scryptN     = 32768
+	scryptR     = 8
+	scryptP     = 1
+	keyLength   = 32
+	saltLength  = 16
+	filePerm    = 0o600
+	dirPerm     = 0o700
+	fileVersion = 1
+)
+
+var (
+	errEmptyPassphrase = errors.New("the passphrase of the credentials file can not be empty")
+	errDecrypt         = errors.New("could not decrypt the credentials file, the passphrase or key file is wrong or the file is corrupted")
+	errFileVersion     = errors.New("unsupported credentials file version")
+)
+
+// encryptedFile is the format of the credentials file, Data is the encrypted JSON of the credentials by domain
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// FileStore keeps credentials in a file encrypted with AES-GCM. The key is derived with scrypt from a passphrase
+// or the content of a key file, passphrase is only called the first time the file is read or written.
+type FileStore struct {
+	path       string
+	passphrase func() (string, error)
+
+	// the file is decrypted once and kept in memory, commands read the current context many times
+	loaded bool
+	salt   []byte
+	key    []byte
+	creds  map[string]Credentials
+}
+
+// NewFileStore returns a FileStore that keeps credentials in the file at path
+func NewFileStore(path string, passphrase func() (string, error)) *FileStore {
+	return &FileStore{path: path, passphrase: passphrase}
+}
+
+// Get returns the credentials of domain or ErrNotFound
+func (s *FileStore) Get(domain string) (Credentials, error) {
+	if err := s.load(); err != nil {
+		return Credentials{}, err
+	}
+	creds, ok := s.creds[domain]
+	if !ok {
+		return Credentials{}, ErrNotFound
+	}
+	return creds, nil
+}
+
+// Store saves the credentials of domain and rewrites the file
+func (s *FileStore) Store(domain string, creds Credentials) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.creds[domain] = creds
+	return s.save()
+}
+
+// Erase deletes the credentials of domain and rewrites the file
+func (s *FileStore) Erase(domain string) error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.creds[domain]; !ok {
+		return nil
+	}
+	delete(s.creds, domain)
+	return s.save()
+}
+
+func (s *FileStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		// the salt and key of a new file are created on the first save
+		s.creds = map[string]Credentials{}
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+	if file.Version != fileVersion {
+		return fmt.Errorf("%w %d in %s", errFileVersion, file.Version, s.path)
+	}
+	key, err := s.deriveKey(file.Salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return errDecrypt
+	}
+	creds := map[string]Credentials{}
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+	s.salt, s.key, s.creds, s.loaded = file.Salt, key, creds, true
+	return nil
+}
+
+func (s *FileStore) save() error {
+	if s.key == nil {
+		s.salt = make([]byte, saltLength)
+		if _, err := io.ReadFull(rand.Reader, s.salt); err != nil {
+			return err
+		}
+		key, err := s.deriveKey(s.salt)
+		if err != nil {
+			return err
+		}
+		s.key = key
+	}
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(s.creds)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(encryptedFile{
+		Version: fileVersion,
+		Salt:    s.salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), dirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, filePerm)
+}
+
+func (s *FileStore) deriveKey(salt []byte) ([]byte, error) {
+	passphrase, err := s.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, errEmptyPassphrase
+	}
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLength)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,93 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	passphrase := func(p string) func() (string, error) {
+		return func() (string, error) { return p, nil }
+	}
+	creds := Credentials{Token: "Bearer token", RefreshToken: "refresh-token"}
+
+	t.Run("stores, gets and erases credentials", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		store := NewFileStore(path, passphrase("secret"))
+
+		_, err := store.Get("astronomer.io")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		err = store.Store("astronomer.io", creds)
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "Bearer token")
+		assert.NotContains(t, string(data), "refresh-token")
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(filePerm), info.Mode().Perm())
+
+		// a new store reads the file written by the first one
+		got, err := NewFileStore(path, passphrase("secret")).Get("astronomer.io")
+		assert.NoError(t, err)
+		assert.Equal(t, creds, got)
+
+		err = store.Erase("astronomer.io")
+		assert.NoError(t, err)
+		_, err = NewFileStore(path, passphrase("secret")).Get("astronomer.io")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		err = store.Erase("unknown.io")
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns an error for a wrong passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		err := NewFileStore(path, passphrase("secret")).Store("astronomer.io", creds)
+		assert.NoError(t, err)
+
+		_, err = NewFileStore(path, passphrase("wrong")).Get("astronomer.io")
+		assert.ErrorIs(t, err, errDecrypt)
+	})
+
+	t.Run("returns an error for an empty passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		err := NewFileStore(path, passphrase("")).Store("astronomer.io", creds)
+		assert.ErrorIs(t, err, errEmptyPassphrase)
+	})
+
+	t.Run("returns the error of passphrase", func(t *testing.T) {
+		errPassphrase := errors.New("no passphrase")
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		err := NewFileStore(path, func() (string, error) { return "", errPassphrase }).Store("astronomer.io", creds)
+		assert.ErrorIs(t, err, errPassphrase)
+	})
+
+	t.Run("asks for the passphrase once", func(t *testing.T) {
+		calls := 0
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		store := NewFileStore(path, func() (string, error) {
+			calls++
+			return "secret", nil
+		})
+		assert.NoError(t, store.Store("astronomer.io", creds))
+		assert.NoError(t, store.Store("gcp0001.us-east4.astronomer.io", creds))
+		_, err := store.Get("astronomer.io")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("returns an error for an unsupported file version", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "credentials.enc")
+		err := os.WriteFile(path, []byte(`{"version": 2}`), filePerm)
+		assert.NoError(t, err)
+		_, err = NewFileStore(path, passphrase("secret")).Get("astronomer.io")
+		assert.ErrorIs(t, err, errFileVersion)
+	})
+}
@@ -0,0 +1,89 @@
+package credentials
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HelperPrefix is the prefix of the executables of credential helpers, the helper named pass is astro-credential-pass
+const HelperPrefix = "astro-credential-"
+
+// Monkey patched to write unit tests
+var execCommand = exec.Command
+
+// helperMessage is what a credential helper reads on store and writes on get
+type helperMessage struct {
+	Domain string `json:"Domain"`
+	Credentials
+}
+
+// HelperStore keeps credentials with an external credential helper, like the credential helpers of docker.
+// The helper is called with one of these actions:
+//
+//	get:   reads a domain on stdin and writes {"Domain": "...", "Token": "...", "RefreshToken": "..."} on stdout,
+//	       it exits with an error and writes "credentials not found" if there are none
+//	store: reads {"Domain": "...", "Token": "...", "RefreshToken": "..."} on stdin
+//	erase: reads a domain on stdin
+type HelperStore struct {
+	program string
+}
+
+// NewHelperStore returns a HelperStore that runs the credential helper astro-credential-<name>
+func NewHelperStore(name string) *HelperStore {
+	return &HelperStore{program: HelperPrefix + name}
+}
+
+// Get returns the credentials of domain or ErrNotFound
+func (s *HelperStore) Get(domain string) (Credentials, error) {
+	out, err := s.run("get", domain)
+	if err != nil {
+		if strings.Contains(err.Error(), ErrNotFound.Error()) {
+			return Credentials{}, ErrNotFound
+		}
+		return Credentials{}, err
+	}
+	var msg helperMessage
+	if err := json.Unmarshal(out, &msg); err != nil {
+		return Credentials{}, fmt.Errorf("invalid output of %s get: %w", s.program, err)
+	}
+	return msg.Credentials, nil
+}
+
+// Store saves the credentials of domain with the helper
+func (s *HelperStore) Store(domain string, creds Credentials) error {
+	msg, err := json.Marshal(helperMessage{Domain: domain, Credentials: creds})
+	if err != nil {
+		return err
+	}
+	_, err = s.run("store", string(msg))
+	return err
+}
+
+// Erase deletes the credentials of domain with the helper
+func (s *HelperStore) Erase(domain string) error {
+	_, err := s.run("erase", domain)
+	if err != nil && strings.Contains(err.Error(), ErrNotFound.Error()) {
+		return nil
+	}
+	return err
+}
+
+// run runs the helper with action and input on stdin, the output of a failed helper is in the error
+func (s *HelperStore) run(action, input string) ([]byte, error) {
+	cmd := execCommand(s.program, action)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s: %s", s.program, action, message) //nolint:goerr113
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,97 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeHelper runs TestHelperProcess instead of a credential helper, it keeps credentials in the file at stateFile
+func fakeHelper(t *testing.T, stateFile string) func() {
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		assert.Equal(t, "astro-credential-test", name)
+		cs := append([]string{"-test.run=TestHelperProcess", "--", name}, args...)
+		cmd := exec.Command(os.Args[0], cs...) //nolint:gosec
+		cmd.Env = append(os.Environ(), "GO_WANT_HELPER_PROCESS=1", "HELPER_STATE_FILE="+stateFile)
+		return cmd
+	}
+	return func() { execCommand = exec.Command }
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	stateFile := os.Getenv("HELPER_STATE_FILE")
+	state := map[string]helperMessage{}
+	if data, err := os.ReadFile(stateFile); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+	input, _ := io.ReadAll(os.Stdin)
+
+	action := os.Args[len(os.Args)-1]
+	switch action {
+	case "get":
+		msg, ok := state[string(input)]
+		if !ok {
+			fmt.Print("credentials not found")
+			os.Exit(1)
+		}
+		out, _ := json.Marshal(msg)
+		fmt.Print(string(out))
+	case "store":
+		var msg helperMessage
+		if err := json.Unmarshal(input, &msg); err != nil {
+			fmt.Fprint(os.Stderr, "invalid input")
+			os.Exit(1)
+		}
+		state[msg.Domain] = msg
+	case "erase":
+		delete(state, string(input))
+	default:
+		fmt.Fprint(os.Stderr, "unknown action")
+		os.Exit(1)
+	}
+	data, _ := json.Marshal(state)
+	_ = os.WriteFile(stateFile, data, filePerm)
+	os.Exit(0)
+}
+
+func TestHelperStore(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	defer fakeHelper(t, stateFile)()
+	store := NewHelperStore("test")
+	creds := Credentials{Token: "Bearer token", RefreshToken: "refresh-token"}
+
+	_, err := store.Get("astronomer.io")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = store.Store("astronomer.io", creds)
+	assert.NoError(t, err)
+
+	got, err := store.Get("astronomer.io")
+	assert.NoError(t, err)
+	assert.Equal(t, creds, got)
+
+	err = store.Erase("astronomer.io")
+	assert.NoError(t, err)
+	_, err = store.Get("astronomer.io")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("returns the output of a failed helper", func(t *testing.T) {
+		_, err := store.run("list", "")
+		assert.EqualError(t, err, "astro-credential-test list: unknown action")
+	})
+
+	t.Run("returns an error if the helper does not exist", func(t *testing.T) {
+		execCommand = exec.Command
+		_, err := NewHelperStore("does-not-exist").Get("astronomer.io")
+		assert.ErrorContains(t, err, "astro-credential-does-not-exist get")
+	})
+}
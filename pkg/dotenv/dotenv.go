@@ -0,0 +1,185 @@
+// Package dotenv reads environment variables from .env files.
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const exportPrefix = "export "
+
+var (
+	errMissingEquals   = errors.New("is not of the form KEY=VALUE")
+	errInvalidKey      = errors.New("has an invalid key")
+	errUnterminated    = errors.New("has an unterminated quoted value")
+	errTrailingContent = errors.New("has content after a quoted value")
+)
+
+// Variable is a key and value read from a .env file
+type Variable struct {
+	Key   string
+	Value string
+}
+
+// Read parses the .env file at path
+func Read(path string) ([]Variable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses r as a .env file and returns its variables in the order they are declared.
+// Lines can start with export, values can be single quoted, double quoted or unquoted and quoted values can span
+// several lines. Double quoted values support \n, \r, \t, \\ and \" escapes and single quoted values are literal.
+// Unquoted values end at a # preceded by a space. Values are not expanded, ${VAR} is kept as it is.
+// If a key is declared more than once the last value is used at the position of the first declaration.
+func Parse(r io.Reader) ([]Variable, error) {
+	variables, skipped, err := parse(r, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(skipped) > 0 {
+		return nil, skipped[0]
+	}
+	return variables, nil
+}
+
+// ReadSkippingInvalid reads the .env file at path like Read but skips the lines that can not be parsed, it returns
+// the error of every skipped line
+func ReadSkippingInvalid(path string) (variables []Variable, skipped []error, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return parse(f, true)
+}
+
+// parse parses r, invalid lines are skipped if skipInvalid is true or stop the parsing otherwise
+func parse(r io.Reader, skipInvalid bool) (variables []Variable, skipped []error, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	declared := map[string]int{}
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, consumed, err := parseLine(line, lines[i+1:])
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("line %d %w", i+1, err))
+			if !skipInvalid {
+				return nil, skipped, nil
+			}
+			continue
+		}
+		i += consumed
+
+		if index, ok := declared[key]; ok {
+			variables[index].Value = value
+			continue
+		}
+		declared[key] = len(variables)
+		variables = append(variables, Variable{Key: key, Value: value})
+	}
+	return variables, skipped, nil
+}
+
+// parseLine returns the variable declared on line, it returns how many of the following lines a quoted value spans
+func parseLine(line string, following []string) (key, value string, consumed int, err error) {
+	line = strings.TrimSpace(strings.TrimPrefix(line, exportPrefix))
+	key, rest, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", 0, errMissingEquals
+	}
+	key = strings.TrimSpace(key)
+	if !validKey(key) {
+		return "", "", 0, fmt.Errorf("%w: %s", errInvalidKey, key)
+	}
+	rest = strings.TrimLeft(rest, " \t")
+	if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+		value, consumed, err = parseQuoted(rest, following)
+		return key, value, consumed, err
+	}
+	return key, parseUnquoted(rest), 0, nil
+}
+
+// parseQuoted returns the quoted value that starts at s and continues on the following lines if it is not closed.
+// It returns how many of the following lines were consumed.
+func parseQuoted(s string, following []string) (value string, consumed int, err error) {
+	quote := s[0]
+	s = s[1:]
+	var b strings.Builder
+	for {
+		for j := 0; j < len(s); j++ {
+			c := s[j]
+			switch {
+			case c == quote:
+				trailing := strings.TrimSpace(s[j+1:])
+				if trailing != "" && !strings.HasPrefix(trailing, "#") {
+					return "", 0, errTrailingContent
+				}
+				return b.String(), consumed, nil
+			case c == '\\' && quote == '"' && j+1 < len(s):
+				j++
+				b.WriteString(unescape(s[j]))
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if consumed == len(following) {
+			return "", 0, errUnterminated
+		}
+		b.WriteByte('\n')
+		s = following[consumed]
+		consumed++
+	}
+}
+
+func unescape(c byte) string {
+	switch c {
+	case 'n':
+		return "\n"
+	case 'r':
+		return "\r"
+	case 't':
+		return "\t"
+	case '"', '\\', '$':
+		return string(c)
+	default:
+		return "\\" + string(c)
+	}
+}
+
+func parseUnquoted(s string) string {
+	if strings.HasPrefix(s, "#") {
+		return ""
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if unicode.IsSpace(r) || r == '"' || r == '\'' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("parses quoting, multiline values and export prefixes", func(t *testing.T) {
+		data := `# comment
+export PLAIN=value
+SPACED = spaced value   # inline comment
+HASH=a#b
+EMPTY=
+DOUBLE="line\nbreak \"quoted\" ${NOT_EXPANDED}"
+SINGLE='literal \n $HOME'
+MULTI="first
+second"
+KEY_PEM='-----BEGIN KEY-----
+abc
+-----END KEY-----' # trailing comment
+PLAIN=override
+`
+		variables, err := Parse(strings.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, []Variable{
+			{Key: "PLAIN", Value: "override"},
+			{Key: "SPACED", Value: "spaced value"},
+			{Key: "HASH", Value: "a#b"},
+			{Key: "EMPTY", Value: ""},
+			{Key: "DOUBLE", Value: "line\nbreak \"quoted\" ${NOT_EXPANDED}"},
+			{Key: "SINGLE", Value: `literal \n $HOME`},
+			{Key: "MULTI", Value: "first\nsecond"},
+			{Key: "KEY_PEM", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----"},
+		}, variables)
+	})
+	t.Run("handles windows line endings", func(t *testing.T) {
+		variables, err := Parse(strings.NewReader("A=1\r\nB=\"2\r\n3\"\r\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, []Variable{{Key: "A", Value: "1"}, {Key: "B", Value: "2\n3"}}, variables)
+	})
+	t.Run("returns an error with the line number", func(t *testing.T) {
+		tests := []struct {
+			data string
+			err  error
+			line string
+		}{
+			{data: "A=1\nNOT_A_VARIABLE", err: errMissingEquals, line: "line 2"},
+			{data: "=value", err: errInvalidKey, line: "line 1"},
+			{data: "MY KEY=value", err: errInvalidKey, line: "line 1"},
+			{data: "A=\"unterminated\nB=2", err: errUnterminated, line: "line 1"},
+			{data: "A='quoted' extra", err: errTrailingContent, line: "line 1"},
+		}
+		for _, tt := range tests {
+			_, err := Parse(strings.NewReader(tt.data))
+			assert.ErrorIs(t, err, tt.err)
+			assert.ErrorContains(t, err, tt.line)
+		}
+	})
+}
+
+func TestRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte("A=1\n"), os.ModePerm))
+	variables, err := Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Variable{{Key: "A", Value: "1"}}, variables)
+
+	_, err = Read(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadSkippingInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(path, []byte("A=1\nNOT_A_VARIABLE\nB=\"unterminated\nC=3\n"), os.ModePerm))
+	variables, skipped, err := ReadSkippingInvalid(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Variable{{Key: "A", Value: "1"}, {Key: "C", Value: "3"}}, variables)
+	if assert.Len(t, skipped, 2) {
+		assert.ErrorIs(t, skipped[0], errMissingEquals)
+		assert.ErrorContains(t, skipped[0], "line 2")
+		assert.ErrorIs(t, skipped[1], errUnterminated)
+		assert.ErrorContains(t, skipped[1], "line 3")
+	}
+
+	_, _, err = ReadSkippingInvalid(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,120 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/astronomer/astro-cli/config"
+	"github.com/astronomer/astro-cli/pkg/ansi"
+)
+
+const (
+	PreBuild   = "pre_build"
+	PostPush   = "post_push"
+	PostDeploy = "post_deploy"
+
+	DefaultTimeout = 5 * time.Minute
+)
+
+var (
+	errHookTimedOut = errors.New("timed out")
+
+	// Monkey patched to write unit tests
+	getDeployHooks = config.GetDeployHooks
+)
+
+// Env is the deploy information handed to every hook as environment variables
+type Env struct {
+	DeploymentID  string
+	ImageTag      string
+	DagVersion    string
+	DeploymentURL string
+	AirflowURL    string
+}
+
+func (e Env) vars(phase string) []string {
+	return []string{
+		"ASTRO_DEPLOY_PHASE=" + phase,
+		"ASTRO_DEPLOYMENT_ID=" + e.DeploymentID,
+		"ASTRO_IMAGE_TAG=" + e.ImageTag,
+		"ASTRO_DAG_VERSION=" + e.DagVersion,
+		"ASTRO_DEPLOYMENT_URL=" + e.DeploymentURL,
+		"ASTRO_AIRFLOW_URL=" + e.AirflowURL,
+	}
+}
+
+// Run runs the hooks of the project config set for the given phase, in order, from the project directory.
+// A failing pre_build hook stops the remaining hooks and returns an error so the deploy can be aborted,
+// failing hooks of later phases only print a warning since the deploy has already happened.
+func Run(phase, path string, env Env, out io.Writer) error {
+	deployHooks, err := getDeployHooks()
+	if err != nil {
+		return err
+	}
+
+	var phaseHooks []config.DeployHook
+	switch phase {
+	case PreBuild:
+		phaseHooks = deployHooks.PreBuild
+	case PostPush:
+		phaseHooks = deployHooks.PostPush
+	case PostDeploy:
+		phaseHooks = deployHooks.PostDeploy
+	}
+
+	for _, hook := range phaseHooks {
+		name := hook.Name
+		if name == "" {
+			name = hook.Command
+		}
+		fmt.Fprintf(out, "Running %s hook: %s\n", phase, name)
+		err := runHook(hook, path, env.vars(phase), out)
+		if err == nil {
+			continue
+		}
+		if phase == PreBuild {
+			return fmt.Errorf("%s hook %q failed, canceling deploy: %w", phase, name, err)
+		}
+		fmt.Fprintf(out, "%s %s hook %q failed: %s\n", ansi.Red("WARNING!"), phase, name, err.Error())
+	}
+	return nil
+}
+
+func runHook(hook config.DeployHook, path string, env []string, out io.Writer) error {
+	timeout := DefaultTimeout
+	if hook.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(hook.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q: %w", hook.Timeout, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Dir = path
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", errHookTimedOut, timeout)
+	}
+	return err
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,89 @@
+package hooks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/astronomer/astro-cli/config"
+	"github.com/stretchr/testify/assert"
+)
+
+var errMock = errors.New("mock error")
+
+func mockHooks(hooks config.DeployHooks, err error) func() {
+	previous := getDeployHooks
+	getDeployHooks = func() (config.DeployHooks, error) { return hooks, err }
+	return func() { getDeployHooks = previous }
+}
+
+func TestRun(t *testing.T) {
+	env := Env{DeploymentID: "test-id", ImageTag: "deploy-tag", DagVersion: "dag-version", DeploymentURL: "https://deployment", AirflowURL: "https://airflow"}
+
+	t.Run("passes deploy info as env vars", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{PostDeploy: []config.DeployHook{
+			{Command: `echo "$ASTRO_DEPLOY_PHASE $ASTRO_DEPLOYMENT_ID $ASTRO_IMAGE_TAG $ASTRO_DAG_VERSION $ASTRO_DEPLOYMENT_URL $ASTRO_AIRFLOW_URL"`},
+		}}, nil)()
+
+		out := new(bytes.Buffer)
+		err := Run(PostDeploy, t.TempDir(), env, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "post_deploy test-id deploy-tag dag-version https://deployment https://airflow")
+	})
+
+	t.Run("runs from the project directory", func(t *testing.T) {
+		dir := t.TempDir()
+		defer mockHooks(config.DeployHooks{PreBuild: []config.DeployHook{{Command: "pwd"}}}, nil)()
+
+		out := new(bytes.Buffer)
+		err := Run(PreBuild, dir, env, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), dir)
+	})
+
+	t.Run("failing pre build hook aborts", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{PreBuild: []config.DeployHook{
+			{Name: "fail", Command: "exit 1"},
+			{Command: "echo not reached"},
+		}}, nil)()
+
+		out := new(bytes.Buffer)
+		err := Run(PreBuild, t.TempDir(), env, out)
+		assert.ErrorContains(t, err, `pre_build hook "fail" failed`)
+		assert.NotContains(t, out.String(), "not reached")
+	})
+
+	t.Run("failing post hook warns", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{PostPush: []config.DeployHook{
+			{Name: "fail", Command: "exit 1"},
+			{Command: "echo still runs"},
+		}}, nil)()
+
+		out := new(bytes.Buffer)
+		err := Run(PostPush, t.TempDir(), env, out)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), `post_push hook "fail" failed`)
+		assert.Contains(t, out.String(), "still runs")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{PreBuild: []config.DeployHook{{Command: "exec sleep 5", Timeout: "100ms"}}}, nil)()
+
+		err := Run(PreBuild, t.TempDir(), env, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errHookTimedOut)
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{PreBuild: []config.DeployHook{{Command: "true", Timeout: "soon"}}}, nil)()
+
+		err := Run(PreBuild, t.TempDir(), env, new(bytes.Buffer))
+		assert.ErrorContains(t, err, `invalid timeout "soon"`)
+	})
+
+	t.Run("config error", func(t *testing.T) {
+		defer mockHooks(config.DeployHooks{}, errMock)()
+
+		err := Run(PreBuild, t.TempDir(), env, new(bytes.Buffer))
+		assert.ErrorIs(t, err, errMock)
+	})
+}
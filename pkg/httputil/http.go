@@ -31,10 +31,30 @@ type DoOptions struct {
 	Path    string
 }
 
-// NewHTTPClient returns a new HTTP Client
+// NewHTTPClient returns a new HTTP Client that uses the http settings, its requests are traced to the trace file
 func NewHTTPClient() *HTTPClient {
+	return NewHTTPClientWithTransport(nil)
+}
+
+// NewHTTPClientWithTransport returns a new HTTP Client like NewHTTPClient whose transport is changed by configure.
+// The requests of the client fail if the http settings are invalid.
+func NewHTTPClientWithTransport(configure func(*http.Transport)) *HTTPClient {
+	var base http.RoundTripper
+	transport, err := NewTransport()
+	if err != nil {
+		base = errorTransport{err: err}
+	} else {
+		if configure != nil {
+			configure(transport)
+		}
+		base = transport
+	}
+	timeout, err := Timeout()
+	if err != nil {
+		base = errorTransport{err: err}
+	}
 	return &HTTPClient{
-		HTTPClient: &http.Client{},
+		HTTPClient: &http.Client{Transport: NewTraceTransport(base), Timeout: timeout},
 	}
 }
 
@@ -0,0 +1,421 @@
+package httputil
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/astronomer/astro-cli/version"
+)
+
+const (
+	// TraceEnv is the environment variable that sets the trace file, like --trace-file
+	TraceEnv = "ASTRO_TRACE"
+
+	// RequestIDHeader identifies a request of the CLI in the logs of the APIs, it is sent with every request
+	RequestIDHeader = "X-Request-Id"
+
+	redacted = "REDACTED"
+	// maxTraceBodySize is the size of the bodies that are written to the trace file, longer bodies are cut
+	maxTraceBodySize = 64 * 1024
+	traceFilePerms   = 0o600
+)
+
+var (
+	// requestIDHeaders are the response headers the APIs identify a request with
+	requestIDHeaders = []string{RequestIDHeader, "X-Amzn-Trace-Id", "X-Trace-Id", "Traceparent"}
+
+	// sensitiveNames are parts of the names of headers, query parameters and JSON properties whose values are redacted
+	sensitiveNames = []string{"authorization", "token", "password", "secret", "apikey", "cookie", "credential", "signature"}
+	// signedURLParams are the query parameters of Azure SAS URLs, like the URLs DAGs are uploaded to, that grant
+	// access to a storage account. Their names are too short to be matched as parts of names.
+	signedURLParams = []string{"sig", "se", "st", "sv", "sp", "sr", "ss", "srt", "spr", "si", "skoid", "sktid", "skt", "ske", "sks", "skv", "sdd"}
+
+	tracer = &traceFile{}
+)
+
+// SetTraceFile sets the file the requests of all HTTP clients are traced to, tracing is off if path is empty.
+// The file is a HAR archive that is rewritten after every request, so it is complete even if the command fails.
+func SetTraceFile(path string) error {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	tracer.path = path
+	tracer.har = har{Log: harLog{Version: "1.2", Creator: harCreator{Name: "astro-cli", Version: version.CurrVersion}, Entries: []harEntry{}}}
+	if path == "" {
+		return nil
+	}
+	if err := tracer.write(); err != nil {
+		tracer.path = ""
+		return err
+	}
+	return nil
+}
+
+// TraceFile returns the file requests are traced to, it is empty if tracing is off
+func TraceFile() string {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	return tracer.path
+}
+
+// traceFile is the HAR archive of the requests of a command
+type traceFile struct {
+	mu   sync.Mutex
+	path string
+	har  har
+}
+
+func (f *traceFile) add(entry *harEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.path == "" {
+		return
+	}
+	f.har.Log.Entries = append(f.har.Log.Entries, *entry)
+	if err := f.write(); err != nil {
+		logrus.Debugf("Unable to write trace file %s: %s", f.path, err.Error())
+	}
+}
+
+func (f *traceFile) write() error {
+	data, err := json.MarshalIndent(f.har, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, data, traceFilePerms)
+}
+
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	RequestID       string      `json:"_requestId,omitempty"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// traceTransport sends a request ID with every request, logs the requests at debug level and writes them to the
+// trace file when tracing is on
+type traceTransport struct {
+	base http.RoundTripper
+}
+
+// NewTraceTransport returns a RoundTripper that traces the requests it sends with base
+func NewTraceTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := base.(*traceTransport); ok {
+		return base
+	}
+	return &traceTransport{base: base}
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the request of the caller is not changed
+	req = req.Clone(req.Context())
+	if req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
+	tracing := TraceFile() != ""
+	var reqBody []byte
+	if tracing && req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		logrus.Debugf("%s %s failed after %s, request ID %s: %s", req.Method, redactURL(req.URL), duration, req.Header.Get(RequestIDHeader), err.Error())
+	} else {
+		logrus.Debugf("%s %s %d in %s, request ID %s", req.Method, redactURL(req.URL), res.StatusCode, duration, requestID(req, res))
+	}
+	if !tracing {
+		return res, err
+	}
+
+	entry := newHAREntry(req, reqBody, start, duration)
+	if err != nil {
+		entry.Error = err.Error()
+		tracer.add(&entry)
+		return res, err
+	}
+	resBody, readErr := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	if readErr != nil {
+		entry.Error = readErr.Error()
+	}
+	entry.RequestID = requestID(req, res)
+	entry.Response = harResponse{
+		Status:      res.StatusCode,
+		StatusText:  http.StatusText(res.StatusCode),
+		HTTPVersion: res.Proto,
+		Headers:     redactHeaders(res.Header),
+		Content: harContent{
+			Size:     len(resBody),
+			MimeType: res.Header.Get("Content-Type"),
+			Text:     redactBody(res.Header.Get("Content-Type"), resBody),
+		},
+		HeadersSize: -1,
+		BodySize:    len(resBody),
+	}
+	tracer.add(&entry)
+	return res, readErr
+}
+
+func newHAREntry(req *http.Request, body []byte, start time.Time, duration time.Duration) harEntry {
+	millis := float64(duration.Microseconds()) / 1000 //nolint:gomnd
+	entry := harEntry{
+		StartedDateTime: start,
+		Time:            millis,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Headers:     redactHeaders(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		Response:  harResponse{Headers: []harNameValue{}, HeadersSize: -1, BodySize: -1},
+		Timings:   harTimings{Wait: millis},
+		RequestID: req.Header.Get(RequestIDHeader),
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: redactValue(name, value)})
+		}
+	}
+	if len(body) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     redactBody(req.Header.Get("Content-Type"), body),
+		}
+	}
+	return entry
+}
+
+// requestID returns the ID the API gave a request, or the ID the CLI sent if the API did not return one
+func requestID(req *http.Request, res *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := res.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return req.Header.Get(RequestIDHeader)
+}
+
+func newRequestID() string {
+	id := make([]byte, 16) //nolint:gomnd
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// isSensitive returns if the value of a header, query parameter or JSON property called name is redacted
+func isSensitive(name string) bool {
+	name = strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveQueryParam returns if the value of a query parameter called name is redacted
+func isSensitiveQueryParam(name string) bool {
+	if isSensitive(name) {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, param := range signedURLParams {
+		if name == param {
+			return true
+		}
+	}
+	return false
+}
+
+func redactValue(name, value string) string {
+	if isSensitive(name) {
+		return redacted
+	}
+	return redactString(value)
+}
+
+// redactString redacts the sensitive query parameters of a value that is a URL, like the signed URLs in responses
+func redactString(value string) string {
+	if !strings.Contains(value, "?") {
+		return value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return value
+	}
+	return redactURL(u)
+}
+
+func redactHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: redactValue(name, value)})
+		}
+	}
+	return headers
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for name := range query {
+		if isSensitiveQueryParam(name) {
+			query.Set(name, redacted)
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+// redactBody returns a JSON or form body with the values of sensitive properties and secret environment
+// variables redacted. Other bodies are left out as they can not be redacted.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return redacted
+		}
+		for name := range form {
+			if isSensitive(name) {
+				form.Set(name, redacted)
+			}
+		}
+		return cut(form.Encode())
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return redacted
+	}
+	redactedBody, err := json.Marshal(redactJSON(data))
+	if err != nil {
+		return redacted
+	}
+	return cut(string(redactedBody))
+}
+
+// redactJSON redacts the values of sensitive properties, the values of environment variables that are secret and the
+// sensitive query parameters of URLs
+func redactJSON(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		secret := v["isSecret"] == true || v["is_secret"] == true
+		for name, value := range v {
+			if isSensitive(name) || (secret && name == "value") {
+				v[name] = redacted
+				continue
+			}
+			v[name] = redactJSON(value)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+		return v
+	case string:
+		return redactString(v)
+	default:
+		return v
+	}
+}
+
+func cut(text string) string {
+	if len(text) > maxTraceBodySize {
+		return text[:maxTraceBodySize] + "..."
+	}
+	return text
+}
@@ -0,0 +1,96 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/astronomer/astro-cli/config"
+)
+
+var (
+	errInvalidCABundle  = errors.New("has no PEM certificates")
+	errClientCertAndKey = errors.New("http.client_cert and http.client_key must be set together")
+	errInvalidProxy     = errors.New("is not a valid proxy URL, it must be like http://proxy.example.com:8080")
+)
+
+// errorTransport fails every request with an error of the http settings, so commands that send requests explain
+// why they can not
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// NewTransport returns an HTTP transport that uses the http settings of the global config: the proxy, the CA bundle
+// that is trusted with the system CAs and the client certificate that is presented to servers that ask for one.
+// The settings are only read from the global config and the environment, so a project can not change them.
+func NewTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if proxy := config.CFG.HTTPProxy.GetGlobalString(); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("http.proxy %s %w", proxy, errInvalidProxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return transport, nil
+}
+
+// Timeout returns the timeout of requests set with http.timeout, requests do not time out if it is 0
+func Timeout() (time.Duration, error) {
+	timeout := config.CFG.HTTPTimeout.GetGlobalString()
+	if timeout == "" {
+		return 0, nil
+	}
+	if err := config.CFG.HTTPTimeout.Validate(timeout); err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(timeout)
+}
+
+func newTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caBundle := config.CFG.HTTPCABundle.GetGlobalString(); caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("http.ca_bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("http.ca_bundle %s %w", caBundle, errInvalidCABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert, clientKey := config.CFG.HTTPClientCert.GetGlobalString(), config.CFG.HTTPClientKey.GetGlobalString()
+	if clientCert == "" && clientKey == "" {
+		return tlsConfig, nil
+	}
+	if clientCert == "" || clientKey == "" {
+		return nil, errClientCertAndKey
+	}
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("http.client_cert and http.client_key: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tlsConfig, nil
+}
@@ -0,0 +1,176 @@
+package httputil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/astronomer/astro-cli/config"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path
+func writePEM(t *testing.T, dir, name, blockType string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600)
+	assert.NoError(t, err)
+	return path
+}
+
+// newClientCert writes a self signed client certificate and its key to dir
+func newClientCert(t *testing.T, dir string) (cert *x509.Certificate, certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "astro-cli"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err = x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return cert, writePEM(t, dir, "client.crt", "CERTIFICATE", der), writePEM(t, dir, "client.key", "EC PRIVATE KEY", keyDER)
+}
+
+func initTestConfig(t *testing.T) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, config.HomeConfigFile, []byte("context: \n"), 0o600))
+	config.InitConfig(fs)
+}
+
+func get(url string) error {
+	resp, err := NewHTTPClient().Do(&DoOptions{Method: http.MethodGet, Path: url})
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestNewHTTPClientCABundle(t *testing.T) {
+	initTestConfig(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	t.Run("the certificate of the server is not trusted", func(t *testing.T) {
+		err := get(server.URL)
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("the certificate of the server is in the CA bundle", func(t *testing.T) {
+		caBundle := writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+		assert.NoError(t, config.CFG.HTTPCABundle.SetHomeString(caBundle))
+		defer config.CFG.HTTPCABundle.SetHomeString("") //nolint:errcheck
+
+		assert.NoError(t, get(server.URL))
+	})
+
+	t.Run("the CA bundle has no certificates", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.pem")
+		assert.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+		t.Setenv(config.CFG.HTTPCABundle.EnvName(), invalid)
+
+		assert.ErrorIs(t, get(server.URL), errInvalidCABundle)
+	})
+
+	t.Run("the CA bundle does not exist", func(t *testing.T) {
+		t.Setenv(config.CFG.HTTPCABundle.EnvName(), filepath.Join(dir, "missing.pem"))
+
+		assert.ErrorIs(t, get(server.URL), os.ErrNotExist)
+	})
+}
+
+func TestNewHTTPClientClientCert(t *testing.T) {
+	initTestConfig(t)
+	dir := t.TempDir()
+	cert, certFile, keyFile := newClientCert(t, dir)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	t.Setenv(config.CFG.HTTPCABundle.EnvName(), writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw))
+
+	t.Run("no client certificate", func(t *testing.T) {
+		assert.Error(t, get(server.URL))
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		t.Setenv(config.CFG.HTTPClientCert.EnvName(), certFile)
+		t.Setenv(config.CFG.HTTPClientKey.EnvName(), keyFile)
+
+		assert.NoError(t, get(server.URL))
+	})
+
+	t.Run("client certificate without key", func(t *testing.T) {
+		t.Setenv(config.CFG.HTTPClientCert.EnvName(), certFile)
+
+		assert.ErrorIs(t, get(server.URL), errClientCertAndKey)
+	})
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	initTestConfig(t)
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	t.Run("requests are sent through the proxy", func(t *testing.T) {
+		t.Setenv(config.CFG.HTTPProxy.EnvName(), proxy.URL)
+
+		assert.NoError(t, get("http://astronomer.invalid/test"))
+		assert.Equal(t, "http://astronomer.invalid/test", proxied)
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		t.Setenv(config.CFG.HTTPProxy.EnvName(), "proxy:8080")
+
+		assert.ErrorIs(t, get("http://astronomer.invalid/test"), errInvalidProxy)
+	})
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	initTestConfig(t)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	assert.Equal(t, time.Duration(0), NewHTTPClient().HTTPClient.Timeout)
+
+	t.Setenv(config.CFG.HTTPTimeout.EnvName(), "20ms")
+	assert.Equal(t, 20*time.Millisecond, NewHTTPClient().HTTPClient.Timeout)
+	assert.ErrorContains(t, get(server.URL), "Client.Timeout exceeded")
+
+	t.Setenv(config.CFG.HTTPTimeout.EnvName(), "soon")
+	assert.ErrorContains(t, get(server.URL), "it must be of type duration")
+}
@@ -2,6 +2,7 @@ package input
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -11,8 +12,54 @@ import (
 	"golang.org/x/term"
 )
 
-// Text requests a user for input text and returns it
+// ErrNoInput is returned by prompts when input is disabled with --no-input or because stdin is not a terminal
+var ErrNoInput = errors.New("cannot prompt for input with --no-input or when stdin is not a terminal")
+
+var (
+	// noInput disables prompts, they return their default or fail with ErrNoInput
+	noInput bool
+	// assumeYes answers yes to confirmations
+	assumeYes bool
+)
+
+// SetNoInput disables prompts, set with --no-input or when stdin is not a terminal
+func SetNoInput(disabled bool) {
+	noInput = disabled
+}
+
+// NoInput is true if prompts are disabled
+func NoInput() bool {
+	return noInput
+}
+
+// SetAssumeYes answers yes to every confirmation without prompting, set with --yes
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
+// StdinIsTerminal is true if stdin is a terminal that a user can answer prompts in
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) //nolint: unconvert
+}
+
+// Required returns ErrNoInput with a hint that names the flag to pass instead of answering a prompt
+func Required(hint string) error {
+	return fmt.Errorf("%w, %s", ErrNoInput, hint)
+}
+
+// Prompt requests a user for input text like Text, when prompts are disabled it fails with hint instead
+func Prompt(promptText, hint string) (string, error) {
+	if noInput {
+		return "", Required(hint)
+	}
+	return Text(promptText), nil
+}
+
+// Text requests a user for input text and returns it, when prompts are disabled it returns an empty string
 func Text(promptText string) string {
+	if noInput {
+		return ""
+	}
 	reader := bufio.NewReader(os.Stdin)
 	if promptText != "" {
 		fmt.Print(promptText)
@@ -21,8 +68,15 @@ func Text(promptText string) string {
 	return strings.Trim(text, "\r\n")
 }
 
-// Confirm requests a user to confirm their input
+// Confirm requests a user to confirm their input. It is confirmed without prompting with --yes and fails when
+// prompts are disabled.
 func Confirm(promptText string) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if noInput {
+		return false, Required("pass --yes to confirm")
+	}
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Printf("%s (y/n) ", promptText)
 
@@ -32,6 +86,9 @@ func Confirm(promptText string) (bool, error) {
 
 // Password requests a users passord, does not print out what they entered, and returns it
 func Password(promptText string) (string, error) {
+	if noInput {
+		return "", ErrNoInput
+	}
 	fmt.Print(promptText)
 	bytePassword, err := term.ReadPassword(int(syscall.Stdin)) //nolint: unconvert
 	if err != nil {
@@ -59,6 +116,12 @@ func GetYesNoSelector(pc PromptContent) *promptui.Select {
 
 // Gets a y/n confirmation from the user for the given prompt content using the promptui library and returns a boolean accordingly
 func PromptGetConfirmation(runner PromptRunner) (bool, error) {
+	if assumeYes {
+		return true, nil
+	}
+	if noInput {
+		return false, Required("pass --yes to confirm")
+	}
 	_, result, err := runner.Run()
 	if err != nil {
 		return false, err
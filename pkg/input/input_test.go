@@ -1,6 +1,7 @@
 package input
 
 import (
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -208,3 +209,54 @@ func TestPromptGetConfirmation(t *testing.T) {
 		})
 	}
 }
+
+func TestNoInput(t *testing.T) {
+	// prompts must not read stdin, it is closed
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	r.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	SetNoInput(true)
+	defer func() {
+		os.Stdin = stdin
+		SetNoInput(false)
+	}()
+
+	if got := Text("enter text input"); got != "" {
+		t.Errorf("Text() = %v, want empty default", got)
+	}
+	if _, err := Prompt("enter text input", "pass --name"); !errors.Is(err, ErrNoInput) || !strings.Contains(err.Error(), "pass --name") {
+		t.Errorf("Prompt() error = %v, want ErrNoInput naming --name", err)
+	}
+	if got, err := Confirm("confirm"); got || !errors.Is(err, ErrNoInput) || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("Confirm() = %v, %v, want false and ErrNoInput naming --yes", got, err)
+	}
+	if _, err := Password("password"); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Password() error = %v, want ErrNoInput", err)
+	}
+	runner := GetYesNoSelector(PromptContent{Label: "test label, enter y/n"})
+	if got, err := PromptGetConfirmation(runner); got || !errors.Is(err, ErrNoInput) {
+		t.Errorf("PromptGetConfirmation() = %v, %v, want false and ErrNoInput", got, err)
+	}
+}
+
+func TestAssumeYes(t *testing.T) {
+	SetNoInput(true)
+	SetAssumeYes(true)
+	defer func() {
+		SetNoInput(false)
+		SetAssumeYes(false)
+	}()
+
+	if got, err := Confirm("confirm"); !got || err != nil {
+		t.Errorf("Confirm() = %v, %v, want true", got, err)
+	}
+	runner := GetYesNoSelector(PromptContent{Label: "test label, enter y/n"})
+	if got, err := PromptGetConfirmation(runner); !got || err != nil {
+		t.Errorf("PromptGetConfirmation() = %v, %v, want true", got, err)
+	}
+}
@@ -0,0 +1,173 @@
+package printutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	TableOutput    = "table"
+	JSONOutput     = "json"
+	YAMLOutput     = "yaml"
+	TemplateOutput = "template"
+
+	// OutputUsage is the usage of the --output flag of list and get commands
+	OutputUsage = "Output format can be one of: table, json, yaml or template=<go template>. Templates are run for each row, " +
+		"fields are named like the JSON fields in CamelCase, like template={{.ID}} for id"
+
+	templatePrefix = TemplateOutput + "="
+)
+
+// FieldType is the type of a field in the json and yaml outputs
+type FieldType int
+
+const (
+	// StringField is the type of fields without a type
+	StringField FieldType = iota
+	BoolField
+	IntField
+)
+
+var (
+	ErrInvalidOutput = errors.New("is not a valid output format, it can be table, json, yaml or template=<go template>")
+
+	// initialisms are upper case in the template names of fields, like ID for id
+	initialisms = map[string]bool{"id": true, "url": true, "api": true, "au": true, "cpu": true, "ram": true}
+)
+
+// Writer prints the tables that are printed to it in an output format other than table. Anything else that is
+// written to it is dropped, so the output can be parsed.
+type Writer struct {
+	out      io.Writer
+	format   string
+	template *template.Template
+}
+
+// NewWriter returns a writer that prints tables in output, a format set with --output. out is returned for the table
+// format, so the commands that write to it are unchanged.
+func NewWriter(out io.Writer, output string) (io.Writer, error) {
+	w := &Writer{out: out, format: output}
+	switch {
+	case output == "" || output == TableOutput:
+		return out, nil
+	case output == JSONOutput || output == YAMLOutput:
+		return w, nil
+	case strings.HasPrefix(output, templatePrefix):
+		tmpl, err := template.New(TemplateOutput).Parse(strings.TrimPrefix(output, templatePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid output template: %w", err)
+		}
+		w.format = TemplateOutput
+		w.template = tmpl
+		return w, nil
+	default:
+		return nil, fmt.Errorf("%s %w", output, ErrInvalidOutput)
+	}
+}
+
+// Write drops text that is not a table
+func (w *Writer) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// printTable prints the rows of t as a list of records keyed by the fields of t
+func (w *Writer) printTable(t *Table) error {
+	records := t.Records()
+	switch w.format {
+	case JSONOutput:
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w.out, string(data))
+		return err
+	case YAMLOutput:
+		data, err := yaml.Marshal(records)
+		if err != nil {
+			return err
+		}
+		_, err = w.out.Write(data)
+		return err
+	default:
+		for _, record := range records {
+			data := make(map[string]interface{}, len(record))
+			for field, value := range record {
+				data[templateName(field)] = value
+			}
+			if err := w.template.Execute(w.out, data); err != nil {
+				return fmt.Errorf("error running output template: %w", err)
+			}
+			fmt.Fprintln(w.out)
+		}
+		return nil
+	}
+}
+
+// Records returns the rows of the table keyed by its field names, values are converted to the FieldTypes of their
+// field. Columns without a field name are left out.
+func (t *Table) Records() []map[string]interface{} {
+	fields := t.fields()
+	records := make([]map[string]interface{}, 0, len(t.Rows))
+	for _, r := range t.Rows {
+		record := make(map[string]interface{}, len(fields))
+		for i, value := range r.Raw {
+			if i < len(fields) && fields[i] != "" {
+				record[fields[i]] = convertValue(value, t.FieldTypes[fields[i]])
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// convertValue returns value as a fieldType, or as it is if it can not be converted
+func convertValue(value string, fieldType FieldType) interface{} {
+	switch fieldType {
+	case BoolField:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case IntField:
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	case StringField:
+	}
+	return value
+}
+
+// fields returns Fields or field names made from the header, like deployment_name for DEPLOYMENT NAME
+func (t *Table) fields() []string {
+	if len(t.Fields) > 0 {
+		return t.Fields
+	}
+	fields := make([]string, len(t.Header))
+	for i, header := range t.Header {
+		words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		fields[i] = strings.Join(words, "_")
+	}
+	return fields
+}
+
+// templateName returns the name of a field in templates, like DeploymentID for deployment_id
+func templateName(field string) string {
+	var name strings.Builder
+	for _, word := range strings.Split(field, "_") {
+		if initialisms[word] {
+			name.WriteString(strings.ToUpper(word))
+		} else if word != "" {
+			name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return name.String()
+}
@@ -0,0 +1,128 @@
+package printutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func newOutputTestTable() *Table {
+	tab := &Table{
+		Padding:      []int{5, 30, 30},
+		Header:       []string{"#", "DEPLOYMENT NAME", "ID"},
+		NoResultsMsg: "No deployments",
+		SuccessMsg:   "Listed deployments",
+	}
+	tab.AddRow([]string{"1", "first", "test-id-1"}, false)
+	tab.AddRow([]string{"2", "second", "test-id-2"}, true)
+	return tab
+}
+
+func TestNewWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	for _, output := range []string{"", TableOutput} {
+		out, err := NewWriter(buf, output)
+		assert.NoError(t, err)
+		assert.Equal(t, buf, out)
+	}
+
+	_, err := NewWriter(buf, "xml")
+	assert.ErrorIs(t, err, ErrInvalidOutput)
+	_, err = NewWriter(buf, "template={{.ID")
+	assert.ErrorContains(t, err, "invalid output template")
+}
+
+func TestRecords(t *testing.T) {
+	tab := newOutputTestTable()
+	assert.Equal(t, []map[string]interface{}{
+		{"deployment_name": "first", "id": "test-id-1"},
+		{"deployment_name": "second", "id": "test-id-2"},
+	}, tab.Records())
+
+	tab.Fields = []string{"", "name"}
+	assert.Equal(t, []map[string]interface{}{{"name": "first"}, {"name": "second"}}, tab.Records())
+
+	tab = &Table{
+		Header:     []string{"NAME", "DEFAULT", "WORKERS"},
+		Fields:     []string{"name", "is_default", "worker_count"},
+		FieldTypes: map[string]FieldType{"is_default": BoolField, "worker_count": IntField},
+	}
+	tab.AddRow([]string{"default", "true", "2"}, false)
+	tab.AddRow([]string{"10", "N/A", ""}, false)
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "default", "is_default": true, "worker_count": 2},
+		{"name": "10", "is_default": "N/A", "worker_count": ""},
+	}, tab.Records())
+}
+
+func TestPrintOutput(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, JSONOutput)
+		assert.NoError(t, err)
+		assert.NoError(t, newOutputTestTable().Print(out))
+
+		var records []map[string]string
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+		assert.Equal(t, "test-id-2", records[1]["id"])
+		assert.NotContains(t, buf.String(), "Listed deployments")
+	})
+
+	t.Run("typed fields", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, JSONOutput)
+		assert.NoError(t, err)
+		tab := &Table{Header: []string{"NAME", "ENABLED"}, FieldTypes: map[string]FieldType{"enabled": BoolField}}
+		tab.AddRow([]string{"first", "false"}, false)
+		assert.NoError(t, tab.Print(out))
+		assert.Contains(t, buf.String(), `"enabled": false`)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, YAMLOutput)
+		assert.NoError(t, err)
+		assert.NoError(t, newOutputTestTable().PrintWithPageNumber(0, out))
+
+		var records []map[string]string
+		assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &records))
+		assert.Equal(t, "first", records[0]["deployment_name"])
+	})
+
+	t.Run("template", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, "template={{.ID}}: {{.DeploymentName}}")
+		assert.NoError(t, err)
+		assert.NoError(t, newOutputTestTable().Print(out))
+		assert.Equal(t, "test-id-1: first\ntest-id-2: second\n", buf.String())
+	})
+
+	t.Run("empty tables are empty lists", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, JSONOutput)
+		assert.NoError(t, err)
+		tab := &Table{Header: []string{"ID"}, NoResultsMsg: "No deployments"}
+		assert.NoError(t, tab.Print(out))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("text that is not a table is dropped", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		out, err := NewWriter(buf, JSONOutput)
+		assert.NoError(t, err)
+		n, err := out.Write([]byte("Listing deployments\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, 20, n)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestTemplateName(t *testing.T) {
+	assert.Equal(t, "ID", templateName("id"))
+	assert.Equal(t, "DeploymentID", templateName("deployment_id"))
+	assert.Equal(t, "DagDeployEnabled", templateName("dag_deploy_enabled"))
+	assert.Equal(t, "APIKey", templateName("api_key"))
+}
@@ -16,6 +16,13 @@ type Table struct {
 	Header         []string
 	RenderedHeader string
 
+	// Field names of the columns in the json, yaml and template outputs, they are made from Header if not set
+	Fields []string
+
+	// Types of the fields that are not strings in the json and yaml outputs, like BoolField for a dag_deploy_enabled
+	// field. Values that can not be converted, like N/A, are kept as strings
+	FieldTypes map[string]FieldType
+
 	// Truncate rows if they exceed padding length
 	Truncate bool
 
@@ -67,6 +74,9 @@ func (t *Table) AddRow(values []string, color bool) {
 
 // Print header __as well as__ rows
 func (t *Table) Print(out io.Writer) error {
+	if w, ok := out.(*Writer); ok {
+		return w.printTable(t)
+	}
 	if len(t.Rows) == 0 && t.NoResultsMsg != "" {
 		fmt.Fprintln(out, t.NoResultsMsg)
 		return nil
@@ -83,6 +93,9 @@ func (t *Table) Print(out io.Writer) error {
 
 // Print header __as well as__ rows
 func (t *Table) PrintWithPageNumber(pageNumber int, out io.Writer) error {
+	if w, ok := out.(*Writer); ok {
+		return w.printTable(t)
+	}
 	if len(t.Rows) == 0 && t.NoResultsMsg != "" {
 		fmt.Fprintln(out, t.NoResultsMsg)
 		return nil
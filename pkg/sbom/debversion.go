@@ -0,0 +1,88 @@
+package sbom
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareDebianVersions compares two versions of a Debian package like dpkg does, so versions like 1.1.1n-0+deb11u3
+// that are not semantic versions are ordered. It returns a negative number if a is older than b, 0 if they are the
+// same version and a positive number if a is newer.
+func compareDebianVersions(a, b string) int {
+	epochA, upstreamA, revisionA := splitDebianVersion(a)
+	epochB, upstreamB, revisionB := splitDebianVersion(b)
+	if epochA != epochB {
+		return epochA - epochB
+	}
+	if c := compareDebianPart(upstreamA, upstreamB); c != 0 {
+		return c
+	}
+	return compareDebianPart(revisionA, revisionB)
+}
+
+// splitDebianVersion splits a version in its [epoch:]upstream[-revision] parts
+func splitDebianVersion(version string) (epoch int, upstream, revision string) {
+	if i := strings.IndexByte(version, ':'); i >= 0 {
+		epoch, _ = strconv.Atoi(version[:i])
+		version = version[i+1:]
+	}
+	if i := strings.LastIndexByte(version, '-'); i >= 0 {
+		return epoch, version[:i], version[i+1:]
+	}
+	return epoch, version, ""
+}
+
+// compareDebianPart compares the upstream versions or revisions of two versions. Non digits are compared character
+// by character with letters before other characters and ~ before anything, even the end of the part. Digits are
+// compared as numbers.
+func compareDebianPart(a, b string) int {
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			orderA, orderB := debianCharOrder(a), debianCharOrder(b)
+			if orderA != orderB {
+				return orderA - orderB
+			}
+			a, b = a[1:], b[1:]
+		}
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		firstDiff := 0
+		for a != "" && b != "" && isDigit(a[0]) && isDigit(b[0]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[0]) - int(b[0])
+			}
+			a, b = a[1:], b[1:]
+		}
+		if a != "" && isDigit(a[0]) {
+			return 1
+		}
+		if b != "" && isDigit(b[0]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// debianCharOrder is the order of the first character of s, the end of s and digits are 0
+func debianCharOrder(s string) int {
+	if s == "" {
+		return 0
+	}
+	c := s[0]
+	switch {
+	case isDigit(c):
+		return 0
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256 //nolint:gomnd
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,101 @@
+package sbom
+
+import (
+	"sort"
+
+	goVersion "github.com/hashicorp/go-version"
+)
+
+// PackageChange is a package whose version differs between two package lists
+type PackageChange struct {
+	Name string
+	From string
+	To   string
+}
+
+// IsUpgrade returns true if the new version is higher than the old one
+func (c PackageChange) IsUpgrade() bool {
+	from, errFrom := goVersion.NewVersion(c.From)
+	to, errTo := goVersion.NewVersion(c.To)
+	if errFrom != nil || errTo != nil {
+		return c.To > c.From
+	}
+	return to.GreaterThan(from)
+}
+
+// IsMajor returns true if the change crosses a major version in either direction
+func (c PackageChange) IsMajor() bool {
+	from, errFrom := goVersion.NewVersion(c.From)
+	to, errTo := goVersion.NewVersion(c.To)
+	if errFrom != nil || errTo != nil {
+		return false
+	}
+	return from.Segments()[0] != to.Segments()[0]
+}
+
+// PackageDiff holds the differences between the packages of two images
+type PackageDiff struct {
+	Added   []Package
+	Removed []Package
+	Changed []PackageChange
+}
+
+// Empty returns true if both package lists were the same
+func (d PackageDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// MajorChanges returns the changed packages that crossed a major version
+func (d PackageDiff) MajorChanges() []PackageChange {
+	var major []PackageChange
+	for _, c := range d.Changed {
+		if c.IsMajor() {
+			major = append(major, c)
+		}
+	}
+	return major
+}
+
+// Diff compares two package lists of the same type, package names are matched the way pip matches them
+func Diff(previous, current []Package) PackageDiff {
+	before := map[string]Package{}
+	for _, p := range previous {
+		before[packageKey(p.Type, p.Name)] = p
+	}
+	after := map[string]Package{}
+	for _, p := range current {
+		after[packageKey(p.Type, p.Name)] = p
+	}
+
+	var diff PackageDiff
+	for key, p := range after {
+		old, ok := before[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, p)
+		case old.Version != p.Version:
+			diff.Changed = append(diff.Changed, PackageChange{Name: p.Name, From: old.Version, To: p.Version})
+		}
+	}
+	for key, p := range before {
+		if _, ok := after[key]; !ok {
+			diff.Removed = append(diff.Removed, p)
+		}
+	}
+
+	diff.Added = sorted(diff.Added)
+	diff.Removed = sorted(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
+
+// OfType returns the packages of the given type
+func OfType(packages []Package, packageType string) []Package {
+	var out []Package
+	for _, p := range packages {
+		if p.Type == packageType {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lucsky/cuid"
+)
+
+const (
+	SPDX      = "spdx"
+	CycloneDX = "cyclonedx"
+
+	PythonPackage = "pypi"
+	DebianPackage = "deb"
+
+	spdxVersion      = "SPDX-2.3"
+	cycloneDXVersion = "1.4"
+	toolName         = "astro-cli"
+)
+
+var errInvalidFormat = errors.New("invalid SBOM format, use one of: spdx, cyclonedx")
+
+// Package is a single Python or OS package installed in an image
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+}
+
+// PURL returns the package URL of the package, see https://github.com/package-url/purl-spec
+func (p Package) PURL() string {
+	switch p.Type {
+	case PythonPackage:
+		return fmt.Sprintf("pkg:pypi/%s@%s", strings.ToLower(p.Name), p.Version)
+	case DebianPackage:
+		return fmt.Sprintf("pkg:deb/debian/%s@%s", p.Name, p.Version)
+	default:
+		return fmt.Sprintf("pkg:generic/%s@%s", p.Name, p.Version)
+	}
+}
+
+// ValidFormat returns true if format is a supported SBOM format
+func ValidFormat(format string) bool {
+	return format == SPDX || format == CycloneDX
+}
+
+// Generate renders the packages of an image as an SPDX or CycloneDX JSON document
+func Generate(format, image string, packages []Package, created time.Time) ([]byte, error) {
+	packages = sorted(packages)
+	switch format {
+	case SPDX:
+		return json.MarshalIndent(spdxDocument(image, packages, created), "", "  ")
+	case CycloneDX:
+		return json.MarshalIndent(cycloneDXDocument(image, packages, created), "", "  ")
+	default:
+		return nil, errInvalidFormat
+	}
+}
+
+func sorted(packages []Package) []Package {
+	out := make([]Package, len(packages))
+	copy(out, packages)
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name)
+	})
+	return out
+}
+
+type spdxDoc struct {
+	SPDXVersion       string        `json:"spdxVersion"`
+	DataLicense       string        `json:"dataLicense"`
+	SPDXID            string        `json:"SPDXID"`
+	Name              string        `json:"name"`
+	DocumentNamespace string        `json:"documentNamespace"`
+	CreationInfo      spdxCreation  `json:"creationInfo"`
+	Packages          []spdxPackage `json:"packages"`
+}
+
+type spdxCreation struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	DownloadLocation string            `json:"downloadLocation"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+func spdxDocument(image string, packages []Package, created time.Time) spdxDoc {
+	doc := spdxDoc{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              image,
+		DocumentNamespace: "https://astronomer.io/spdx/" + cuid.New(),
+		CreationInfo: spdxCreation{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{},
+	}
+	for i, p := range packages {
+		doc.Packages = append(doc.Packages, spdxPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%s-%d", p.Type, i),
+			Name:             p.Name,
+			VersionInfo:      p.Version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []spdxExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: p.PURL()},
+			},
+		})
+	}
+	return doc
+}
+
+type cycloneDXDoc struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+func cycloneDXDocument(image string, packages []Package, created time.Time) cycloneDXDoc {
+	doc := cycloneDXDoc{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXVersion,
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: created.UTC().Format(time.RFC3339),
+			Tools:     []cycloneDXTool{{Name: toolName}},
+			Component: cycloneDXComponent{Type: "container", Name: image},
+		},
+		Components: []cycloneDXComponent{},
+	}
+	for _, p := range packages {
+		doc.Components = append(doc.Components, cycloneDXComponent{
+			Type:    "library",
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    p.PURL(),
+		})
+	}
+	return doc
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID as required for CycloneDX serial numbers
+func newUUID() string {
+	b := make([]byte, 16) //nolint:gomnd
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40 //nolint:gomnd
+	b[8] = (b[8] & 0x3f) | 0x80 //nolint:gomnd
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
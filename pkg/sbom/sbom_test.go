@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPackages = []Package{
+	{Name: "requests", Version: "2.28.1", Type: PythonPackage},
+	{Name: "openssl", Version: "3.0.2", Type: DebianPackage},
+	{Name: "apache-airflow", Version: "2.5.1", Type: PythonPackage},
+}
+
+func TestPURL(t *testing.T) {
+	assert.Equal(t, "pkg:pypi/flask@2.2.2", Package{Name: "Flask", Version: "2.2.2", Type: PythonPackage}.PURL())
+	assert.Equal(t, "pkg:deb/debian/openssl@3.0.2", Package{Name: "openssl", Version: "3.0.2", Type: DebianPackage}.PURL())
+	assert.Equal(t, "pkg:generic/foo@1", Package{Name: "foo", Version: "1"}.PURL())
+}
+
+func TestGenerate(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("spdx", func(t *testing.T) {
+		out, err := Generate(SPDX, "test-image", testPackages, created)
+		assert.NoError(t, err)
+
+		var doc spdxDoc
+		assert.NoError(t, json.Unmarshal(out, &doc))
+		assert.Equal(t, spdxVersion, doc.SPDXVersion)
+		assert.Equal(t, "test-image", doc.Name)
+		assert.Equal(t, "2023-01-02T03:04:05Z", doc.CreationInfo.Created)
+		assert.Len(t, doc.Packages, 3)
+		assert.Equal(t, "openssl", doc.Packages[0].Name)
+		assert.Equal(t, "pkg:pypi/apache-airflow@2.5.1", doc.Packages[1].ExternalRefs[0].ReferenceLocator)
+	})
+
+	t.Run("cyclonedx", func(t *testing.T) {
+		out, err := Generate(CycloneDX, "test-image", testPackages, created)
+		assert.NoError(t, err)
+
+		var doc cycloneDXDoc
+		assert.NoError(t, json.Unmarshal(out, &doc))
+		assert.Equal(t, "CycloneDX", doc.BOMFormat)
+		assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, doc.SerialNumber)
+		assert.Equal(t, "test-image", doc.Metadata.Component.Name)
+		assert.Len(t, doc.Components, 3)
+		assert.Equal(t, "pkg:pypi/requests@2.28.1", doc.Components[2].PURL)
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, err := Generate("xml", "test-image", testPackages, created)
+		assert.ErrorIs(t, err, errInvalidFormat)
+	})
+}
+
+func TestScan(t *testing.T) {
+	db := Database{Advisories: []Advisory{
+		{ID: "CVE-1", Type: PythonPackage, Package: "Requests", Severity: "medium", Introduced: "2.0.0", Fixed: "2.31.0"},
+		{ID: "CVE-2", Type: PythonPackage, Package: "requests", Severity: "high", Fixed: "2.20.0"},
+		{ID: "CVE-3", Type: DebianPackage, Package: "openssl", Severity: "critical", Versions: []string{"3.0.2"}},
+		{ID: "CVE-4", Type: PythonPackage, Package: "openssl", Severity: "critical", Versions: []string{"3.0.2"}},
+		{ID: "CVE-5", Type: PythonPackage, Package: "apache_airflow", Severity: "low", Introduced: "2.5.0", Fixed: "2.5.2"},
+	}}
+
+	findings := db.Scan(testPackages)
+	assert.Len(t, findings, 3)
+	assert.Equal(t, "CVE-3", findings[0].ID)
+	assert.Equal(t, SeverityCritical, findings[0].Severity)
+	assert.Equal(t, "CVE-1", findings[1].ID)
+	assert.Equal(t, "2.31.0", findings[1].FixedVersion)
+	assert.Equal(t, "CVE-5", findings[2].ID)
+
+	assert.Len(t, AtOrAbove(findings, "medium"), 2)
+	assert.Len(t, AtOrAbove(findings, "CRITICAL"), 1)
+}
+
+func TestScanDebianVersions(t *testing.T) {
+	db := Database{Advisories: []Advisory{
+		{ID: "CVE-1", Type: DebianPackage, Package: "openssl", Severity: "high", Fixed: "1.1.1n-0+deb11u4"},
+		{ID: "CVE-2", Type: DebianPackage, Package: "openssl", Severity: "high", Fixed: "1.1.1n-0+deb11u3"},
+		{ID: "CVE-3", Type: DebianPackage, Package: "libc6", Severity: "low", Introduced: "2.31-1", Fixed: "2.31-13+deb11u6"},
+	}}
+	packages := []Package{
+		{Name: "openssl", Version: "1.1.1n-0+deb11u3", Type: DebianPackage},
+		{Name: "libc6", Version: "2.31-13+deb11u5", Type: DebianPackage},
+	}
+
+	findings := db.Scan(packages)
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "CVE-1", findings[0].ID)
+	assert.Equal(t, "CVE-3", findings[1].ID)
+}
+
+func TestCompareDebianVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.1.1n-0+deb11u3", "1.1.1n-0+deb11u4", -1},
+		{"1.1.1n-0+deb11u3", "1.1.1n-0+deb11u3", 0},
+		{"1.1.1n-0+deb11u3", "1.1.1k-1", 1},
+		{"2.31-13+deb11u5", "2.31-13+deb11u10", -1},
+		{"1:1.0-1", "2.0-1", 1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		{"1.0-1", "1.0", 1},
+		{"1.01", "1.1", 0},
+		{"1.0a", "1.0+", -1},
+	}
+	for _, tt := range tests {
+		got := compareDebianVersions(tt.a, tt.b)
+		switch {
+		case tt.want < 0:
+			assert.Negative(t, got, "%s < %s", tt.a, tt.b)
+		case tt.want > 0:
+			assert.Positive(t, got, "%s > %s", tt.a, tt.b)
+		default:
+			assert.Zero(t, got, "%s = %s", tt.a, tt.b)
+		}
+	}
+}
+
+func TestValidSeverity(t *testing.T) {
+	assert.NoError(t, ValidSeverity("high"))
+	assert.ErrorIs(t, ValidSeverity("severe"), errInvalidSeverity)
+}
+
+func TestDiff(t *testing.T) {
+	previous := []Package{
+		{Name: "requests", Version: "2.28.1", Type: PythonPackage},
+		{Name: "Flask", Version: "1.1.4", Type: PythonPackage},
+		{Name: "pandas", Version: "1.5.3", Type: PythonPackage},
+		{Name: "six", Version: "1.16.0", Type: PythonPackage},
+	}
+	current := []Package{
+		{Name: "requests", Version: "2.31.0", Type: PythonPackage},
+		{Name: "flask", Version: "2.2.2", Type: PythonPackage},
+		{Name: "pandas", Version: "1.5.3", Type: PythonPackage},
+		{Name: "boto3", Version: "1.26.0", Type: PythonPackage},
+	}
+
+	diff := Diff(previous, current)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []Package{{Name: "boto3", Version: "1.26.0", Type: PythonPackage}}, diff.Added)
+	assert.Equal(t, []Package{{Name: "six", Version: "1.16.0", Type: PythonPackage}}, diff.Removed)
+	assert.Equal(t, []PackageChange{
+		{Name: "flask", From: "1.1.4", To: "2.2.2"},
+		{Name: "requests", From: "2.28.1", To: "2.31.0"},
+	}, diff.Changed)
+	assert.Equal(t, []PackageChange{{Name: "flask", From: "1.1.4", To: "2.2.2"}}, diff.MajorChanges())
+
+	assert.True(t, Diff(previous, previous).Empty())
+}
+
+func TestPackageChange(t *testing.T) {
+	assert.True(t, PackageChange{From: "1.9.0", To: "1.10.0"}.IsUpgrade())
+	assert.False(t, PackageChange{From: "2.0.0", To: "1.10.0"}.IsUpgrade())
+	assert.True(t, PackageChange{From: "2.0.0", To: "1.10.0"}.IsMajor())
+	assert.False(t, PackageChange{From: "not-a-version", To: "1.10.0"}.IsMajor())
+}
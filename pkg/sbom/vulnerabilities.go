@@ -0,0 +1,168 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	goVersion "github.com/hashicorp/go-version"
+)
+
+const (
+	SeverityLow      = "LOW"
+	SeverityMedium   = "MEDIUM"
+	SeverityHigh     = "HIGH"
+	SeverityCritical = "CRITICAL"
+)
+
+var (
+	severityRank = map[string]int{SeverityLow: 1, SeverityMedium: 2, SeverityHigh: 3, SeverityCritical: 4} //nolint:gomnd
+
+	errInvalidSeverity = errors.New("invalid severity, use one of: low, medium, high, critical")
+)
+
+// Advisory is a single entry of the offline vulnerability database. A package version is affected when it is
+// listed in Versions, or when it is at or above Introduced and below Fixed. Versions of Debian packages are ordered
+// like dpkg orders them.
+type Advisory struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Package    string   `json:"package"`
+	Severity   string   `json:"severity"`
+	Summary    string   `json:"summary,omitempty"`
+	Introduced string   `json:"introduced,omitempty"`
+	Fixed      string   `json:"fixed,omitempty"`
+	Versions   []string `json:"versions,omitempty"`
+}
+
+// Finding is an advisory that matched a package installed in the image
+type Finding struct {
+	ID           string `json:"id"`
+	Package      string `json:"package"`
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Severity     string `json:"severity"`
+	FixedVersion string `json:"fixedVersion,omitempty"`
+	Summary      string `json:"summary,omitempty"`
+}
+
+// Database is an offline vulnerability database
+type Database struct {
+	Advisories []Advisory `json:"advisories"`
+}
+
+// LoadDatabase reads a vulnerability database from a JSON file
+func LoadDatabase(path string) (*Database, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading vulnerability database %s: %w", path, err)
+	}
+	var db Database
+	if err := json.Unmarshal(data, &db); err != nil {
+		return nil, fmt.Errorf("error parsing vulnerability database %s: %w", path, err)
+	}
+	return &db, nil
+}
+
+// Scan returns the advisories matching any of the packages, most severe first
+func (db *Database) Scan(packages []Package) []Finding {
+	index := map[string][]Advisory{}
+	for _, a := range db.Advisories {
+		key := packageKey(a.Type, a.Package)
+		index[key] = append(index[key], a)
+	}
+
+	findings := []Finding{}
+	for _, p := range packages {
+		for _, a := range index[packageKey(p.Type, p.Name)] {
+			if !a.affects(p.Version) {
+				continue
+			}
+			findings = append(findings, Finding{
+				ID:           a.ID,
+				Package:      p.Name,
+				Type:         p.Type,
+				Version:      p.Version,
+				Severity:     strings.ToUpper(a.Severity),
+				FixedVersion: a.Fixed,
+				Summary:      a.Summary,
+			})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] > severityRank[findings[j].Severity]
+	})
+	return findings
+}
+
+func (a Advisory) affects(version string) bool {
+	for _, v := range a.Versions {
+		if v == version {
+			return true
+		}
+	}
+	if a.Introduced == "" && a.Fixed == "" {
+		return false
+	}
+	if a.Introduced != "" {
+		c, ok := compareVersions(a.Type, version, a.Introduced)
+		if !ok || c < 0 {
+			return false
+		}
+	}
+	if a.Fixed != "" {
+		c, ok := compareVersions(a.Type, version, a.Fixed)
+		if !ok || c >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares two versions of a package of packageType, it returns false if they can not be compared.
+// Debian versions are compared like dpkg does, other versions as semantic versions.
+func compareVersions(packageType, a, b string) (int, bool) {
+	if packageType == DebianPackage {
+		return compareDebianVersions(a, b), true
+	}
+	versionA, err := goVersion.NewVersion(a)
+	if err != nil {
+		return 0, false
+	}
+	versionB, err := goVersion.NewVersion(b)
+	if err != nil {
+		return 0, false
+	}
+	return versionA.Compare(versionB), true
+}
+
+// python package names are case insensitive and treat - and _ the same
+func packageKey(packageType, name string) string {
+	if packageType == PythonPackage {
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	}
+	return packageType + "/" + name
+}
+
+// ValidSeverity returns an error if severity is not one of the supported levels
+func ValidSeverity(severity string) error {
+	if _, ok := severityRank[strings.ToUpper(severity)]; !ok {
+		return errInvalidSeverity
+	}
+	return nil
+}
+
+// AtOrAbove returns the findings with a severity of at least the given level
+func AtOrAbove(findings []Finding, severity string) []Finding {
+	threshold := severityRank[strings.ToUpper(severity)]
+	matched := []Finding{}
+	for _, f := range findings {
+		if severityRank[f.Severity] >= threshold {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
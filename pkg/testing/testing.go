@@ -9,6 +9,7 @@ import (
 
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/pkg/httputil"
+	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/spf13/afero"
 )
 
@@ -37,9 +38,7 @@ func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 
 // NewTestClient returns *httputil.HTTPClient with Transport replaced to avoid making real calls
 func NewTestClient(fn RoundTripFunc) *httputil.HTTPClient {
-	testClient := httputil.NewHTTPClient()
-	testClient.HTTPClient.Transport = fn
-	return testClient
+	return &httputil.HTTPClient{HTTPClient: &http.Client{Transport: fn}}
 }
 
 func GetEnv(key, fallback string) string {
@@ -140,6 +139,24 @@ func MockUserInput(t *testing.T, i string) func() {
 	return func() { os.Stdin = realStdin }
 }
 
+// MockNoInput closes stdin and disables prompts like --no-input, returns function to defer in the test
+func MockNoInput(t *testing.T) func() {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	r.Close()
+
+	realStdin := os.Stdin
+	os.Stdin = r
+	input.SetNoInput(true)
+	return func() {
+		os.Stdin = realStdin
+		input.SetNoInput(false)
+	}
+}
+
 // This handles a bug in ginkgo when testing cobra commands. Essentially when no arguments are supplied
 // Cobra uses os.Args[1:] as the arguments to the command. This causes a panic when os.Args is empty or
 // if it contains a ginkgo argument.
@@ -21,6 +21,7 @@ const (
 	inputOAuthToken          = "oAuth Token: " //nolint:gosec // false positive
 	inputUsername            = "Username (leave blank for oAuth): "
 	inputPassword            = "Password: "
+	noInputHint              = "log in to Astronomer Software from a terminal"
 	cliChooseWorkspace       = "Please choose a workspace:"
 	cliSetWorkspaceExample   = "\nNo default workspace detected, you can list workspaces with \n\tastro workspace list\nand set your default workspace with \n\tastro workspace switch [WORKSPACEID]\n\n"
 	houstonBasicAuthDisabled = "Basic authentication is disabled, conact administrator or defer back to oAuth"
@@ -48,6 +49,9 @@ var (
 // basicAuth handles authentication with the houston api
 func basicAuth(username, password string, ctx *config.Context, client houston.ClientInterface) (string, error) {
 	if password == "" {
+		if input.NoInput() {
+			return "", input.Required(noInputHint)
+		}
 		password, _ = input.Password(inputPassword)
 	}
 
@@ -75,10 +79,13 @@ var switchToLastUsedWorkspace = func(client houston.ClientInterface, c *config.C
 }
 
 // oAuth handles oAuth with houston api
-func oAuth(oAuthURL string) string {
+func oAuth(oAuthURL string) (string, error) {
+	if input.NoInput() {
+		return "", input.Required(noInputHint)
+	}
 	fmt.Printf("\n" + houstonOAuthRedirect + "\n")
 	fmt.Println(oAuthURL + "\n")
-	return input.Text(inputOAuthToken)
+	return input.Text(inputOAuthToken), nil
 }
 
 // registryAuth authenticates with the private registry
@@ -288,7 +295,10 @@ func getAuthToken(username, password string, authConfig *houston.AuthConfig, ctx
 	var err error
 	if username == "" {
 		if len(authConfig.AuthProviders) > 0 {
-			token = oAuth(ctx.GetSoftwareAppURL() + "/token")
+			token, err = oAuth(ctx.GetSoftwareAppURL() + "/token")
+			if err != nil {
+				return "", err
+			}
 		} else {
 			return "", errOAuthDisabled
 		}
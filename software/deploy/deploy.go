@@ -14,6 +14,7 @@ import (
 	"github.com/astronomer/astro-cli/config"
 	"github.com/astronomer/astro-cli/docker"
 	"github.com/astronomer/astro-cli/houston"
+	"github.com/astronomer/astro-cli/pkg/hooks"
 	"github.com/astronomer/astro-cli/pkg/input"
 	"github.com/astronomer/astro-cli/pkg/printutil"
 )
@@ -21,6 +22,7 @@ import (
 var (
 	// this is used to monkey patch the function in order to write unit test cases
 	imageHandlerInit = airflow.ImageHandlerInit
+	runHooks         = hooks.Run
 
 	dockerfile = "Dockerfile"
 
@@ -115,7 +117,10 @@ func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, by
 		}
 
 		tab.Print(os.Stdout)
-		choice := input.Text("\n> ")
+		choice, err := input.Prompt("\n> ", "pass the ID of the deployment as an argument")
+		if err != nil {
+			return err
+		}
 		selected, ok := deployMap[choice]
 		if !ok {
 			return errInvalidDeploymentSelected
@@ -144,13 +149,30 @@ func Airflow(houstonClient houston.ClientInterface, path, deploymentID, wsID, by
 
 	fmt.Printf(houstonDeploymentPrompt, releaseName)
 
+	deploymentLink := getAirflowUILink(deploymentID, deploymentInfo.Urls)
+	hookEnv := hooks.Env{DeploymentID: deploymentID, DeploymentURL: getDeploymentURL(&c, currentWorkspace.ID, releaseName), AirflowURL: deploymentLink}
+	err = runHooks(hooks.PreBuild, path, hookEnv, os.Stdout)
+	if err != nil {
+		return err
+	}
+
 	// Build the image to deploy
 	err = buildPushDockerImage(houstonClient, &c, deploymentInfo, releaseName, path, nextTag, cloudDomain, byoRegistryDomain, ignoreCacheDeploy, byoRegistryEnabled)
 	if err != nil {
 		return err
 	}
 
-	deploymentLink := getAirflowUILink(deploymentID, deploymentInfo.Urls)
+	// pushing the image is what deploys it on Astronomer Software, so both post hooks run once it is pushed
+	hookEnv.ImageTag = nextTag
+	err = runHooks(hooks.PostPush, path, hookEnv, os.Stdout)
+	if err != nil {
+		return err
+	}
+	err = runHooks(hooks.PostDeploy, path, hookEnv, os.Stdout)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Successfully pushed Docker image to Astronomer registry, it can take a few minutes to update the deployment with the new image. Navigate to the Astronomer UI to confirm the state of your deployment (%s).\n", deploymentLink)
 
 	return nil
@@ -179,7 +201,10 @@ func buildPushDockerImage(houstonClient houston.ClientInterface, c *config.Conte
 
 	image, tag := docker.GetImageTagFromParsedFile(cmds)
 	if config.CFG.ShowWarnings.GetBool() && !validAirflowImageRepo(image) && !validRuntimeImageRepo(image) {
-		i, _ := input.Confirm(fmt.Sprintf(warningInvalidImageName, image))
+		i, err := input.Confirm(fmt.Sprintf(warningInvalidImageName, image))
+		if err != nil {
+			fmt.Println(err)
+		}
 		if !i {
 			fmt.Println("Canceling deploy...")
 			os.Exit(1)
@@ -207,7 +232,10 @@ func buildPushDockerImage(houstonClient houston.ClientInterface, c *config.Conte
 			msg = fmt.Sprintf(warningInvalidNameTagEmptyRecommendations, tag)
 		}
 
-		i, _ := input.Confirm(msg)
+		i, err := input.Confirm(msg)
+		if err != nil {
+			fmt.Println(err)
+		}
 		if !i {
 			fmt.Println("Canceling deploy...")
 			os.Exit(1)
@@ -277,6 +305,14 @@ func validRuntimeImageRepo(image string) bool {
 	return result
 }
 
+// getDeploymentURL returns the link to the deployment in the Astronomer UI
+func getDeploymentURL(c *config.Context, workspaceID, releaseName string) string {
+	if releaseName == "" {
+		return ""
+	}
+	return c.GetSoftwareAppURL() + "/w/" + workspaceID + "/d/" + releaseName
+}
+
 func getAirflowUILink(deploymentID string, deploymentURLs []houston.DeploymentURL) string {
 	if deploymentID == "" {
 		return ""
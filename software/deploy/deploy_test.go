@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/astronomer/astro-cli/context"
 	"github.com/astronomer/astro-cli/houston"
 	houston_mocks "github.com/astronomer/astro-cli/houston/mocks"
+	"github.com/astronomer/astro-cli/pkg/hooks"
 	testUtil "github.com/astronomer/astro-cli/pkg/testing"
 
 	"github.com/spf13/afero"
@@ -248,6 +250,15 @@ func TestGetAirflowUILink(t *testing.T) {
 	assert.Equal(t, expectedResult, actualResult)
 }
 
+func TestGetDeploymentURL(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+	c, err := config.GetCurrentContext()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://app.astronomer_dev.com/w/test-workspace-id/d/test-release", getDeploymentURL(&c, "test-workspace-id", "test-release"))
+	assert.Equal(t, "", getDeploymentURL(&c, "test-workspace-id", ""))
+}
+
 func TestGetAirflowUILinkFailure(t *testing.T) {
 	actualResult := getAirflowUILink("", []houston.DeploymentURL{})
 	assert.Equal(t, actualResult, "")
@@ -351,3 +362,50 @@ func TestAirflowSuccess(t *testing.T) {
 	assert.Nil(t, err)
 	houstonMock.AssertExpectations(t)
 }
+
+func TestAirflowHooks(t *testing.T) {
+	testUtil.InitTestConfig(testUtil.SoftwarePlatform)
+
+	mockImageHandler := new(mocks.ImageHandler)
+	imageHandlerInit = func(image string) airflow.ImageHandler {
+		mockImageHandler.On("Build", mock.Anything, mock.Anything).Return(nil)
+		mockImageHandler.On("Push", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		return mockImageHandler
+	}
+
+	houstonMock := new(houston_mocks.ClientInterface)
+	houstonMock.On("GetWorkspace", mock.Anything).Return(&houston.Workspace{ID: "test-workspace-id"}, nil)
+	houstonMock.On("ListDeployments", mock.Anything).Return([]houston.Deployment{{ID: "test-deployment-id", ReleaseName: "test-release", DeploymentInfo: houston.DeploymentInfo{NextCli: "deploy-2"}}}, nil)
+	houstonMock.On("GetDeploymentConfig", nil).Return(&houston.DeploymentConfig{AirflowImages: mockAirflowImageList}, nil)
+	houstonMock.On("GetDeployment", mock.Anything).Return(&houston.Deployment{Urls: []houston.DeploymentURL{{URL: "https://deployments.local.astronomer.io/test-release/airflow", Type: "airflow"}}}, nil)
+	houstonMock.On("GetRuntimeReleases", "").Return(houston.RuntimeReleases{}, nil)
+
+	defer func() { runHooks = hooks.Run }()
+
+	t.Run("hooks run around the push", func(t *testing.T) {
+		var phases []string
+		var env hooks.Env
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			phases = append(phases, phase)
+			env = e
+			return nil
+		}
+
+		err := Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", false, false, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{hooks.PreBuild, hooks.PostPush, hooks.PostDeploy}, phases)
+		assert.Equal(t, "test-deployment-id", env.DeploymentID)
+		assert.Equal(t, "deploy-2", env.ImageTag)
+		assert.Equal(t, "https://app.astronomer_dev.com/w/test-workspace-id/d/test-release", env.DeploymentURL)
+		assert.Equal(t, "https://deployments.local.astronomer.io/test-release/airflow", env.AirflowURL)
+	})
+
+	t.Run("failing pre build hook aborts the deploy", func(t *testing.T) {
+		runHooks = func(phase, path string, e hooks.Env, out io.Writer) error {
+			return errMockHouston
+		}
+
+		err := Airflow(houstonMock, "./testfiles/", "test-deployment-id", "test-workspace-id", "", false, false, false)
+		assert.ErrorIs(t, err, errMockHouston)
+	})
+}
@@ -81,6 +81,7 @@ func newTableOut() *printutil.Table {
 		Padding:        []int{30, 30, 10, 50, 10, 10},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "DEPLOYMENT NAME", "ASTRO", "DEPLOYMENT ID", "TAG", "IMAGE VERSION"},
+		Fields:         []string{"name", "release_name", "astro_version", "id", "current_tag", "image_version"},
 	}
 }
 
@@ -272,7 +273,10 @@ func getDeploymentSelectionNamespaces(client houston.ClientInterface, out io.Wri
 
 	tab.Print(out)
 
-	in := input.Text("\n> ")
+	in, err := input.Prompt("\n> ", "run the command from a terminal to select a Kubernetes namespace")
+	if err != nil {
+		return "", err
+	}
 	i, err := strconv.ParseInt(in, 10, 64) //nolint:gomnd
 	if err != nil {
 		return "", ErrParsingInt{in: in}
@@ -284,7 +288,10 @@ func getDeploymentSelectionNamespaces(client houston.ClientInterface, out io.Wri
 }
 
 func getDeploymentNamespaceName() (string, error) {
-	namespaceName := input.Text("\nKubernetes Namespace Name: ")
+	namespaceName, err := input.Prompt("\nKubernetes Namespace Name: ", "run the command from a terminal to enter a Kubernetes namespace")
+	if err != nil {
+		return "", err
+	}
 	noSpaceString := strings.ReplaceAll(namespaceName, " ", "")
 	if noSpaceString == "" {
 		return "", ErrKubernetesNamespaceNotSpecified
@@ -630,7 +637,10 @@ func getAirflowVersionSelection(airflowVersion string, client houston.ClientInte
 
 	t.Print(out)
 
-	in := input.Text("\n> ")
+	in, err := input.Prompt("\n> ", "pass --desired-airflow-version")
+	if err != nil {
+		return "", err
+	}
 	i, err := strconv.ParseInt(in, 10, 64)
 	if err != nil {
 		return "", err
@@ -680,7 +690,10 @@ func getRuntimeVersionSelection(runtimeVersion, airflowVersion string, client ho
 
 	t.Print(out)
 
-	in := input.Text("\n> ")
+	in, err := input.Prompt("\n> ", "pass --desired-runtime-version")
+	if err != nil {
+		return "", err
+	}
 	i, err := strconv.ParseInt(in, 10, 64) //nolint:gomnd
 	if err != nil {
 		return "", err
@@ -891,7 +904,10 @@ var SelectDeployment = func(deployments []houston.Deployment, message string) (h
 	}
 
 	tab.Print(os.Stdout)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass --deployment-id")
+	if err != nil {
+		return houston.Deployment{}, err
+	}
 	selected, ok := deployMap[choice]
 	if !ok {
 		return houston.Deployment{}, ErrInvalidDeploymentKey
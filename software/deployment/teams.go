@@ -26,6 +26,7 @@ func ListTeamRoles(deploymentID string, client houston.ClientInterface, out io.W
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"DEPLOYMENT ID", "TEAM ID", "TEAM NAME", "ROLE"},
+		Fields:         []string{"deployment_id", "id", "name", "role"},
 	}
 
 	// Build rows
@@ -36,9 +37,7 @@ func ListTeamRoles(deploymentID string, client houston.ClientInterface, out io.W
 		}
 	}
 
-	tab.Print(out)
-
-	return nil
+	return tab.Print(out)
 }
 
 // AddTeam adds a team to a deployment with specified role
@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	houstonInvalidDeploymentUsersMsg = "No users were found for this deployment.  Check the deploymentId and try again.\n"
+	houstonInvalidDeploymentUsersMsg = "No users were found for this deployment.  Check the deploymentId and try again."
 )
 
 var (
@@ -35,16 +35,13 @@ func UserList(deploymentID, email, userID, fullName string, client houston.Clien
 		return err
 	}
 
-	if len(deploymentUsers) < 1 {
-		_, err = out.Write([]byte(houstonInvalidDeploymentUsersMsg))
-		return err
-	}
-
 	header = []string{"USER ID", "NAME", "EMAIL", "ROLE"}
 	tab = printutil.Table{
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         header,
+		Fields:         []string{"id", "full_name", "email", "role"},
+		NoResultsMsg:   houstonInvalidDeploymentUsersMsg,
 	}
 
 	// Build rows
@@ -55,9 +52,7 @@ func UserList(deploymentID, email, userID, fullName string, client houston.Clien
 		}
 	}
 
-	tab.Print(out)
-
-	return nil
+	return tab.Print(out)
 }
 
 // Add a user to a deployment with specified role
@@ -15,6 +15,7 @@ func newTableOut() *printutil.Table {
 		Padding:        []int{40, 40, 50, 50},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "CATEGORY", "ID", "APIKEY"},
+		Fields:         []string{"name", "category", "id", "api_key"},
 	}
 }
 
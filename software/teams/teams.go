@@ -114,6 +114,7 @@ func List(client houston.ClientInterface, out io.Writer) error {
 		Padding:        []int{50, 50},
 		DynamicPadding: true,
 		Header:         []string{"TEAM ID", "TEAM NAME", "ROLE"},
+		Fields:         []string{"id", "name", "role"},
 		ColorRowCode:   [2]string{"\033[1;32m", "\033[0m"},
 	}
 	for i := range teams {
@@ -132,6 +133,7 @@ func PaginatedList(client houston.ClientInterface, out io.Writer, pageSize, page
 		Padding:        []int{50, 50},
 		DynamicPadding: true,
 		Header:         []string{"TEAM ID", "TEAM NAME", "ROLE"},
+		Fields:         []string{"id", "name", "role"},
 		ColorRowCode:   [2]string{"\033[1;32m", "\033[0m"},
 	}
 	for i := range resp.Teams {
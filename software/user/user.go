@@ -17,9 +17,16 @@ var (
 // Create verifies input before sending a CreateUser API call to houston
 func Create(email, password string, client houston.ClientInterface, out io.Writer) error {
 	if email == "" {
-		email = input.Text("Email: ")
+		var err error
+		email, err = input.Prompt("Email: ", "pass --email")
+		if err != nil {
+			return err
+		}
 	}
 	if password == "" {
+		if input.NoInput() {
+			return input.Required("pass --password")
+		}
 		inputPassword, _ := input.Password("Password: ")
 		inputPassword2, _ := input.Password("Re-enter Password: ")
 		if inputPassword != inputPassword2 {
@@ -21,6 +21,10 @@ type PaginationOptions struct {
 
 // PromptPaginatedOption Show pagination option based on page size and total record
 func PromptPaginatedOption(previousCursorID, nextCursorID string, take, totalRecord, pageNumber int, lastPage bool) PaginationOptions {
+	// quit after the first page when prompts are disabled
+	if input.NoInput() {
+		return PaginationOptions{CursorID: "", PageSize: 0, Quit: true, PageNumber: 0}
+	}
 	for {
 		pageSize := Abs(take)
 		gotoOptionMessage := defaultPaginationOptions
@@ -61,6 +61,7 @@ func ListTeamRoles(workspaceID string, client houston.ClientInterface, out io.Wr
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"WORKSPACE ID", "TEAM ID", "TEAM NAME", "ROLE"},
+		Fields:         []string{"workspace_id", "id", "name", "role"},
 	}
 	for i := range workspaceTeams {
 		role := getWorkspaceLevelRole(workspaceTeams[i].RoleBindings, workspaceID)
@@ -68,8 +69,7 @@ func ListTeamRoles(workspaceID string, client houston.ClientInterface, out io.Wr
 			tab.AddRow([]string{workspaceID, workspaceTeams[i].ID, workspaceTeams[i].Name, role}, false)
 		}
 	}
-	tab.Print(out)
-	return nil
+	return tab.Print(out)
 }
 
 // Update workspace team role
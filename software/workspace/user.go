@@ -67,6 +67,7 @@ func ListRoles(workspaceID string, client houston.ClientInterface, out io.Writer
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"USERNAME", "ID", "ROLE"},
+		Fields:         []string{"username", "id", "role"},
 	}
 	for i := range users {
 		var color bool
@@ -75,8 +76,7 @@ func ListRoles(workspaceID string, client houston.ClientInterface, out io.Writer
 			tab.AddRow([]string{users[i].Username, users[i].ID, role}, color)
 		}
 	}
-	tab.Print(out)
-	return nil
+	return tab.Print(out)
 }
 
 // PaginatedListRoles print users and roles from a workspace
@@ -90,6 +90,7 @@ func PaginatedListRoles(workspaceID, cursorID string, take, pageNumber int, clie
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"USERNAME", "ID", "ROLE"},
+		Fields:         []string{"username", "id", "role"},
 	}
 	for i := range users {
 		var color bool
@@ -41,6 +41,7 @@ func newTableOut() *printutil.Table {
 		Padding:        []int{44, 50},
 		DynamicPadding: true,
 		Header:         []string{"NAME", "ID"},
+		Fields:         []string{"name", "id"},
 		ColorRowCode:   [2]string{"\033[1;32m", "\033[0m"},
 	}
 }
@@ -88,9 +89,7 @@ func List(client houston.ClientInterface, out io.Writer) error {
 		tab.AddRow([]string{name, workspace}, color)
 	}
 
-	tab.Print(out)
-
-	return nil
+	return tab.Print(out)
 }
 
 // Delete a workspace by id
@@ -153,7 +152,10 @@ var GetWorkspaceSelectionID = func(client houston.ClientInterface, out io.Writer
 		deployMap[strconv.Itoa(index)] = ws[i]
 	}
 	tab.Print(out)
-	choice := input.Text("\n> ")
+	choice, err := input.Prompt("\n> ", "pass the ID of the workspace")
+	if err != nil {
+		return "", err
+	}
 	selected, ok := deployMap[choice]
 	if !ok {
 		return "", errInvalidWorkspaceKey
@@ -164,6 +166,10 @@ var GetWorkspaceSelectionID = func(client houston.ClientInterface, out io.Writer
 
 // workspacesPromptPaginatedOption Show pagination option based on page size and total record
 var workspacesPromptPaginatedOption = func(pageSize, pageNumber, totalRecord int) workspacePaginationOptions {
+	// quit without a selection when prompts are disabled
+	if input.NoInput() {
+		return workspacePaginationOptions{pageSize: pageSize, quit: true, pageNumber: pageNumber, userSelection: 0}
+	}
 	for {
 		gotoOptionMessage := defaultWorkspacePaginationOptions
 		gotoOptions := make(map[string]workspacePaginationOptions)
@@ -254,7 +260,10 @@ func getWorkspaceSelection(pageSize, pageNumber int, client houston.ClientInterf
 		return getWorkspaceSelection(selectedOption.pageSize, selectedOption.pageNumber, client, out)
 	}
 
-	in := input.Text("\n> ")
+	in, err := input.Prompt("\n> ", "pass the ID of the workspace")
+	if err != nil {
+		return workspaceSelection{id: "", quit: false, err: err}
+	}
 	i, err := strconv.ParseInt(in, 10, 64) //nolint:gomnd
 	if err != nil {
 		return workspaceSelection{id: "", quit: false, err: fmt.Errorf("cannot parse %s to int: %w", in, err)}